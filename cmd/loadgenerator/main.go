@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryConfig "github.com/amadeusitgroup/kanary/pkg/config"
+	"github.com/amadeusitgroup/kanary/pkg/loadgenerator"
+)
+
+var log = logf.Log.WithName("loadgenerator")
+
+func main() {
+	metricsAddr := flag.String("metrics-addr", ":8080", "address on which the metrics are exposed")
+	flag.Parse()
+
+	logf.SetLogger(logf.ZapLogger(false))
+
+	target := os.Getenv(kanaryConfig.KanaryLoadGeneratorTargetEnvVar)
+	if target == "" {
+		log.Info("target not defined", "envVar", kanaryConfig.KanaryLoadGeneratorTargetEnvVar)
+		os.Exit(1)
+	}
+	spec := &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{}
+	if err := json.Unmarshal([]byte(os.Getenv(kanaryConfig.KanaryLoadGeneratorConfigEnvVar)), spec); err != nil {
+		log.Error(err, "unable to decode the load generator configuration", "envVar", kanaryConfig.KanaryLoadGeneratorConfigEnvVar)
+		os.Exit(1)
+	}
+
+	registry := prometheus.NewRegistry()
+	generator, err := loadgenerator.NewGenerator(target, os.Getenv(kanaryConfig.KanaryLoadGeneratorNameEnvVar), spec, registry)
+	if err != nil {
+		log.Error(err, "unable to create the load generator")
+		os.Exit(1)
+	}
+
+	go func() {
+		http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		if err := http.ListenAndServe(*metricsAddr, nil); err != nil {
+			log.Error(err, "metrics server stopped")
+			os.Exit(1)
+		}
+	}()
+
+	stop := signals.SetupSignalHandler()
+	log.Info("Starting the load generator", "target", target, "rps", *spec.RPS)
+	generator.Run(stop)
+	log.Info("Load generator done, serving the metrics until stopped")
+	<-stop
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/client-go/discovery"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
@@ -27,10 +28,17 @@ import (
 	"github.com/amadeusitgroup/kanary/pkg/apis"
 	kanaryConfig "github.com/amadeusitgroup/kanary/pkg/config"
 	"github.com/amadeusitgroup/kanary/pkg/controller"
+	"github.com/amadeusitgroup/kanary/pkg/verdict"
 )
 
 var log = logf.Log.WithName("cmd")
 
+var (
+	verdictAddr        = flag.String("verdict-addr", "", "address of the external validation verdict endpoint, disabled if empty")
+	verdictTLSCertFile = flag.String("verdict-tls-cert-file", "", "TLS certificate file of the verdict endpoint, served over plain HTTP if not set")
+	verdictTLSKeyFile  = flag.String("verdict-tls-key-file", "", "TLS private key file of the verdict endpoint")
+)
+
 func printVersion() {
 	log.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	log.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
@@ -128,6 +136,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup the external validation verdict endpoint
+	if *verdictAddr != "" {
+		if (*verdictTLSCertFile == "") != (*verdictTLSKeyFile == "") {
+			log.Error(fmt.Errorf("both --verdict-tls-cert-file and --verdict-tls-key-file should be set"), "")
+			os.Exit(1)
+		}
+		// the token Secrets are read without cache, to not watch all the Secrets of the namespace
+		secretReader, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+		if err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+		if err := mgr.Add(verdict.NewServer(*verdictAddr, *verdictTLSCertFile, *verdictTLSKeyFile, mgr.GetClient(), secretReader)); err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
 	log.Info("Starting the Cmd.")
 
 	// Start the Cmd
@@ -5,7 +5,7 @@ go 1.12
 require (
 	cloud.google.com/go v0.34.0 // indirect
 	github.com/blang/semver v0.0.0-20190414102917-ba2c2ddd8906
-	github.com/evanphx/json-patch v4.1.0+incompatible // indirect
+	github.com/evanphx/json-patch v4.1.0+incompatible
 	github.com/ghodss/yaml v1.0.0
 	github.com/go-logr/logr v0.1.0
 	github.com/go-logr/zapr v0.1.0 // indirect
@@ -0,0 +1,22 @@
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConvertV1beta1DeploymentSpec returns the Deployment spec of a KanaryDeployment template converted to the apps/v1 API.
+// The KanaryDeployments created before the apps/v1 migration embed an apps/v1beta1 Deployment spec: it is decoded as is, the
+// v1beta1 rollbackTo field being dropped, but its selector may be missing since apps/v1beta1 defaulted it from the pod template
+// labels, whereas apps/v1 requires it.
+func ConvertV1beta1DeploymentSpec(spec *appsv1.DeploymentSpec) *appsv1.DeploymentSpec {
+	converted := spec.DeepCopy()
+	if converted.Selector == nil && len(converted.Template.Labels) > 0 {
+		matchLabels := map[string]string{}
+		for k, v := range converted.Template.Labels {
+			matchLabels[k] = v
+		}
+		converted.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+	return converted
+}
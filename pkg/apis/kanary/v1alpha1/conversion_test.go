@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConvertV1beta1DeploymentSpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		stored       string
+		wantSelector map[string]string
+	}{
+		{
+			name:         "v1beta1 spec without selector",
+			stored:       `{"spec":{"template":{"spec":{"template":{"metadata":{"labels":{"app":"foo"}}},"rollbackTo":{"revision":2}}}}}`,
+			wantSelector: map[string]string{"app": "foo"},
+		},
+		{
+			name:         "spec with selector",
+			stored:       `{"spec":{"template":{"spec":{"selector":{"matchLabels":{"app":"foo"}},"template":{"metadata":{"labels":{"app":"foo","version":"2"}}}}}}}`,
+			wantSelector: map[string]string{"app": "foo"},
+		},
+		{
+			name:   "spec without pod labels",
+			stored: `{"spec":{"template":{"spec":{"replicas":2}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kd := &KanaryDeployment{}
+			if err := json.Unmarshal([]byte(tt.stored), kd); err != nil {
+				t.Fatalf("unable to decode the stored KanaryDeployment: %v", err)
+			}
+			storedSelector := kd.Spec.Template.Spec.Selector
+			got := ConvertV1beta1DeploymentSpec(&kd.Spec.Template.Spec)
+			if kd.Spec.Template.Spec.Selector != storedSelector {
+				t.Errorf("ConvertV1beta1DeploymentSpec() modified the KanaryDeployment template")
+			}
+			if tt.wantSelector == nil {
+				if got.Selector != nil {
+					t.Errorf("ConvertV1beta1DeploymentSpec() selector = %v, want nil", got.Selector)
+				}
+				return
+			}
+			if got.Selector == nil || !reflect.DeepEqual(got.Selector.MatchLabels, tt.wantSelector) {
+				t.Errorf("ConvertV1beta1DeploymentSpec() selector = %v, want %v", got.Selector, tt.wantSelector)
+			}
+		})
+	}
+}
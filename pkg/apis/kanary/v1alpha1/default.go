@@ -26,7 +26,34 @@ func IsDefaultedKanaryDeployment(kd *KanaryDeployment) bool {
 	if !IsDefaultedKanaryDeploymentSpecValidationList(&kd.Spec.Validations) {
 		return false
 	}
+	if kd.Spec.Promotion != nil && !isDefaultedKanaryDeploymentSpecPromotion(kd.Spec.Promotion) {
+		return false
+	}
+	if kd.Spec.BlueGreen != nil && kd.Spec.BlueGreen.RollbackWindow == nil {
+		return false
+	}
+	if kd.Spec.StatefulSet != nil && kd.Spec.StatefulSet.CanaryReplicas == nil {
+		return false
+	}
+	if kd.Spec.DaemonSet != nil && len(kd.Spec.DaemonSet.NodeSelector) == 0 && kd.Spec.DaemonSet.NodePercent == nil {
+		return false
+	}
+	if kd.Spec.Patch != nil && kd.Spec.Patch.Type == "" {
+		return false
+	}
+
+	return true
+}
 
+func isDefaultedKanaryDeploymentSpecPromotion(p *KanaryDeploymentSpecPromotion) bool {
+	if len(p.Steps) == 0 || p.StepDuration == nil {
+		return false
+	}
+	for _, v := range p.Items {
+		if isInit := IsDefaultedKanaryDeploymentSpecValidation(&v); !isInit {
+			return false
+		}
+	}
 	return true
 }
 
@@ -72,11 +99,23 @@ func IsDefaultedKanaryDeploymentSpecTraffic(t *KanaryDeploymentSpecTraffic) bool
 		t.Source == KanaryServiceKanaryDeploymentSpecTrafficSource ||
 		t.Source == BothKanaryDeploymentSpecTrafficSource ||
 		t.Source == MirrorKanaryDeploymentSpecTrafficSource {
-		return true
+		return t.LoadGenerator == nil || isDefaultedKanaryDeploymentSpecTrafficLoadGenerator(t.LoadGenerator)
 	}
 	return false
 }
 
+func isDefaultedKanaryDeploymentSpecTrafficLoadGenerator(lg *KanaryDeploymentSpecTrafficLoadGenerator) bool {
+	if lg.RPS == nil || lg.Image == "" || len(lg.Requests) == 0 {
+		return false
+	}
+	for _, r := range lg.Requests {
+		if r.Method == "" || r.Path == "" {
+			return false
+		}
+	}
+	return true
+}
+
 // IsDefaultedKanaryDeploymentSpecValidation used to know if a KanaryDeploymentSpecValidation is already defaulted
 // returns true if yes, else no
 func IsDefaultedKanaryDeploymentSpecValidationList(list *KanaryDeploymentSpecValidationList) bool {
@@ -99,19 +138,38 @@ func IsDefaultedKanaryDeploymentSpecValidationList(list *KanaryDeploymentSpecVal
 		return false
 	}
 
+	if list.Scoring != nil && (list.Scoring.PassThreshold == nil || list.Scoring.MarginalThreshold == nil) {
+		return false
+	}
+
+	if list.EarlySuccess != nil && (list.EarlySuccess.ConsecutivePasses == nil || list.EarlySuccess.PrometheusService == "" || (list.EarlySuccess.SamplesQuery != "" && list.EarlySuccess.MinSamples == nil)) {
+		return false
+	}
+
 	for _, v := range list.Items {
 		if isInit := IsDefaultedKanaryDeploymentSpecValidation(&v); !isInit {
 			return false
 		}
 	}
 
+	if list.PostPromotion != nil {
+		if list.PostPromotion.Period == nil {
+			return false
+		}
+		for _, v := range list.PostPromotion.Items {
+			if isInit := IsDefaultedKanaryDeploymentSpecValidation(&v); !isInit {
+				return false
+			}
+		}
+	}
+
 	return true
 }
 
 // IsDefaultedKanaryDeploymentSpecValidation used to know if a KanaryDeploymentSpecValidation is already defaulted
 // returns true if yes, else no
 func IsDefaultedKanaryDeploymentSpecValidation(v *KanaryDeploymentSpecValidation) bool {
-	if v.Manual == nil && v.LabelWatch == nil && v.PromQL == nil {
+	if v.Manual == nil && v.LabelWatch == nil && v.PromQL == nil && v.PodHealth == nil && v.Logs == nil && v.Job == nil && v.Alerts == nil && v.External == nil && v.SLO == nil {
 		return false
 	}
 
@@ -129,9 +187,47 @@ func IsDefaultedKanaryDeploymentSpecValidation(v *KanaryDeploymentSpecValidation
 		}
 	}
 
+	if v.PodHealth != nil {
+		if !isDefaultedKanaryDeploymentSpecValidationPodHealth(v.PodHealth) {
+			return false
+		}
+	}
+
+	if v.Logs != nil {
+		if !isDefaultedKanaryDeploymentSpecValidationLogs(v.Logs) {
+			return false
+		}
+	}
+
+	if v.Alerts != nil && v.Alerts.AlertmanagerService == "" {
+		return false
+	}
+
+	if v.External != nil && v.External.StatusAfterDeadline == "" {
+		return false
+	}
+
+	if v.SLO != nil && (v.SLO.PrometheusService == "" || len(v.SLO.Windows) == 0) {
+		return false
+	}
+
+	return true
+}
+
+func isDefaultedKanaryDeploymentSpecValidationLogs(l *KanaryDeploymentSpecValidationLogs) bool {
+	if l.Window == nil || (l.MaxCount == nil && l.MaxRatePercent == nil) {
+		return false
+	}
+	if l.StableComparison != nil && l.StableComparison.MaxIncreasePercent == nil {
+		return false
+	}
 	return true
 }
 
+func isDefaultedKanaryDeploymentSpecValidationPodHealth(ph *KanaryDeploymentSpecValidationPodHealth) bool {
+	return ph.MaxRestarts != nil && ph.NotReadyGracePeriod != nil && ph.MaxWarningEvents != nil
+}
+
 func isDefaultedKanaryDeploymentSpecValidationPromQL(pq *KanaryDeploymentSpecValidationPromQL) bool {
 	if pq.PrometheusService == "" {
 		return false
@@ -139,6 +235,12 @@ func isDefaultedKanaryDeploymentSpecValidationPromQL(pq *KanaryDeploymentSpecVal
 	if pq.PodNameKey == "" {
 		return false
 	}
+	if pq.MinSamples == nil || pq.NoDataPolicy == "" {
+		return false
+	}
+	if pq.Exclusion != nil && pq.Exclusion.TrafficQuery != "" && pq.Exclusion.MinTraffic == nil {
+		return false
+	}
 	if pq.DiscreteValueOutOfList != nil && !isDefaultedKanaryDeploymentSpecValidationPromQLDiscrete(pq.DiscreteValueOutOfList) {
 		return false
 	}
@@ -177,6 +279,39 @@ func defaultKanaryDeploymentSpec(spec *KanaryDeploymentSpec) {
 	defaultKanaryDeploymentSpecScale(&spec.Scale)
 	defaultKanaryDeploymentSpecTraffic(&spec.Traffic)
 	defaultKanaryDeploymentSpecValidationList(&spec.Validations)
+	defaultKanaryDeploymentSpecPromotion(spec.Promotion)
+	if spec.BlueGreen != nil && spec.BlueGreen.RollbackWindow == nil {
+		spec.BlueGreen.RollbackWindow = &metav1.Duration{
+			Duration: 10 * time.Minute,
+		}
+	}
+	if spec.StatefulSet != nil && spec.StatefulSet.CanaryReplicas == nil {
+		spec.StatefulSet.CanaryReplicas = NewInt32(1)
+	}
+	if spec.DaemonSet != nil && len(spec.DaemonSet.NodeSelector) == 0 && spec.DaemonSet.NodePercent == nil {
+		spec.DaemonSet.NodePercent = NewInt32(10)
+	}
+	if spec.Patch != nil && spec.Patch.Type == "" {
+		spec.Patch.Type = StrategicMergeKanaryDeploymentSpecPatchType
+	}
+}
+
+func defaultKanaryDeploymentSpecPromotion(p *KanaryDeploymentSpecPromotion) {
+	if p == nil {
+		return
+	}
+	if len(p.Steps) == 0 {
+		p.Steps = []int32{25, 50, 75}
+	}
+	if p.StepDuration == nil {
+		p.StepDuration = &metav1.Duration{
+			Duration: 5 * time.Minute,
+		}
+	}
+	for id, value := range p.Items {
+		defaultKanaryDeploymentSpecValidation(&value)
+		p.Items[id] = value
+	}
 }
 
 func defaultKanaryDeploymentSpecScale(s *KanaryDeploymentSpecScale) {
@@ -230,6 +365,29 @@ func defaultKanaryDeploymentSpecTraffic(t *KanaryDeploymentSpecTraffic) {
 	if t.Mirror != nil {
 		defaultKanaryDeploymentSpecScaleTrafficMirror(t.Mirror)
 	}
+	if t.LoadGenerator != nil {
+		defaultKanaryDeploymentSpecTrafficLoadGenerator(t.LoadGenerator)
+	}
+}
+
+func defaultKanaryDeploymentSpecTrafficLoadGenerator(lg *KanaryDeploymentSpecTrafficLoadGenerator) {
+	if lg.RPS == nil {
+		lg.RPS = NewInt32(10)
+	}
+	if lg.Image == "" {
+		lg.Image = "kanaryoperator/loadgenerator:latest"
+	}
+	if len(lg.Requests) == 0 {
+		lg.Requests = []LoadGeneratorRequest{{}}
+	}
+	for i := range lg.Requests {
+		if lg.Requests[i].Method == "" {
+			lg.Requests[i].Method = "GET"
+		}
+		if lg.Requests[i].Path == "" {
+			lg.Requests[i].Path = "/"
+		}
+	}
 }
 
 func defaultKanaryDeploymentSpecScaleTrafficMirror(t *KanaryDeploymentSpecTrafficMirror) {
@@ -256,6 +414,27 @@ func defaultKanaryDeploymentSpecValidationList(list *KanaryDeploymentSpecValidat
 		}
 	}
 
+	if list.Scoring != nil {
+		if list.Scoring.PassThreshold == nil {
+			list.Scoring.PassThreshold = NewInt32(95)
+		}
+		if list.Scoring.MarginalThreshold == nil {
+			list.Scoring.MarginalThreshold = NewInt32(75)
+		}
+	}
+
+	if list.EarlySuccess != nil {
+		if list.EarlySuccess.ConsecutivePasses == nil {
+			list.EarlySuccess.ConsecutivePasses = NewInt32(5)
+		}
+		if list.EarlySuccess.PrometheusService == "" {
+			list.EarlySuccess.PrometheusService = "prometheus:9090"
+		}
+		if list.EarlySuccess.SamplesQuery != "" && list.EarlySuccess.MinSamples == nil {
+			list.EarlySuccess.MinSamples = NewFloat64(1)
+		}
+	}
+
 	if list.Items == nil || len(list.Items) == 0 {
 		list.Items = []KanaryDeploymentSpecValidation{
 			{},
@@ -265,10 +444,22 @@ func defaultKanaryDeploymentSpecValidationList(list *KanaryDeploymentSpecValidat
 		defaultKanaryDeploymentSpecValidation(&value)
 		list.Items[id] = value
 	}
+
+	if list.PostPromotion != nil {
+		if list.PostPromotion.Period == nil {
+			list.PostPromotion.Period = &metav1.Duration{
+				Duration: 10 * time.Minute,
+			}
+		}
+		for id, value := range list.PostPromotion.Items {
+			defaultKanaryDeploymentSpecValidation(&value)
+			list.PostPromotion.Items[id] = value
+		}
+	}
 }
 
 func defaultKanaryDeploymentSpecValidation(v *KanaryDeploymentSpecValidation) {
-	if v.Manual == nil && v.LabelWatch == nil && v.PromQL == nil {
+	if v.Manual == nil && v.LabelWatch == nil && v.PromQL == nil && v.PodHealth == nil && v.Logs == nil && v.Job == nil && v.Alerts == nil && v.External == nil && v.SLO == nil {
 		defaultKanaryDeploymentSpecScaleValidationManual(v)
 	}
 	if v.Manual != nil {
@@ -280,6 +471,60 @@ func defaultKanaryDeploymentSpecValidation(v *KanaryDeploymentSpecValidation) {
 		defaultKanaryDeploymentSpecValidationPromQL(v.PromQL)
 
 	}
+	if v.PodHealth != nil {
+		defaultKanaryDeploymentSpecValidationPodHealth(v.PodHealth)
+	}
+	if v.Logs != nil {
+		defaultKanaryDeploymentSpecValidationLogs(v.Logs)
+	}
+	if v.Alerts != nil && v.Alerts.AlertmanagerService == "" {
+		v.Alerts.AlertmanagerService = "alertmanager:9093"
+	}
+	if v.External != nil && v.External.StatusAfterDeadline == "" {
+		v.External.StatusAfterDeadline = InvalidKanaryDeploymentSpecValidationManualDeadineStatus
+	}
+	if v.SLO != nil {
+		defaultKanaryDeploymentSpecValidationSLO(v.SLO)
+	}
+}
+
+func defaultKanaryDeploymentSpecValidationSLO(slo *KanaryDeploymentSpecValidationSLO) {
+	if slo.PrometheusService == "" {
+		slo.PrometheusService = "prometheus:9090"
+	}
+	if len(slo.Windows) == 0 {
+		// page-level alerting windows of the multi-window multi-burn-rate recommendation
+		slo.Windows = []SLOBurnRateWindow{
+			{LongWindow: metav1.Duration{Duration: time.Hour}, ShortWindow: metav1.Duration{Duration: 5 * time.Minute}, BurnRateFactor: NewFloat64(14.4)},
+			{LongWindow: metav1.Duration{Duration: 6 * time.Hour}, ShortWindow: metav1.Duration{Duration: 30 * time.Minute}, BurnRateFactor: NewFloat64(6)},
+		}
+	}
+}
+func defaultKanaryDeploymentSpecValidationLogs(l *KanaryDeploymentSpecValidationLogs) {
+	if l.Window == nil {
+		l.Window = &metav1.Duration{
+			Duration: time.Minute,
+		}
+	}
+	if l.MaxCount == nil && l.MaxRatePercent == nil {
+		l.MaxCount = NewInt32(0)
+	}
+	if l.StableComparison != nil && l.StableComparison.MaxIncreasePercent == nil {
+		l.StableComparison.MaxIncreasePercent = NewFloat64(0)
+	}
+}
+func defaultKanaryDeploymentSpecValidationPodHealth(ph *KanaryDeploymentSpecValidationPodHealth) {
+	if ph.MaxRestarts == nil {
+		ph.MaxRestarts = NewInt32(0)
+	}
+	if ph.NotReadyGracePeriod == nil {
+		ph.NotReadyGracePeriod = &metav1.Duration{
+			Duration: 2 * time.Minute,
+		}
+	}
+	if ph.MaxWarningEvents == nil {
+		ph.MaxWarningEvents = NewInt32(5)
+	}
 }
 func defaultKanaryDeploymentSpecValidationPromQL(pq *KanaryDeploymentSpecValidationPromQL) {
 	if pq.PrometheusService == "" {
@@ -288,6 +533,15 @@ func defaultKanaryDeploymentSpecValidationPromQL(pq *KanaryDeploymentSpecValidat
 	if pq.PodNameKey == "" {
 		pq.PodNameKey = "pod"
 	}
+	if pq.MinSamples == nil {
+		pq.MinSamples = NewInt32(1)
+	}
+	if pq.NoDataPolicy == "" {
+		pq.NoDataPolicy = PassNoDataPolicy
+	}
+	if pq.Exclusion != nil && pq.Exclusion.TrafficQuery != "" && pq.Exclusion.MinTraffic == nil {
+		pq.Exclusion.MinTraffic = NewFloat64(1)
+	}
 	if pq.ContinuousValueDeviation != nil {
 		defaultKanaryDeploymentSpecValidationPromQLContinuous(pq.ContinuousValueDeviation)
 	}
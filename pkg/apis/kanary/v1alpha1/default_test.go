@@ -204,6 +204,8 @@ func TestDefaultKanaryDeployment(t *testing.T) {
 									ContinuousValueDeviation: &ContinuousValueDeviation{
 										MaxDeviationPercent: NewFloat64(10),
 									},
+									MinSamples:   NewInt32(1),
+									NoDataPolicy: PassNoDataPolicy,
 								},
 							},
 						},
@@ -357,6 +359,37 @@ func Test_defaultKanaryDeploymentSpecValidationList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "early success with a samples query",
+			list: &KanaryDeploymentSpecValidationList{
+				Items:        []KanaryDeploymentSpecValidation{{}},
+				EarlySuccess: &KanaryDeploymentSpecValidationEarlySuccess{SamplesQuery: "sum(requests_total)"},
+			},
+			want: &KanaryDeploymentSpecValidationList{
+				ValidationPeriod: &metav1.Duration{
+					Duration: 15 * time.Minute,
+				},
+				InitialDelay: &metav1.Duration{
+					Duration: 0 * time.Minute,
+				},
+				MaxIntervalPeriod: &metav1.Duration{
+					Duration: 20 * time.Second,
+				},
+				Items: []KanaryDeploymentSpecValidation{
+					{
+						Manual: &KanaryDeploymentSpecValidationManual{
+							StatusAfterDealine: NoneKanaryDeploymentSpecValidationManualDeadineStatus,
+						},
+					},
+				},
+				EarlySuccess: &KanaryDeploymentSpecValidationEarlySuccess{
+					ConsecutivePasses: NewInt32(5),
+					PrometheusService: "prometheus:9090",
+					SamplesQuery:      "sum(requests_total)",
+					MinSamples:        NewFloat64(1),
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,11 +1,13 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/autoscaling/v2beta1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func init() {
@@ -47,6 +49,9 @@ type KanaryDeploymentSpec struct {
 	ServiceName string `json:"serviceName,omitempty"`
 	// Template  is the object that describes the deployment that will be created.
 	Template DeploymentTemplate `json:"template,omitempty"`
+	// Patch if defined, the template is not given but derived from the main Deployment: the patch is applied to the
+	// main Deployment spec at the run start. The main Deployment must exist.
+	Patch *KanaryDeploymentSpecPatch `json:"patch,omitempty"`
 	// Scale is the scaling configuration for the canary deployment
 	Scale KanaryDeploymentSpecScale `json:"scale,omitempty"`
 	// Traffic is the scaling configuration for the canary deployment
@@ -55,6 +60,104 @@ type KanaryDeploymentSpec struct {
 	Validations KanaryDeploymentSpecValidationList `json:"validations,omitempty"`
 	// Schedule helps you to define when that canary deployment should start. RFC3339 = "2006-01-02T15:04:05Z07:00" "2006-01-02T15:04:05Z"
 	Schedule string `json:"schedule,omiempty"`
+	// Paused if true, the validation is frozen: the validation clock is stopped and the scale and traffic are held as they are.
+	Paused bool `json:"paused,omitempty"`
+	// Abort if true, the KanaryDeployment fails immediately and the canary is cleaned up as after a validation failure.
+	Abort bool `json:"abort,omitempty"`
+	// Restart any new value starts a new run of the KanaryDeployment, like a deployment template update.
+	// The previous run outcome is archived in the status history.
+	Restart string `json:"restart,omitempty"`
+	// Promotion if defined, the Deployment is promoted progressively after a successful validation, instead of being updated in one shot.
+	Promotion *KanaryDeploymentSpecPromotion `json:"promotion,omitempty"`
+	// BlueGreen if defined, the KanaryDeployment runs in blue/green mode: the canary Deployment is deployed at full size behind the
+	// kanary service, and the service is switched to the canary pods once validated.
+	BlueGreen *KanaryDeploymentSpecBlueGreen `json:"blueGreen,omitempty"`
+	// Variants additional candidate templates, validated at the same time as the template with the same validation items.
+	// Each variant runs in its own canary Deployment, behind its own kanary service.
+	Variants []KanaryDeploymentSpecVariant `json:"variants,omitempty"`
+	// PromoteBestVariant if true, the Deployment is updated with the best-scoring candidate among the template and the variants,
+	// and the KanaryDeployment fails only if all the candidates fail. Otherwise the variants verdicts are only reported.
+	PromoteBestVariant bool `json:"promoteBestVariant,omitempty"`
+	// StatefulSet if defined, the KanaryDeployment targets this StatefulSet instead of a Deployment: the pod template of the
+	// template is rolled out to the highest ordinals of the StatefulSet with a partitioned rolling update, and validated there.
+	StatefulSet *KanaryDeploymentSpecStatefulSet `json:"statefulSet,omitempty"`
+	// DaemonSet if defined, the KanaryDeployment targets this DaemonSet instead of a Deployment: the pod template of the template
+	// runs in a canary DaemonSet on a subset of the nodes, excluded from the DaemonSet, and is validated there.
+	DaemonSet *KanaryDeploymentSpecDaemonSet `json:"daemonSet,omitempty"`
+}
+
+// KanaryDeploymentSpecPatch defines the changes applied to the main Deployment spec to derive the template
+type KanaryDeploymentSpecPatch struct {
+	// Type of the spec patch: "strategic-merge" or "json". Default value is "strategic-merge".
+	Type KanaryDeploymentSpecPatchType `json:"type,omitempty"`
+	// Spec patch of the main Deployment spec: a strategic merge patch object, or a JSON patch operations array
+	Spec *runtime.RawExtension `json:"spec,omitempty"`
+	// Images new image of the containers by container name, set after the spec patch
+	Images map[string]string `json:"images,omitempty"`
+}
+
+// KanaryDeploymentSpecPatchType defines the type of the spec patch
+type KanaryDeploymentSpecPatchType string
+
+const (
+	// StrategicMergeKanaryDeploymentSpecPatchType the spec patch is a strategic merge patch
+	StrategicMergeKanaryDeploymentSpecPatchType KanaryDeploymentSpecPatchType = "strategic-merge"
+	// JSONKanaryDeploymentSpecPatchType the spec patch is a JSON patch (RFC 6902)
+	JSONKanaryDeploymentSpecPatchType KanaryDeploymentSpecPatchType = "json"
+)
+
+// KanaryDeploymentSpecDaemonSet defines the canary of a DaemonSet. The canary nodes are labelled by the controller, the DaemonSet
+// pods are moved away from them with a node affinity, and a canary DaemonSet runs the pod template of the KanaryDeployment template
+// on them. On success the DaemonSet is updated with the pod template, on failure the DaemonSet pod template is restored.
+type KanaryDeploymentSpecDaemonSet struct {
+	// Name of the DaemonSet
+	Name string `json:"name"`
+	// NodeSelector labels selecting the canary nodes
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// NodePercent percentage, in ]0:100[, of the DaemonSet nodes selected as canary nodes by name order, if the nodeSelector
+	// is not defined. Default value is 10.
+	NodePercent *int32 `json:"nodePercent,omitempty"`
+}
+
+// KanaryDeploymentSpecStatefulSet defines the canary of a StatefulSet. The StatefulSet pod template is updated with the pod template
+// of the KanaryDeployment template, and its rolling update partition is set so only the highest ordinals are updated.
+// On success the partition is lowered to zero, on failure the previous pod template and update strategy are restored.
+type KanaryDeploymentSpecStatefulSet struct {
+	// Name of the StatefulSet
+	Name string `json:"name"`
+	// CanaryReplicas number of pods, with the highest ordinals, updated during the validation. Default value is 1.
+	CanaryReplicas *int32 `json:"canaryReplicas,omitempty"`
+}
+
+// KanaryDeploymentSpecVariant defines a candidate template validated in parallel of the KanaryDeployment template.
+// Variants require the "kanary-service" traffic source and the static scale.
+type KanaryDeploymentSpecVariant struct {
+	// Name of the variant, used as suffix of its canary Deployment and kanary service names
+	Name string `json:"name"`
+	// Template is the object that describes the canary Deployment of the variant
+	Template DeploymentTemplate `json:"template"`
+}
+
+// KanaryDeploymentSpecBlueGreen defines the blue/green mode. The canary Deployment runs the template replicas, and the versions are never
+// mixed behind the service: its selector is switched atomically from the Deployment pods to the canary pods, then back to the Deployment
+// pods once the Deployment is updated and its rollout is complete. The blue/green mode requires the "kanary-service" traffic source.
+type KanaryDeploymentSpecBlueGreen struct {
+	// RollbackWindow duration after the service switch while the previous Deployment pods are kept. During the window, setting
+	// abort to true switches the service back to them. At the end of the window, the Deployment is updated. Default value is 10m.
+	RollbackWindow *metav1.Duration `json:"rollbackWindow,omitempty"`
+}
+
+// KanaryDeploymentSpecPromotion defines the progressive promotion: the canary Deployment is scaled up step by step while the
+// Deployment is scaled down, and the Deployment template is updated only after the last step.
+// At each step, the validation items are evaluated against the canary pods every MaxIntervalPeriod, the first failure reverts the promotion.
+// The progressive promotion requires the static scale.
+type KanaryDeploymentSpecPromotion struct {
+	// Steps increasing percentages, in ]0:100[, of the Deployment replicas run by the canary Deployment at each step. Default value is [25, 50, 75].
+	Steps []int32 `json:"steps,omitempty"`
+	// StepDuration duration of each step. Default value is 5m.
+	StepDuration *metav1.Duration `json:"stepDuration,omitempty"`
+	// Items list of validation items evaluated at each step. Only the promQL, podHealth, logs, alerts and slo items are supported.
+	Items []KanaryDeploymentSpecValidation `json:"items,omitempty"`
 }
 
 // KanaryDeploymentSpecScale defines the scale configuration for the canary deployment
@@ -102,6 +205,9 @@ type KanaryDeploymentSpecTraffic struct {
 	KanaryService string `json:"kanaryService,omitempty"`
 	// Mirror
 	Mirror *KanaryDeploymentSpecTrafficMirror `json:"mirror,omitempty"`
+	// LoadGenerator if defined, a load generator Deployment is created to send synthetic traffic to the kanary service
+	// during the validation. Only available with the "kanary-service" and "both" traffic sources.
+	LoadGenerator *KanaryDeploymentSpecTrafficLoadGenerator `json:"loadGenerator,omitempty"`
 }
 
 // KanaryDeploymentSpecTrafficSource defines the traffic source that targets the canary deployment pods
@@ -127,6 +233,34 @@ type KanaryDeploymentSpecTrafficMirror struct {
 	Activate bool `json:"activate"`
 }
 
+// MaxLoadGeneratorRPS maximum number of requests per second sent by the load generator
+const MaxLoadGeneratorRPS int32 = 10000
+
+// KanaryDeploymentSpecTrafficLoadGenerator defines the synthetic load generator configuration
+// The load generator exposes its own latency and error metrics on its /metrics endpoint.
+type KanaryDeploymentSpecTrafficLoadGenerator struct {
+	// Requests list of requests sent in round-robin to the kanary service. Default value is a single "GET /" request.
+	Requests []LoadGeneratorRequest `json:"requests,omitempty"`
+	// RPS target number of requests per second, at most MaxLoadGeneratorRPS. Default value is 10.
+	RPS *int32 `json:"rps,omitempty"`
+	// Duration how long the load generator sends requests. If not set, the requests are sent until the end of the validation.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Headers HTTP headers added to all the requests.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Image load generator container image. Default value is "kanaryoperator/loadgenerator:latest".
+	Image string `json:"image,omitempty"`
+}
+
+// LoadGeneratorRequest defines a request sent by the load generator
+type LoadGeneratorRequest struct {
+	// Method HTTP method. Default value is "GET".
+	Method string `json:"method,omitempty"`
+	// Path URL path, including the query string.
+	Path string `json:"path,omitempty"`
+	// Body request body.
+	Body string `json:"body,omitempty"`
+}
+
 // KanaryDeploymentSpecValidationList define list of KanaryDeploymentSpecValidation
 type KanaryDeploymentSpecValidationList struct {
 	// InitialDelay duration after the KanaryDeployment has started before validation checks is started.
@@ -139,15 +273,98 @@ type KanaryDeploymentSpecValidationList struct {
 	NoUpdate bool `json:"noUpdate,omitempty"`
 	// Items list of KanaryDeploymentSpecValidation
 	Items []KanaryDeploymentSpecValidation `json:"items,omitempty"`
+	// Scoring if defined, the validation items results are aggregated in a weighted score, instead of failing
+	// the KanaryDeployment as soon as one validation item fails.
+	Scoring *KanaryDeploymentSpecValidationScoring `json:"scoring,omitempty"`
+	// Timeout maximum duration of each evaluation of the validation items, which run concurrently. Default value is 1m.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// EarlySuccess if defined, the KanaryDeployment succeeds before the end of the validation period once enough evidence has been collected.
+	EarlySuccess *KanaryDeploymentSpecValidationEarlySuccess `json:"earlySuccess,omitempty"`
+	// PostPromotion if defined, the main Deployment is verified after its update with the kanary template,
+	// and it is rolled back to its previous template if the verification fails.
+	PostPromotion *KanaryDeploymentSpecValidationPostPromotion `json:"postPromotion,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationPostPromotion defines the verification of the main Deployment after the promotion of the kanary.
+// The validation items are evaluated against the main Deployment pods every MaxIntervalPeriod, during the Period.
+type KanaryDeploymentSpecValidationPostPromotion struct {
+	// Period duration of the post-promotion verification. Default value is 10m.
+	Period *metav1.Duration `json:"period,omitempty"`
+	// Items list of validation items evaluated against the main Deployment. Only the promQL, podHealth, alerts and slo items are supported.
+	Items []KanaryDeploymentSpecValidation `json:"items,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationEarlySuccess defines the early success rule: the KanaryDeployment succeeds when all the validation items
+// passed for ConsecutivePasses evaluations, and when the kanary served at least MinSamples samples.
+// The rule is not applied if a manual or an external validation item is defined.
+type KanaryDeploymentSpecValidationEarlySuccess struct {
+	// ConsecutivePasses number of consecutive evaluations where all the validation items passed with enough data. Default value is 5.
+	ConsecutivePasses *int32 `json:"consecutivePasses,omitempty"`
+	// PrometheusService Prometheus service used by the SamplesQuery. Default value is "prometheus:9090".
+	PrometheusService string `json:"prometheusService,omitempty"`
+	// SamplesQuery promQL query template returning the number of samples (requests, events...) served by the kanary.
+	// The template can use {{.Namespace}}, {{.Deployment}}, {{.KanaryDeployment}} and {{.Pods}}. If empty, the sample volume is not checked.
+	SamplesQuery string `json:"samplesQuery,omitempty"`
+	// MinSamples minimum number of samples returned by the SamplesQuery. Default value is 1 if the SamplesQuery is defined.
+	MinSamples *float64 `json:"minSamples,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationScoring defines the score thresholds
+// At each evaluation the score is computed from 0 to 100 with the weight of the succeeded validation items.
+type KanaryDeploymentSpecValidationScoring struct {
+	// PassThreshold minimum score of the last evaluation, at the end of the validation period, to succeed. Default value is 95.
+	PassThreshold *int32 `json:"passThreshold,omitempty"`
+	// MarginalThreshold score under which the KanaryDeployment fails immediately. Default value is 75.
+	MarginalThreshold *int32 `json:"marginalThreshold,omitempty"`
 }
 
 // KanaryDeploymentSpecValidation defines the validation configuration for the canary deployment
 type KanaryDeploymentSpecValidation struct {
+	// Weight weight of the validation item in the score. Default value is 1. Only used if the validation scoring is defined.
+	Weight *int32 `json:"weight,omitempty"`
+	// Critical if true, the failure of this validation item fails the KanaryDeployment whatever the score.
+	// Only used if the validation scoring is defined.
+	Critical bool `json:"critical,omitempty"`
+	// Timeout maximum duration of the evaluation of this validation item, bounded by the validation list Timeout.
+	// A timed-out item neither fails nor validates the KanaryDeployment, it is reported in the InsufficientData condition.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// ErrorPolicy defines how the provider errors of this validation item (unreachable Prometheus...) are handled.
+	ErrorPolicy *KanaryDeploymentSpecValidationErrorPolicy `json:"errorPolicy,omitempty"`
+
 	Manual     *KanaryDeploymentSpecValidationManual     `json:"manual,omitempty"`
 	LabelWatch *KanaryDeploymentSpecValidationLabelWatch `json:"labelWatch,omitempty"`
 	PromQL     *KanaryDeploymentSpecValidationPromQL     `json:"promQL,omitempty"`
+	PodHealth  *KanaryDeploymentSpecValidationPodHealth  `json:"podHealth,omitempty"`
+	Logs       *KanaryDeploymentSpecValidationLogs       `json:"logs,omitempty"`
+	Job        *KanaryDeploymentSpecValidationJob        `json:"job,omitempty"`
+	Alerts     *KanaryDeploymentSpecValidationAlerts     `json:"alerts,omitempty"`
+	External   *KanaryDeploymentSpecValidationExternal   `json:"external,omitempty"`
+	SLO        *KanaryDeploymentSpecValidationSLO        `json:"slo,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationErrorPolicy defines how the provider errors of a validation item are handled.
+// While the number of consecutive errors is below MaxConsecutiveErrors, the validation item is inconclusive: it neither
+// fails nor validates the KanaryDeployment, which can not succeed until a conclusive evaluation.
+type KanaryDeploymentSpecValidationErrorPolicy struct {
+	// MaxConsecutiveErrors number of consecutive evaluations in error tolerated before applying the Action. Default value is 3.
+	MaxConsecutiveErrors *int32 `json:"maxConsecutiveErrors,omitempty"`
+	// Action applied once MaxConsecutiveErrors is reached. Default value is Pause.
+	Action ErrorPolicyAction `json:"action,omitempty"`
 }
 
+// ErrorPolicyAction defines the action applied when a validation item reached its maximum number of consecutive errors
+type ErrorPolicyAction string
+
+const (
+	// FailErrorPolicyAction the validation item fails
+	FailErrorPolicyAction ErrorPolicyAction = "Fail"
+	// PassErrorPolicyAction the validation item is considered as succeeded
+	PassErrorPolicyAction ErrorPolicyAction = "Pass"
+	// PauseErrorPolicyAction the validation item stays inconclusive, the KanaryDeployment waits for the provider
+	// recovery or for a human decision
+	PauseErrorPolicyAction ErrorPolicyAction = "Pause"
+)
+
 // KanaryDeploymentSpecValidationManual defines the manual validation configuration
 type KanaryDeploymentSpecValidationManual struct {
 	StatusAfterDealine KanaryDeploymentSpecValidationManualDeadineStatus `json:"statusAfterDeadline,omitempty"`
@@ -188,6 +405,114 @@ type KanaryDeploymentSpecValidationLabelWatch struct {
 	DeploymentInvalidationLabels *metav1.LabelSelector `json:"deploymentInvalidationLabels,omitempty"`
 }
 
+// KanaryDeploymentSpecValidationPodHealth defines the podHealth validation configuration
+// A canary pod in CrashLoopBackOff or with a container terminated by OOMKilled always invalidates the canary deployment.
+type KanaryDeploymentSpecValidationPodHealth struct {
+	// MaxRestarts defines the maximum number of restarts tolerated for a canary pod container. Default value is 0.
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+	// NotReadyGracePeriod defines how long a canary pod can stay not Ready before invalidating the canary deployment. Default value is 2m.
+	NotReadyGracePeriod *metav1.Duration `json:"notReadyGracePeriod,omitempty"`
+	// MaxWarningEvents defines the maximum number of Warning events tolerated for a canary pod. Default value is 5.
+	MaxWarningEvents *int32 `json:"maxWarningEvents,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationJob defines the job validation configuration
+// The Job is created when the validation starts, and the canary deployment is invalidated if the Job fails,
+// or if it is still running at the end of the validation period.
+type KanaryDeploymentSpecValidationJob struct {
+	// Template batch/v1 Job template. The kanary service information is injected as environment variables in the Job containers:
+	// KANARY_SERVICE_NAME, KANARY_SERVICE_HOST, KANARY_SERVICE_PORT and KANARY_SERVICE_URL.
+	// If the Job spec.activeDeadlineSeconds is not set, the validation period is used.
+	Template JobTemplate `json:"template"`
+}
+
+// KanaryDeploymentSpecValidationAlerts defines the alerts validation configuration
+// The Alertmanager v2 API is queried for the active alerts matching all the Matchers, and the canary deployment
+// is invalidated as soon as one of them is firing.
+type KanaryDeploymentSpecValidationAlerts struct {
+	// AlertmanagerService Alertmanager service address (host:port). Default value is "alertmanager:9093".
+	AlertmanagerService string `json:"alertmanagerService"`
+	// Matchers list of alert label matchers. The values are go templates that can use {{.Namespace}}, {{.Deployment}}
+	// (the canary Deployment name), {{.KanaryDeployment}} and {{.Pods}} (the canary pod names, as a regular expression alternation).
+	Matchers []AlertMatcher `json:"matchers"`
+	// IncludeSilenced if true, the silenced and inhibited alerts are also taken into account. Default value is false.
+	IncludeSilenced bool `json:"includeSilenced,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationExternal defines the external validation configuration
+// An external system (CI pipeline, QA tool...) posts its verdict for the KanaryDeployment on the operator verdict endpoint,
+// authenticated with the bearer token stored in the TokenSecret.
+type KanaryDeploymentSpecValidationExternal struct {
+	// TokenSecret name of the Secret, in the KanaryDeployment namespace, containing the bearer token (key "token") expected by the verdict endpoint
+	TokenSecret string `json:"tokenSecret"`
+	// StatusAfterDeadline defines the validation status if no pass or fail verdict has been received at the end of the validation period:
+	// "none" waits for a verdict, "valid" or "invalid". Default value is "invalid".
+	StatusAfterDeadline KanaryDeploymentSpecValidationManualDeadineStatus `json:"statusAfterDeadline,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationSLO defines the SLO burn-rate validation configuration
+// The error-budget burn rate of the canary is computed over each pair of Windows, and the canary deployment is invalidated
+// when the burn rates over both the long and the short window of a pair are above the pair BurnRateFactor (multi-window multi-burn-rate).
+type KanaryDeploymentSpecValidationSLO struct {
+	// PrometheusService Prometheus service address (host:port). Default value is "prometheus:9090".
+	PrometheusService string `json:"prometheusService"`
+	// GoodEventsQuery promQL query returning the number of good events of the canary over {{.Window}},
+	// for instance: sum(increase(http_requests_total{pod=~"{{.Pods}}",code!~"5.."}[{{.Window}}])).
+	// The query is a go template that can also use {{.Namespace}}, {{.Deployment}} (the canary Deployment name),
+	// {{.KanaryDeployment}} and {{.Pods}} (the canary pod names, as a regular expression alternation).
+	GoodEventsQuery string `json:"goodEventsQuery"`
+	// TotalEventsQuery promQL query returning the total number of events of the canary over {{.Window}}, same templating as GoodEventsQuery.
+	TotalEventsQuery string `json:"totalEventsQuery"`
+	// Objective SLO objective in percent of good events, for instance 99.9
+	Objective *float64 `json:"objective"`
+	// Windows burn-rate windows. Default value is 1h/5m with a factor of 14.4, and 6h/30m with a factor of 6.
+	Windows []SLOBurnRateWindow `json:"windows,omitempty"`
+}
+
+// SLOBurnRateWindow defines a pair of windows over which the error-budget burn rate is checked
+type SLOBurnRateWindow struct {
+	// LongWindow window detecting a significant budget consumption
+	LongWindow metav1.Duration `json:"longWindow"`
+	// ShortWindow window checking that the budget is still being consumed
+	ShortWindow metav1.Duration `json:"shortWindow"`
+	// BurnRateFactor maximum burn rate: 1 means that the whole error budget would be consumed over the SLO period
+	BurnRateFactor *float64 `json:"burnRateFactor"`
+}
+
+// AlertMatcher defines an alert label matcher
+type AlertMatcher struct {
+	// Name alert label name
+	Name string `json:"name"`
+	// Value alert label value, or regular expression if IsRegex is true
+	Value string `json:"value"`
+	// IsRegex if true the Value is a regular expression
+	IsRegex bool `json:"isRegex,omitempty"`
+}
+
+// KanaryDeploymentSpecValidationLogs defines the logs validation configuration
+// The canary pods logs are read through the pods/log subresource, and the lines matching one of the Patterns
+// are counted over the last Window.
+type KanaryDeploymentSpecValidationLogs struct {
+	// Patterns list of regular expressions, a log line matching at least one of them is counted. Example: ["ERROR", "FATAL", "^\\s+at "]
+	Patterns []string `json:"patterns"`
+	// Container name of the container to read the logs from. If empty, the first container of the pod is used.
+	Container string `json:"container,omitempty"`
+	// Window duration of the time window on which the matching lines are counted. Default value is 1m.
+	Window *metav1.Duration `json:"window,omitempty"`
+	// MaxCount maximum number of matching lines tolerated per canary pod during the Window. Default value is 0 if MaxRatePercent is not set.
+	MaxCount *int32 `json:"maxCount,omitempty"`
+	// MaxRatePercent maximum % of log lines matching the Patterns tolerated per canary pod during the Window.
+	MaxRatePercent *float64 `json:"maxRatePercent,omitempty"`
+	// StableComparison if set, the number of matching lines of the canary pods is also compared with the one of an equal number of stable pods.
+	StableComparison *LogsStableComparison `json:"stableComparison,omitempty"`
+}
+
+// LogsStableComparison defines the comparison of the canary pods logs with the stable pods logs
+type LogsStableComparison struct {
+	// MaxIncreasePercent % of matching lines that the canary pods can log on top of the stable pods. Default value is 0.
+	MaxIncreasePercent *float64 `json:"maxIncreasePercent,omitempty"`
+}
+
 // KanaryDeploymentSpecValidationPromQL defines the promQL validation configuration
 type KanaryDeploymentSpecValidationPromQL struct {
 	PrometheusService string `json:"prometheusService"`
@@ -198,8 +523,42 @@ type KanaryDeploymentSpecValidationPromQL struct {
 	ValueInRange             *ValueInRange             `json:"valueInRange,omitempty"`
 	DiscreteValueOutOfList   *DiscreteValueOutOfList   `json:"discreteValueOutOfList,omitempty"`
 	ContinuousValueDeviation *ContinuousValueDeviation `json:"continuousValueDeviation,omitempty"`
+
+	// MinSamples minimum number of samples a kanary pod should get to be judged. For DiscreteValueOutOfList it is the request volume (sum of the counters), for the other analysers a pod without value has no sample. Default value is 1.
+	MinSamples *int32 `json:"minSamples,omitempty"`
+	// NoDataPolicy defines what to do when a kanary pod did not get MinSamples samples: Pass, Fail or Extend the validation period. Default value is Pass.
+	NoDataPolicy NoDataPolicy `json:"noDataPolicy,omitempty"`
+	// MaxExtension maximum extension of the validation period when NoDataPolicy is Extend. Default value is the validation period.
+	MaxExtension *metav1.Duration `json:"maxExtension,omitempty"`
+	// Exclusion rules of the kanary pods left out of the analysis, for instance pods serving a trickle of requests or just restarted
+	Exclusion *PromQLExclusion `json:"exclusion,omitempty"`
 }
 
+// PromQLExclusion defines the rules excluding kanary pods from the promQL analysis. A pod matching any rule is excluded.
+type PromQLExclusion struct {
+	// MinPodAge the pods (re)started for less than MinPodAge are excluded
+	MinPodAge *metav1.Duration `json:"minPodAge,omitempty"`
+	// LabelSelector the pods matching the label selector are excluded
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// TrafficQuery promQL query returning the traffic of each pod, with the pod name in the PodNameKey label.
+	// The pods with a traffic below MinTraffic, or without value, are excluded.
+	TrafficQuery string `json:"trafficQuery,omitempty"`
+	// MinTraffic minimum traffic returned by TrafficQuery for a pod to be analysed. Default value is 1.
+	MinTraffic *float64 `json:"minTraffic,omitempty"`
+}
+
+// NoDataPolicy defines the behavior of a promQL validation when there is not enough samples to judge a kanary pod
+type NoDataPolicy string
+
+const (
+	// PassNoDataPolicy a kanary pod without enough samples is considered healthy
+	PassNoDataPolicy NoDataPolicy = "Pass"
+	// FailNoDataPolicy a kanary pod without enough samples fails the validation
+	FailNoDataPolicy NoDataPolicy = "Fail"
+	// ExtendNoDataPolicy the validation period is extended, up to MaxExtension, until the kanary pods get enough samples
+	ExtendNoDataPolicy NoDataPolicy = "Extend"
+)
+
 // ValueInRange detect anomaly when the value returned is not inside the defined range
 type ValueInRange struct {
 	Min *float64 `json:"min"` // Min , the lower bound of the range. Default value is 0.0
@@ -228,19 +587,339 @@ type DiscreteValueOutOfList struct {
 
 // KanaryDeploymentStatus defines the observed state of KanaryDeployment
 type KanaryDeploymentStatus struct {
-	// CurrentHash represents the current MD5 spec deployment template hash
+	// CurrentHash represents the current MD5 spec deployment template hash, including the variants templates if any
 	CurrentHash string `json:"currentHash,omitempty"`
+	// Revision number of the current run, incremented by each deployment template update or restart request
+	Revision int32 `json:"revision,omitempty"`
+	// ObservedRestart restart value of the spec that started the current run
+	ObservedRestart string `json:"observedRestart,omitempty"`
+	// History outcome of the previous runs, the oldest first
+	History []KanaryDeploymentStatusRun `json:"history,omitempty"`
 	// Represents the latest available observations of a kanarydeployment's current state.
 	Conditions []KanaryDeploymentCondition `json:"conditions,omitempty"`
 	// Report
 	Report KanaryDeploymentStatusReport `json:"report,omitempty"`
+	// StartTime time when the canary run started, after the scheduling and the canary deployment creation.
+	// The validation timeline is anchored to it.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// PausedDuration accumulated duration of the past pauses, added to the validation timeline
+	PausedDuration *metav1.Duration `json:"pausedDuration,omitempty"`
+	// PauseTime start time of the current pause, only set while the KanaryDeployment is paused
+	PauseTime *metav1.Time `json:"pauseTime,omitempty"`
+	// Score result of the last validation evaluation, only set if the validation scoring is defined.
+	Score *KanaryDeploymentStatusScore `json:"score,omitempty"`
+	// ValidationHistory bounded history of the measurements of each validation item
+	ValidationHistory []KanaryDeploymentStatusValidationHistory `json:"validationHistory,omitempty"`
+	// ExternalVerdict last verdict received from an external system, only set if an external validation is defined.
+	ExternalVerdict *KanaryDeploymentStatusExternalVerdict `json:"externalVerdict,omitempty"`
+	// ExcludedPods kanary pods excluded from the last promQL analysis by the exclusion rules
+	ExcludedPods []KanaryDeploymentStatusExcludedPod `json:"excludedPods,omitempty"`
+	// EarlySuccess consecutive passed evaluations, only set if the early success rule is defined
+	EarlySuccess *KanaryDeploymentStatusEarlySuccess `json:"earlySuccess,omitempty"`
+	// ValidationErrors consecutive provider errors of each validation item, only set while a validation item is in error
+	ValidationErrors []KanaryDeploymentStatusValidationErrors `json:"validationErrors,omitempty"`
+	// PostPromotion progress of the post-promotion verification, only set once the Deployment was updated if it is defined
+	PostPromotion *KanaryDeploymentStatusPostPromotion `json:"postPromotion,omitempty"`
+	// Rollout progress of the Deployment rollout, only set once the Deployment was updated
+	Rollout *KanaryDeploymentStatusRollout `json:"rollout,omitempty"`
+	// Promotion progress of the progressive promotion, only set once the kanary succeeded if it is defined
+	Promotion *KanaryDeploymentStatusPromotion `json:"promotion,omitempty"`
+	// BlueGreen progress of the blue/green switch, only set once the service was switched to the canary pods
+	BlueGreen *KanaryDeploymentStatusBlueGreen `json:"blueGreen,omitempty"`
+	// Variants verdict of each candidate, the template first, only set if variants are defined
+	Variants []KanaryDeploymentStatusVariant `json:"variants,omitempty"`
+	// PromotedVariant name of the candidate selected to update the Deployment, only set if promoteBestVariant is true
+	PromotedVariant string `json:"promotedVariant,omitempty"`
+	// StatefulSet progress of the StatefulSet canary, only set once the StatefulSet was updated if it is defined
+	StatefulSet *KanaryDeploymentStatusStatefulSet `json:"statefulSet,omitempty"`
+	// DaemonSet progress of the DaemonSet canary, only set once the canary nodes were selected if it is defined
+	DaemonSet *KanaryDeploymentStatusDaemonSet `json:"daemonSet,omitempty"`
+	// Patch template resolved from the patch for the run, only set if the patch is defined
+	Patch *KanaryDeploymentStatusPatch `json:"patch,omitempty"`
+}
+
+// KanaryDeploymentStatusPatch defines the template resolved from the patch and the main Deployment spec. It is used for the
+// whole run, so the run doesn't drift with the main Deployment.
+type KanaryDeploymentStatusPatch struct {
+	// Hash of the patch the template was resolved from
+	Hash string `json:"hash"`
+	// TemplateHash hash of the resolved template spec
+	TemplateHash string `json:"templateHash"`
+	// Template resolved template
+	Template DeploymentTemplate `json:"template"`
+	// ResolutionTime time when the template was resolved
+	ResolutionTime metav1.Time `json:"resolutionTime"`
 }
 
+// KanaryDeploymentStatusDaemonSet defines the progress of the DaemonSet canary
+type KanaryDeploymentStatusDaemonSet struct {
+	// Nodes names of the canary nodes
+	Nodes []string `json:"nodes,omitempty"`
+	// PreviousTemplate pod template of the DaemonSet before the canary, restored on failure
+	PreviousTemplate *v1.PodTemplateSpec `json:"previousTemplate,omitempty"`
+	// EndTime time when the DaemonSet was updated or restored
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// KanaryDeploymentStatusStatefulSet defines the progress of the StatefulSet canary
+type KanaryDeploymentStatusStatefulSet struct {
+	// Partition rolling update partition of the StatefulSet during the validation: the pods with an ordinal greater or equal are updated
+	Partition int32 `json:"partition"`
+	// Generation of the StatefulSet updated with the canary pod template
+	Generation int64 `json:"generation,omitempty"`
+	// PreviousTemplate pod template of the StatefulSet before the canary, restored on failure
+	PreviousTemplate *v1.PodTemplateSpec `json:"previousTemplate,omitempty"`
+	// PreviousUpdateStrategy update strategy of the StatefulSet before the canary, restored on failure
+	PreviousUpdateStrategy *appsv1.StatefulSetUpdateStrategy `json:"previousUpdateStrategy,omitempty"`
+	// EndTime time when the StatefulSet was fully updated or restored
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// KanaryDeploymentStatusVariant defines the verdict of a candidate
+type KanaryDeploymentStatusVariant struct {
+	// Name of the variant, "template" for the KanaryDeployment template
+	Name string `json:"name"`
+	// Verdict Running, Passed, Failed or Inconclusive
+	Verdict VariantVerdict `json:"verdict"`
+	// Score score of the last evaluation, only set if the validation scoring is defined
+	Score *int32 `json:"score,omitempty"`
+	// Message failure reason
+	Message string `json:"message,omitempty"`
+}
+
+// VariantVerdict defines the verdict of a candidate
+type VariantVerdict string
+
+const (
+	// TemplateVariantName name of the KanaryDeployment template candidate in the variants verdicts
+	TemplateVariantName = "template"
+
+	// RunningVariantVerdict the candidate is under validation
+	RunningVariantVerdict VariantVerdict = "Running"
+	// PassedVariantVerdict the candidate passed the validation
+	PassedVariantVerdict VariantVerdict = "Passed"
+	// FailedVariantVerdict the candidate failed the validation
+	FailedVariantVerdict VariantVerdict = "Failed"
+	// InconclusiveVariantVerdict the candidate evaluation was still inconclusive at the end of the validation
+	InconclusiveVariantVerdict VariantVerdict = "Inconclusive"
+)
+
+// KanaryDeploymentStatusBlueGreen defines the progress of the blue/green switch
+type KanaryDeploymentStatusBlueGreen struct {
+	// SwitchTime time when the service was switched to the canary pods
+	SwitchTime metav1.Time `json:"switchTime"`
+	// ServiceSelector selector of the service before the switch, restored at the end of the blue/green switch
+	ServiceSelector map[string]string `json:"serviceSelector,omitempty"`
+}
+
+// KanaryDeploymentStatusPromotion defines the progress of the progressive promotion
+type KanaryDeploymentStatusPromotion struct {
+	// Step index of the current step, equal to the number of steps once the last step is completed
+	Step int32 `json:"step"`
+	// StepStartTime time when the current step started
+	StepStartTime metav1.Time `json:"stepStartTime"`
+	// Replicas number of replicas of the Deployment before the promotion, split between the Deployment and the canary Deployment
+	Replicas int32 `json:"replicas"`
+}
+
+// KanaryDeploymentStatusRollout defines the progress of the Deployment rollout after its update
+type KanaryDeploymentStatusRollout struct {
+	// Status RolloutInProgress, RolloutComplete or RolloutFailed
+	Status RolloutStatus `json:"status"`
+	// Message rollout progress, or failure reason
+	Message string `json:"message,omitempty"`
+	// Generation generation of the Deployment once updated with the kanary template
+	Generation int64 `json:"generation,omitempty"`
+	// ObservedGeneration generation of the Deployment observed by the deployment controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas number of pods of the Deployment, old and updated ones
+	Replicas int32 `json:"replicas,omitempty"`
+	// UpdatedReplicas number of pods running the updated template
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+	// ReadyReplicas number of ready pods
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// AvailableReplicas number of available pods
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// LastTransitionTime last time the rollout status changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// RolloutStatus defines the status of the Deployment rollout
+type RolloutStatus string
+
+const (
+	// InProgressRolloutStatus the Deployment pods are being updated
+	InProgressRolloutStatus RolloutStatus = "RolloutInProgress"
+	// CompleteRolloutStatus all the Deployment pods are updated and available
+	CompleteRolloutStatus RolloutStatus = "RolloutComplete"
+	// FailedRolloutStatus the Deployment exceeded its progress deadline
+	FailedRolloutStatus RolloutStatus = "RolloutFailed"
+)
+
+// KanaryDeploymentStatusPostPromotion defines the progress of the post-promotion verification
+type KanaryDeploymentStatusPostPromotion struct {
+	// PromotionTime time when the Deployment was updated with the kanary template
+	PromotionTime metav1.Time `json:"promotionTime"`
+	// PreviousTemplate labels, annotations and spec of the Deployment before its update, restored by a rollback
+	PreviousTemplate *DeploymentTemplate `json:"previousTemplate,omitempty"`
+	// EndTime time when the Deployment was verified or rolled back
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// KanaryDeploymentStatusRun defines the outcome of a previous run of the KanaryDeployment
+type KanaryDeploymentStatusRun struct {
+	// Revision run number
+	Revision int32 `json:"revision"`
+	// Hash deployment template hash of the run
+	Hash string `json:"hash,omitempty"`
+	// StartTime time when the run started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime time when the run was archived
+	EndTime metav1.Time `json:"endTime"`
+	// Outcome status of the run when it was archived: Failed, Succeeded, DeploymentUpdated, RolledBack, or the status of an interrupted run
+	Outcome string `json:"outcome"`
+	// Reason failure reason, or why an unfinished run was interrupted
+	Reason string `json:"reason,omitempty"`
+}
+
+// KanaryDeploymentStatusEarlySuccess defines the progress of the early success rule
+type KanaryDeploymentStatusEarlySuccess struct {
+	// ConsecutivePasses number of consecutive evaluations where all the validation items passed with enough data
+	ConsecutivePasses int32 `json:"consecutivePasses"`
+	// LastPassTime time of the last counted passed evaluation
+	LastPassTime metav1.Time `json:"lastPassTime"`
+}
+
+// KanaryDeploymentStatusValidationErrors defines the consecutive provider errors of a validation item
+type KanaryDeploymentStatusValidationErrors struct {
+	// Validation validation item type
+	Validation string `json:"validation"`
+	// ConsecutiveErrors number of consecutive evaluations in error
+	ConsecutiveErrors int32 `json:"consecutiveErrors,omitempty"`
+	// LastError last provider error
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorTime time of the last counted error
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+}
+
+// KanaryDeploymentStatusExcludedPod defines a kanary pod excluded from the analysis
+type KanaryDeploymentStatusExcludedPod struct {
+	// Pod kanary pod name
+	Pod string `json:"pod"`
+	// Reason exclusion rule matched by the pod
+	Reason string `json:"reason"`
+}
+
+// KanaryDeploymentStatusExternalVerdict defines a verdict received from an external system
+type KanaryDeploymentStatusExternalVerdict struct {
+	// Verdict pass, fail or inconclusive
+	Verdict ExternalVerdict `json:"verdict"`
+	// Reason reason given by the external system
+	Reason string `json:"reason,omitempty"`
+	// Time when the verdict was received
+	Time metav1.Time `json:"time"`
+}
+
+// ExternalVerdict defines the verdict of an external system
+type ExternalVerdict string
+
+const (
+	// PassExternalVerdict the external system validated the canary
+	PassExternalVerdict ExternalVerdict = "pass"
+	// FailExternalVerdict the external system invalidated the canary
+	FailExternalVerdict ExternalVerdict = "fail"
+	// InconclusiveExternalVerdict the external system could not judge the canary, the validation continues
+	InconclusiveExternalVerdict ExternalVerdict = "inconclusive"
+)
+
+// KanaryDeploymentStatusValidationHistory defines the measurement history of a validation item
+type KanaryDeploymentStatusValidationHistory struct {
+	// Validation validation item type
+	Validation string `json:"validation"`
+	// Measurements last measurements of the validation item, the oldest first
+	Measurements []KanaryDeploymentStatusMeasurement `json:"measurements,omitempty"`
+}
+
+// KanaryDeploymentStatusMeasurement defines the result of a validation item evaluation
+type KanaryDeploymentStatusMeasurement struct {
+	// Time of the evaluation
+	Time metav1.Time `json:"time"`
+	// Failed true if the validation item failed
+	Failed bool `json:"failed,omitempty"`
+	// Comment validation item failure comment
+	Comment string `json:"comment,omitempty"`
+	// Values measured for each kanary pod
+	Values []KanaryDeploymentMeasuredValue `json:"values,omitempty"`
+}
+
+// KanaryDeploymentMeasuredValue defines a value measured for a kanary pod
+type KanaryDeploymentMeasuredValue struct {
+	// Pod kanary pod name, empty for a value measured over all the kanary pods
+	Pod string `json:"pod,omitempty"`
+	// Window measurement window, only set for the SLO burn rates
+	Window string `json:"window,omitempty"`
+	// Value measured value
+	Value string `json:"value"`
+	// Failed true if the value is out of bounds
+	Failed bool `json:"failed,omitempty"`
+}
+
+// KanaryDeploymentStatusScore defines the score of a validation evaluation
+type KanaryDeploymentStatusScore struct {
+	// Score from 0 to 100
+	Score int32 `json:"score"`
+	// Outcome Pass, Marginal or Fail, depending of the score thresholds and of the critical validation items
+	Outcome KanaryDeploymentScoreOutcome `json:"outcome"`
+	// Items contribution of each validation item to the score
+	Items []KanaryDeploymentStatusScoreItem `json:"items,omitempty"`
+}
+
+// KanaryDeploymentStatusScoreItem defines the contribution of a validation item to the score
+type KanaryDeploymentStatusScoreItem struct {
+	// Validation validation item type
+	Validation string `json:"validation"`
+	// Weight validation item weight
+	Weight int32 `json:"weight"`
+	// Critical true if the validation item is critical
+	Critical bool `json:"critical,omitempty"`
+	// Failed true if the validation item failed
+	Failed bool `json:"failed,omitempty"`
+	// Contribution score points brought by the validation item
+	Contribution int32 `json:"contribution"`
+	// Comment validation item failure comment
+	Comment string `json:"comment,omitempty"`
+}
+
+// KanaryDeploymentScoreOutcome defines the outcome of a validation score
+type KanaryDeploymentScoreOutcome string
+
+const (
+	// PassKanaryDeploymentScoreOutcome the score is above the pass threshold
+	PassKanaryDeploymentScoreOutcome KanaryDeploymentScoreOutcome = "Pass"
+	// MarginalKanaryDeploymentScoreOutcome the score is between the marginal and the pass thresholds
+	MarginalKanaryDeploymentScoreOutcome KanaryDeploymentScoreOutcome = "Marginal"
+	// FailKanaryDeploymentScoreOutcome the score is below the marginal threshold, or a critical validation item failed
+	FailKanaryDeploymentScoreOutcome KanaryDeploymentScoreOutcome = "Fail"
+)
+
 type KanaryDeploymentStatusReport struct {
 	Status     string `json:"status,omitempty"`
 	Validation string `json:"validation,omitempty"`
 	Scale      string `json:"scale,omitempty"`
 	Traffic    string `json:"traffic,omitempty"`
+	Rollout    string `json:"rollout,omitempty"`
+}
+
+// JobTemplate is the object that describes the validation job that will be created.
+type JobTemplate struct {
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of the Job.
+	// +optional
+	Spec batchv1.JobSpec `json:"spec,omitempty"`
 }
 
 // DeploymentTemplate is the object that describes the deployment that will be created.
@@ -253,7 +932,7 @@ type DeploymentTemplate struct {
 
 	// Specification of the desired behavior of the Deployment.
 	// +optional
-	Spec v1beta1.DeploymentSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	Spec appsv1.DeploymentSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
 }
 
 // KanaryDeploymentCondition describes the state of a deployment at a certain point.
@@ -298,6 +977,20 @@ const (
 	ErroredKanaryDeploymentConditionType KanaryDeploymentConditionType = "Errored"
 	// TrafficServiceKanaryDeploymentConditionType means the KanaryDeployment Traffic strategy is activated
 	TrafficKanaryDeploymentConditionType KanaryDeploymentConditionType = "Traffic"
+	// InsufficientDataKanaryDeploymentConditionType is added in a kanarydeployment when the canary
+	// did not get enough traffic to be judged by a promQL validation.
+	InsufficientDataKanaryDeploymentConditionType KanaryDeploymentConditionType = "InsufficientData"
+	// PausedKanaryDeploymentConditionType is added in a kanarydeployment when its validation is paused.
+	PausedKanaryDeploymentConditionType KanaryDeploymentConditionType = "Paused"
+	// InconclusiveKanaryDeploymentConditionType is added in a kanarydeployment when a validation item
+	// can not be evaluated because of provider errors, the canary can not succeed meanwhile.
+	InconclusiveKanaryDeploymentConditionType KanaryDeploymentConditionType = "Inconclusive"
+	// RolledBackKanaryDeploymentConditionType is added in a kanarydeployment when the post-promotion verification
+	// failed and that the deployment was rolled back to its previous template.
+	RolledBackKanaryDeploymentConditionType KanaryDeploymentConditionType = "RolledBack"
+	// ServiceSwitchedKanaryDeploymentConditionType is added in a kanarydeployment in blue/green mode when the service
+	// targets the canary pods.
+	ServiceSwitchedKanaryDeploymentConditionType KanaryDeploymentConditionType = "ServiceSwitched"
 )
 
 // KanaryDeploymentAnnotationKeyType corresponds to all possible Annotation Keys that can be added/updated by Kanary
@@ -308,6 +1001,10 @@ const (
 	MD5KanaryDeploymentAnnotationKey KanaryDeploymentAnnotationKeyType = "kanary.k8s-operators.dev/md5"
 )
 
+// KanaryDeploymentFinalizer finalizer set on the KanaryDeployments that update resources they don't own,
+// to restore these resources before the KanaryDeployment deletion.
+const KanaryDeploymentFinalizer = "kanary.k8s-operators.dev/cleanup"
+
 const (
 	// KanaryDeploymentIsKanaryLabelKey correspond to the label key used on a deployment to inform
 	// that this instance is used in a canary deployment.
@@ -317,6 +1014,15 @@ const (
 	// KanaryDeploymentActivateLabelKey correspond to the label key used on a pod to inform that this
 	// Pod instance in a canary version of the application.
 	KanaryDeploymentActivateLabelKey = "kanary.k8s-operators.dev/canary-pod"
+	// KanaryDeploymentLoadGeneratorLabelKey correspond to the label key used on the load generator deployment and pods
+	// to provide the KanaryDeployment name.
+	KanaryDeploymentLoadGeneratorLabelKey = "kanary.k8s-operators.dev/loadgenerator"
+	// KanaryDeploymentVariantLabelKey correspond to the label key used on the canary deployment and pods of a variant
+	// to provide the variant name.
+	KanaryDeploymentVariantLabelKey = "kanary.k8s-operators.dev/variant"
+	// KanaryDeploymentCanaryNodeLabelKey correspond to the label key used on the canary nodes of a DaemonSet canary
+	// to provide the KanaryDeployment name.
+	KanaryDeploymentCanaryNodeLabelKey = "kanary.k8s-operators.dev/canary-node"
 	// KanaryDeploymentLabelValueTrue correspond to the label value True used with several Kanary label keys.
 	KanaryDeploymentLabelValueTrue = "true"
 	// KanaryDeploymentLabelValueFalse correspond to the label value False used with several Kanary label keys.
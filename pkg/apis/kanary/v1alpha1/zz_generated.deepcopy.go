@@ -5,11 +5,29 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	v2beta1 "k8s.io/api/autoscaling/v2beta1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertMatcher) DeepCopyInto(out *AlertMatcher) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertMatcher.
+func (in *AlertMatcher) DeepCopy() *AlertMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContinuousValueDeviation) DeepCopyInto(out *ContinuousValueDeviation) {
 	*out = *in
@@ -109,6 +127,24 @@ func (in *HorizontalPodAutoscalerSpec) DeepCopy() *HorizontalPodAutoscalerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplate) DeepCopyInto(out *JobTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplate.
+func (in *JobTemplate) DeepCopy() *JobTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanaryDeployment) DeepCopyInto(out *KanaryDeployment) {
 	*out = *in
@@ -188,13 +224,61 @@ func (in *KanaryDeploymentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentMeasuredValue) DeepCopyInto(out *KanaryDeploymentMeasuredValue) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentMeasuredValue.
+func (in *KanaryDeploymentMeasuredValue) DeepCopy() *KanaryDeploymentMeasuredValue {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentMeasuredValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanaryDeploymentSpec) DeepCopyInto(out *KanaryDeploymentSpec) {
 	*out = *in
 	in.Template.DeepCopyInto(&out.Template)
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = new(KanaryDeploymentSpecPatch)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Scale.DeepCopyInto(&out.Scale)
 	in.Traffic.DeepCopyInto(&out.Traffic)
 	in.Validations.DeepCopyInto(&out.Validations)
+	if in.Promotion != nil {
+		in, out := &in.Promotion, &out.Promotion
+		*out = new(KanaryDeploymentSpecPromotion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(KanaryDeploymentSpecBlueGreen)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make([]KanaryDeploymentSpecVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(KanaryDeploymentSpecStatefulSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(KanaryDeploymentSpecDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -208,6 +292,116 @@ func (in *KanaryDeploymentSpec) DeepCopy() *KanaryDeploymentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecBlueGreen) DeepCopyInto(out *KanaryDeploymentSpecBlueGreen) {
+	*out = *in
+	if in.RollbackWindow != nil {
+		in, out := &in.RollbackWindow, &out.RollbackWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecBlueGreen.
+func (in *KanaryDeploymentSpecBlueGreen) DeepCopy() *KanaryDeploymentSpecBlueGreen {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecBlueGreen)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecDaemonSet) DeepCopyInto(out *KanaryDeploymentSpecDaemonSet) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodePercent != nil {
+		in, out := &in.NodePercent, &out.NodePercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecDaemonSet.
+func (in *KanaryDeploymentSpecDaemonSet) DeepCopy() *KanaryDeploymentSpecDaemonSet {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecDaemonSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecPatch) DeepCopyInto(out *KanaryDeploymentSpecPatch) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecPatch.
+func (in *KanaryDeploymentSpecPatch) DeepCopy() *KanaryDeploymentSpecPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecPromotion) DeepCopyInto(out *KanaryDeploymentSpecPromotion) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepDuration != nil {
+		in, out := &in.StepDuration, &out.StepDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KanaryDeploymentSpecValidation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecPromotion.
+func (in *KanaryDeploymentSpecPromotion) DeepCopy() *KanaryDeploymentSpecPromotion {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecPromotion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanaryDeploymentSpecScale) DeepCopyInto(out *KanaryDeploymentSpecScale) {
 	*out = *in
@@ -255,6 +449,27 @@ func (in *KanaryDeploymentSpecScaleStatic) DeepCopy() *KanaryDeploymentSpecScale
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecStatefulSet) DeepCopyInto(out *KanaryDeploymentSpecStatefulSet) {
+	*out = *in
+	if in.CanaryReplicas != nil {
+		in, out := &in.CanaryReplicas, &out.CanaryReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecStatefulSet.
+func (in *KanaryDeploymentSpecStatefulSet) DeepCopy() *KanaryDeploymentSpecStatefulSet {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecStatefulSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanaryDeploymentSpecTraffic) DeepCopyInto(out *KanaryDeploymentSpecTraffic) {
 	*out = *in
@@ -263,6 +478,11 @@ func (in *KanaryDeploymentSpecTraffic) DeepCopyInto(out *KanaryDeploymentSpecTra
 		*out = new(KanaryDeploymentSpecTrafficMirror)
 		**out = **in
 	}
+	if in.LoadGenerator != nil {
+		in, out := &in.LoadGenerator, &out.LoadGenerator
+		*out = new(KanaryDeploymentSpecTrafficLoadGenerator)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -276,6 +496,44 @@ func (in *KanaryDeploymentSpecTraffic) DeepCopy() *KanaryDeploymentSpecTraffic {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecTrafficLoadGenerator) DeepCopyInto(out *KanaryDeploymentSpecTrafficLoadGenerator) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make([]LoadGeneratorRequest, len(*in))
+		copy(*out, *in)
+	}
+	if in.RPS != nil {
+		in, out := &in.RPS, &out.RPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecTrafficLoadGenerator.
+func (in *KanaryDeploymentSpecTrafficLoadGenerator) DeepCopy() *KanaryDeploymentSpecTrafficLoadGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecTrafficLoadGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanaryDeploymentSpecTrafficMirror) DeepCopyInto(out *KanaryDeploymentSpecTrafficMirror) {
 	*out = *in
@@ -295,6 +553,21 @@ func (in *KanaryDeploymentSpecTrafficMirror) DeepCopy() *KanaryDeploymentSpecTra
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanaryDeploymentSpecValidation) DeepCopyInto(out *KanaryDeploymentSpecValidation) {
 	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ErrorPolicy != nil {
+		in, out := &in.ErrorPolicy, &out.ErrorPolicy
+		*out = new(KanaryDeploymentSpecValidationErrorPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Manual != nil {
 		in, out := &in.Manual, &out.Manual
 		*out = new(KanaryDeploymentSpecValidationManual)
@@ -310,6 +583,36 @@ func (in *KanaryDeploymentSpecValidation) DeepCopyInto(out *KanaryDeploymentSpec
 		*out = new(KanaryDeploymentSpecValidationPromQL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodHealth != nil {
+		in, out := &in.PodHealth, &out.PodHealth
+		*out = new(KanaryDeploymentSpecValidationPodHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(KanaryDeploymentSpecValidationLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(KanaryDeploymentSpecValidationJob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(KanaryDeploymentSpecValidationAlerts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(KanaryDeploymentSpecValidationExternal)
+		**out = **in
+	}
+	if in.SLO != nil {
+		in, out := &in.SLO, &out.SLO
+		*out = new(KanaryDeploymentSpecValidationSLO)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -324,112 +627,419 @@ func (in *KanaryDeploymentSpecValidation) DeepCopy() *KanaryDeploymentSpecValida
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KanaryDeploymentSpecValidationLabelWatch) DeepCopyInto(out *KanaryDeploymentSpecValidationLabelWatch) {
+func (in *KanaryDeploymentSpecValidationAlerts) DeepCopyInto(out *KanaryDeploymentSpecValidationAlerts) {
 	*out = *in
-	if in.PodInvalidationLabels != nil {
-		in, out := &in.PodInvalidationLabels, &out.PodInvalidationLabels
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DeploymentInvalidationLabels != nil {
-		in, out := &in.DeploymentInvalidationLabels, &out.DeploymentInvalidationLabels
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]AlertMatcher, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationLabelWatch.
-func (in *KanaryDeploymentSpecValidationLabelWatch) DeepCopy() *KanaryDeploymentSpecValidationLabelWatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationAlerts.
+func (in *KanaryDeploymentSpecValidationAlerts) DeepCopy() *KanaryDeploymentSpecValidationAlerts {
 	if in == nil {
 		return nil
 	}
-	out := new(KanaryDeploymentSpecValidationLabelWatch)
+	out := new(KanaryDeploymentSpecValidationAlerts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KanaryDeploymentSpecValidationList) DeepCopyInto(out *KanaryDeploymentSpecValidationList) {
+func (in *KanaryDeploymentSpecValidationEarlySuccess) DeepCopyInto(out *KanaryDeploymentSpecValidationEarlySuccess) {
 	*out = *in
-	if in.InitialDelay != nil {
-		in, out := &in.InitialDelay, &out.InitialDelay
-		*out = new(v1.Duration)
+	if in.ConsecutivePasses != nil {
+		in, out := &in.ConsecutivePasses, &out.ConsecutivePasses
+		*out = new(int32)
 		**out = **in
 	}
-	if in.ValidationPeriod != nil {
-		in, out := &in.ValidationPeriod, &out.ValidationPeriod
-		*out = new(v1.Duration)
+	if in.MinSamples != nil {
+		in, out := &in.MinSamples, &out.MinSamples
+		*out = new(float64)
 		**out = **in
 	}
-	if in.MaxIntervalPeriod != nil {
-		in, out := &in.MaxIntervalPeriod, &out.MaxIntervalPeriod
-		*out = new(v1.Duration)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationEarlySuccess.
+func (in *KanaryDeploymentSpecValidationEarlySuccess) DeepCopy() *KanaryDeploymentSpecValidationEarlySuccess {
+	if in == nil {
+		return nil
 	}
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KanaryDeploymentSpecValidation, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(KanaryDeploymentSpecValidationEarlySuccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationErrorPolicy) DeepCopyInto(out *KanaryDeploymentSpecValidationErrorPolicy) {
+	*out = *in
+	if in.MaxConsecutiveErrors != nil {
+		in, out := &in.MaxConsecutiveErrors, &out.MaxConsecutiveErrors
+		*out = new(int32)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationList.
-func (in *KanaryDeploymentSpecValidationList) DeepCopy() *KanaryDeploymentSpecValidationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationErrorPolicy.
+func (in *KanaryDeploymentSpecValidationErrorPolicy) DeepCopy() *KanaryDeploymentSpecValidationErrorPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(KanaryDeploymentSpecValidationList)
+	out := new(KanaryDeploymentSpecValidationErrorPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KanaryDeploymentSpecValidationManual) DeepCopyInto(out *KanaryDeploymentSpecValidationManual) {
+func (in *KanaryDeploymentSpecValidationExternal) DeepCopyInto(out *KanaryDeploymentSpecValidationExternal) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationManual.
-func (in *KanaryDeploymentSpecValidationManual) DeepCopy() *KanaryDeploymentSpecValidationManual {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationExternal.
+func (in *KanaryDeploymentSpecValidationExternal) DeepCopy() *KanaryDeploymentSpecValidationExternal {
 	if in == nil {
 		return nil
 	}
-	out := new(KanaryDeploymentSpecValidationManual)
+	out := new(KanaryDeploymentSpecValidationExternal)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KanaryDeploymentSpecValidationPromQL) DeepCopyInto(out *KanaryDeploymentSpecValidationPromQL) {
+func (in *KanaryDeploymentSpecValidationJob) DeepCopyInto(out *KanaryDeploymentSpecValidationJob) {
 	*out = *in
-	if in.ValueInRange != nil {
-		in, out := &in.ValueInRange, &out.ValueInRange
-		*out = new(ValueInRange)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DiscreteValueOutOfList != nil {
-		in, out := &in.DiscreteValueOutOfList, &out.DiscreteValueOutOfList
-		*out = new(DiscreteValueOutOfList)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ContinuousValueDeviation != nil {
-		in, out := &in.ContinuousValueDeviation, &out.ContinuousValueDeviation
-		*out = new(ContinuousValueDeviation)
-		(*in).DeepCopyInto(*out)
-	}
+	in.Template.DeepCopyInto(&out.Template)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationPromQL.
-func (in *KanaryDeploymentSpecValidationPromQL) DeepCopy() *KanaryDeploymentSpecValidationPromQL {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationJob.
+func (in *KanaryDeploymentSpecValidationJob) DeepCopy() *KanaryDeploymentSpecValidationJob {
 	if in == nil {
 		return nil
 	}
-	out := new(KanaryDeploymentSpecValidationPromQL)
+	out := new(KanaryDeploymentSpecValidationJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationLabelWatch) DeepCopyInto(out *KanaryDeploymentSpecValidationLabelWatch) {
+	*out = *in
+	if in.PodInvalidationLabels != nil {
+		in, out := &in.PodInvalidationLabels, &out.PodInvalidationLabels
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentInvalidationLabels != nil {
+		in, out := &in.DeploymentInvalidationLabels, &out.DeploymentInvalidationLabels
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationLabelWatch.
+func (in *KanaryDeploymentSpecValidationLabelWatch) DeepCopy() *KanaryDeploymentSpecValidationLabelWatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationLabelWatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationList) DeepCopyInto(out *KanaryDeploymentSpecValidationList) {
+	*out = *in
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ValidationPeriod != nil {
+		in, out := &in.ValidationPeriod, &out.ValidationPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxIntervalPeriod != nil {
+		in, out := &in.MaxIntervalPeriod, &out.MaxIntervalPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KanaryDeploymentSpecValidation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Scoring != nil {
+		in, out := &in.Scoring, &out.Scoring
+		*out = new(KanaryDeploymentSpecValidationScoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EarlySuccess != nil {
+		in, out := &in.EarlySuccess, &out.EarlySuccess
+		*out = new(KanaryDeploymentSpecValidationEarlySuccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostPromotion != nil {
+		in, out := &in.PostPromotion, &out.PostPromotion
+		*out = new(KanaryDeploymentSpecValidationPostPromotion)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationList.
+func (in *KanaryDeploymentSpecValidationList) DeepCopy() *KanaryDeploymentSpecValidationList {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationLogs) DeepCopyInto(out *KanaryDeploymentSpecValidationLogs) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRatePercent != nil {
+		in, out := &in.MaxRatePercent, &out.MaxRatePercent
+		*out = new(float64)
+		**out = **in
+	}
+	if in.StableComparison != nil {
+		in, out := &in.StableComparison, &out.StableComparison
+		*out = new(LogsStableComparison)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationLogs.
+func (in *KanaryDeploymentSpecValidationLogs) DeepCopy() *KanaryDeploymentSpecValidationLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationManual) DeepCopyInto(out *KanaryDeploymentSpecValidationManual) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationManual.
+func (in *KanaryDeploymentSpecValidationManual) DeepCopy() *KanaryDeploymentSpecValidationManual {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationManual)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationPodHealth) DeepCopyInto(out *KanaryDeploymentSpecValidationPodHealth) {
+	*out = *in
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NotReadyGracePeriod != nil {
+		in, out := &in.NotReadyGracePeriod, &out.NotReadyGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxWarningEvents != nil {
+		in, out := &in.MaxWarningEvents, &out.MaxWarningEvents
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationPodHealth.
+func (in *KanaryDeploymentSpecValidationPodHealth) DeepCopy() *KanaryDeploymentSpecValidationPodHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationPodHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationPostPromotion) DeepCopyInto(out *KanaryDeploymentSpecValidationPostPromotion) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KanaryDeploymentSpecValidation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationPostPromotion.
+func (in *KanaryDeploymentSpecValidationPostPromotion) DeepCopy() *KanaryDeploymentSpecValidationPostPromotion {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationPostPromotion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationPromQL) DeepCopyInto(out *KanaryDeploymentSpecValidationPromQL) {
+	*out = *in
+	if in.ValueInRange != nil {
+		in, out := &in.ValueInRange, &out.ValueInRange
+		*out = new(ValueInRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiscreteValueOutOfList != nil {
+		in, out := &in.DiscreteValueOutOfList, &out.DiscreteValueOutOfList
+		*out = new(DiscreteValueOutOfList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContinuousValueDeviation != nil {
+		in, out := &in.ContinuousValueDeviation, &out.ContinuousValueDeviation
+		*out = new(ContinuousValueDeviation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinSamples != nil {
+		in, out := &in.MinSamples, &out.MinSamples
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxExtension != nil {
+		in, out := &in.MaxExtension, &out.MaxExtension
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Exclusion != nil {
+		in, out := &in.Exclusion, &out.Exclusion
+		*out = new(PromQLExclusion)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationPromQL.
+func (in *KanaryDeploymentSpecValidationPromQL) DeepCopy() *KanaryDeploymentSpecValidationPromQL {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationPromQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationSLO) DeepCopyInto(out *KanaryDeploymentSpecValidationSLO) {
+	*out = *in
+	if in.Objective != nil {
+		in, out := &in.Objective, &out.Objective
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]SLOBurnRateWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationSLO.
+func (in *KanaryDeploymentSpecValidationSLO) DeepCopy() *KanaryDeploymentSpecValidationSLO {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationSLO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecValidationScoring) DeepCopyInto(out *KanaryDeploymentSpecValidationScoring) {
+	*out = *in
+	if in.PassThreshold != nil {
+		in, out := &in.PassThreshold, &out.PassThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MarginalThreshold != nil {
+		in, out := &in.MarginalThreshold, &out.MarginalThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecValidationScoring.
+func (in *KanaryDeploymentSpecValidationScoring) DeepCopy() *KanaryDeploymentSpecValidationScoring {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecValidationScoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentSpecVariant) DeepCopyInto(out *KanaryDeploymentSpecVariant) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentSpecVariant.
+func (in *KanaryDeploymentSpecVariant) DeepCopy() *KanaryDeploymentSpecVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentSpecVariant)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -437,23 +1047,306 @@ func (in *KanaryDeploymentSpecValidationPromQL) DeepCopy() *KanaryDeploymentSpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KanaryDeploymentStatus) DeepCopyInto(out *KanaryDeploymentStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]KanaryDeploymentCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]KanaryDeploymentStatusRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]KanaryDeploymentCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Report = in.Report
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PausedDuration != nil {
+		in, out := &in.PausedDuration, &out.PausedDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PauseTime != nil {
+		in, out := &in.PauseTime, &out.PauseTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(KanaryDeploymentStatusScore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValidationHistory != nil {
+		in, out := &in.ValidationHistory, &out.ValidationHistory
+		*out = make([]KanaryDeploymentStatusValidationHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalVerdict != nil {
+		in, out := &in.ExternalVerdict, &out.ExternalVerdict
+		*out = new(KanaryDeploymentStatusExternalVerdict)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludedPods != nil {
+		in, out := &in.ExcludedPods, &out.ExcludedPods
+		*out = make([]KanaryDeploymentStatusExcludedPod, len(*in))
+		copy(*out, *in)
+	}
+	if in.EarlySuccess != nil {
+		in, out := &in.EarlySuccess, &out.EarlySuccess
+		*out = new(KanaryDeploymentStatusEarlySuccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]KanaryDeploymentStatusValidationErrors, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostPromotion != nil {
+		in, out := &in.PostPromotion, &out.PostPromotion
+		*out = new(KanaryDeploymentStatusPostPromotion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(KanaryDeploymentStatusRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Promotion != nil {
+		in, out := &in.Promotion, &out.Promotion
+		*out = new(KanaryDeploymentStatusPromotion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(KanaryDeploymentStatusBlueGreen)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make([]KanaryDeploymentStatusVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(KanaryDeploymentStatusStatefulSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(KanaryDeploymentStatusDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = new(KanaryDeploymentStatusPatch)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatus.
+func (in *KanaryDeploymentStatus) DeepCopy() *KanaryDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusBlueGreen) DeepCopyInto(out *KanaryDeploymentStatusBlueGreen) {
+	*out = *in
+	in.SwitchTime.DeepCopyInto(&out.SwitchTime)
+	if in.ServiceSelector != nil {
+		in, out := &in.ServiceSelector, &out.ServiceSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusBlueGreen.
+func (in *KanaryDeploymentStatusBlueGreen) DeepCopy() *KanaryDeploymentStatusBlueGreen {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusBlueGreen)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusDaemonSet) DeepCopyInto(out *KanaryDeploymentStatusDaemonSet) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviousTemplate != nil {
+		in, out := &in.PreviousTemplate, &out.PreviousTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusDaemonSet.
+func (in *KanaryDeploymentStatusDaemonSet) DeepCopy() *KanaryDeploymentStatusDaemonSet {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusDaemonSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusEarlySuccess) DeepCopyInto(out *KanaryDeploymentStatusEarlySuccess) {
+	*out = *in
+	in.LastPassTime.DeepCopyInto(&out.LastPassTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusEarlySuccess.
+func (in *KanaryDeploymentStatusEarlySuccess) DeepCopy() *KanaryDeploymentStatusEarlySuccess {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusEarlySuccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusExcludedPod) DeepCopyInto(out *KanaryDeploymentStatusExcludedPod) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusExcludedPod.
+func (in *KanaryDeploymentStatusExcludedPod) DeepCopy() *KanaryDeploymentStatusExcludedPod {
+	if in == nil {
+		return nil
 	}
-	out.Report = in.Report
+	out := new(KanaryDeploymentStatusExcludedPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusExternalVerdict) DeepCopyInto(out *KanaryDeploymentStatusExternalVerdict) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatus.
-func (in *KanaryDeploymentStatus) DeepCopy() *KanaryDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusExternalVerdict.
+func (in *KanaryDeploymentStatusExternalVerdict) DeepCopy() *KanaryDeploymentStatusExternalVerdict {
 	if in == nil {
 		return nil
 	}
-	out := new(KanaryDeploymentStatus)
+	out := new(KanaryDeploymentStatusExternalVerdict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusMeasurement) DeepCopyInto(out *KanaryDeploymentStatusMeasurement) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]KanaryDeploymentMeasuredValue, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusMeasurement.
+func (in *KanaryDeploymentStatusMeasurement) DeepCopy() *KanaryDeploymentStatusMeasurement {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusMeasurement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusPatch) DeepCopyInto(out *KanaryDeploymentStatusPatch) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	in.ResolutionTime.DeepCopyInto(&out.ResolutionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusPatch.
+func (in *KanaryDeploymentStatusPatch) DeepCopy() *KanaryDeploymentStatusPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusPostPromotion) DeepCopyInto(out *KanaryDeploymentStatusPostPromotion) {
+	*out = *in
+	in.PromotionTime.DeepCopyInto(&out.PromotionTime)
+	if in.PreviousTemplate != nil {
+		in, out := &in.PreviousTemplate, &out.PreviousTemplate
+		*out = new(DeploymentTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusPostPromotion.
+func (in *KanaryDeploymentStatusPostPromotion) DeepCopy() *KanaryDeploymentStatusPostPromotion {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusPostPromotion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusPromotion) DeepCopyInto(out *KanaryDeploymentStatusPromotion) {
+	*out = *in
+	in.StepStartTime.DeepCopyInto(&out.StepStartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusPromotion.
+func (in *KanaryDeploymentStatusPromotion) DeepCopy() *KanaryDeploymentStatusPromotion {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusPromotion)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -474,6 +1367,266 @@ func (in *KanaryDeploymentStatusReport) DeepCopy() *KanaryDeploymentStatusReport
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusRollout) DeepCopyInto(out *KanaryDeploymentStatusRollout) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusRollout.
+func (in *KanaryDeploymentStatusRollout) DeepCopy() *KanaryDeploymentStatusRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusRun) DeepCopyInto(out *KanaryDeploymentStatusRun) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusRun.
+func (in *KanaryDeploymentStatusRun) DeepCopy() *KanaryDeploymentStatusRun {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusScore) DeepCopyInto(out *KanaryDeploymentStatusScore) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KanaryDeploymentStatusScoreItem, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusScore.
+func (in *KanaryDeploymentStatusScore) DeepCopy() *KanaryDeploymentStatusScore {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusScore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusScoreItem) DeepCopyInto(out *KanaryDeploymentStatusScoreItem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusScoreItem.
+func (in *KanaryDeploymentStatusScoreItem) DeepCopy() *KanaryDeploymentStatusScoreItem {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusScoreItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusStatefulSet) DeepCopyInto(out *KanaryDeploymentStatusStatefulSet) {
+	*out = *in
+	if in.PreviousTemplate != nil {
+		in, out := &in.PreviousTemplate, &out.PreviousTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreviousUpdateStrategy != nil {
+		in, out := &in.PreviousUpdateStrategy, &out.PreviousUpdateStrategy
+		*out = new(appsv1.StatefulSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusStatefulSet.
+func (in *KanaryDeploymentStatusStatefulSet) DeepCopy() *KanaryDeploymentStatusStatefulSet {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusStatefulSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusValidationErrors) DeepCopyInto(out *KanaryDeploymentStatusValidationErrors) {
+	*out = *in
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusValidationErrors.
+func (in *KanaryDeploymentStatusValidationErrors) DeepCopy() *KanaryDeploymentStatusValidationErrors {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusValidationErrors)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusValidationHistory) DeepCopyInto(out *KanaryDeploymentStatusValidationHistory) {
+	*out = *in
+	if in.Measurements != nil {
+		in, out := &in.Measurements, &out.Measurements
+		*out = make([]KanaryDeploymentStatusMeasurement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusValidationHistory.
+func (in *KanaryDeploymentStatusValidationHistory) DeepCopy() *KanaryDeploymentStatusValidationHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusValidationHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KanaryDeploymentStatusVariant) DeepCopyInto(out *KanaryDeploymentStatusVariant) {
+	*out = *in
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KanaryDeploymentStatusVariant.
+func (in *KanaryDeploymentStatusVariant) DeepCopy() *KanaryDeploymentStatusVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(KanaryDeploymentStatusVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadGeneratorRequest) DeepCopyInto(out *LoadGeneratorRequest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadGeneratorRequest.
+func (in *LoadGeneratorRequest) DeepCopy() *LoadGeneratorRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadGeneratorRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsStableComparison) DeepCopyInto(out *LogsStableComparison) {
+	*out = *in
+	if in.MaxIncreasePercent != nil {
+		in, out := &in.MaxIncreasePercent, &out.MaxIncreasePercent
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsStableComparison.
+func (in *LogsStableComparison) DeepCopy() *LogsStableComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsStableComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromQLExclusion) DeepCopyInto(out *PromQLExclusion) {
+	*out = *in
+	if in.MinPodAge != nil {
+		in, out := &in.MinPodAge, &out.MinPodAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinTraffic != nil {
+		in, out := &in.MinTraffic, &out.MinTraffic
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromQLExclusion.
+func (in *PromQLExclusion) DeepCopy() *PromQLExclusion {
+	if in == nil {
+		return nil
+	}
+	out := new(PromQLExclusion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLOBurnRateWindow) DeepCopyInto(out *SLOBurnRateWindow) {
+	*out = *in
+	out.LongWindow = in.LongWindow
+	out.ShortWindow = in.ShortWindow
+	if in.BurnRateFactor != nil {
+		in, out := &in.BurnRateFactor, &out.BurnRateFactor
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLOBurnRateWindow.
+func (in *SLOBurnRateWindow) DeepCopy() *SLOBurnRateWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(SLOBurnRateWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueInRange) DeepCopyInto(out *ValueInRange) {
 	*out = *in
@@ -2,3 +2,12 @@ package config
 
 // KanaryStatusSubresourceDisabledEnvVar use to know if status subresource is disabled
 const KanaryStatusSubresourceDisabledEnvVar = "KANARY_STATUS_SUBRESOURCE_DISABLED"
+
+// KanaryLoadGeneratorConfigEnvVar env var containing the load generator configuration, in JSON
+const KanaryLoadGeneratorConfigEnvVar = "KANARY_LOADGENERATOR_CONFIG"
+
+// KanaryLoadGeneratorTargetEnvVar env var containing the load generator target URL
+const KanaryLoadGeneratorTargetEnvVar = "KANARY_LOADGENERATOR_TARGET"
+
+// KanaryLoadGeneratorNameEnvVar env var containing the KanaryDeployment name, used as metrics label by the load generator
+const KanaryLoadGeneratorNameEnvVar = "KANARY_LOADGENERATOR_NAME"
@@ -1,6 +1,8 @@
 package anomalydetector
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
 	kapiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -9,7 +11,17 @@ import (
 
 //AnomalyDetector returns the list of pods that do not behave correctly according to the configuration
 type AnomalyDetector interface {
-	GetPodsOutOfBounds() ([]*kapiv1.Pod, error)
+	GetPodsOutOfBounds(ctx context.Context) ([]*kapiv1.Pod, error)
+}
+
+//InsufficientDataDetector returns the list of pods that did not get enough samples during the last GetPodsOutOfBounds call
+type InsufficientDataDetector interface {
+	GetPodsWithInsufficientData() []*kapiv1.Pod
+}
+
+//MeasuredValuesReporter returns the values measured for each pod, indexed by pod name, during the last GetPodsOutOfBounds call
+type MeasuredValuesReporter interface {
+	GetMeasuredValues() map[string]string
 }
 
 //Config generic part of the configuration for anomalyDetector
@@ -18,21 +30,36 @@ type Config struct {
 	PodLister     kv1.PodNamespaceLister
 	Logger        logr.Logger
 	ExclusionFunc func(*kapiv1.Pod) (bool, error)
+	MinSamples    uint // minimum number of samples for a pod to be judged, 0 disables the check
 }
 
 var _ AnomalyDetector = &Fake{}
+var _ InsufficientDataDetector = &Fake{}
+var _ MeasuredValuesReporter = &Fake{}
 
 //Fake should be used in test to mock an AnomalyDetector
 type Fake struct {
-	Pods []*kapiv1.Pod
-	Err  error
+	Pods             []*kapiv1.Pod
+	InsufficientData []*kapiv1.Pod
+	Values           map[string]string
+	Err              error
 }
 
 //GetPodsOutOfBounds implements AnomalyDetector
-func (f *Fake) GetPodsOutOfBounds() ([]*kapiv1.Pod, error) {
+func (f *Fake) GetPodsOutOfBounds(ctx context.Context) ([]*kapiv1.Pod, error) {
 	return f.Pods, f.Err
 }
 
+//GetPodsWithInsufficientData implements InsufficientDataDetector
+func (f *Fake) GetPodsWithInsufficientData() []*kapiv1.Pod {
+	return f.InsufficientData
+}
+
+//GetMeasuredValues implements MeasuredValuesReporter
+func (f *Fake) GetMeasuredValues() map[string]string {
+	return f.Values
+}
+
 //FakeFactory create a fake anomaly detector factory that return a Fake anomaly detector
 func FakeFactory(p []*kapiv1.Pod, e error) Factory {
 	return func(cfg FactoryConfig) (AnomalyDetector, error) {
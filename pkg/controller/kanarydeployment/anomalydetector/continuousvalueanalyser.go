@@ -1,6 +1,7 @@
 package anomalydetector
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -9,11 +10,13 @@ import (
 )
 
 var _ AnomalyDetector = &ContinuousValueDeviationAnalyser{}
+var _ InsufficientDataDetector = &ContinuousValueDeviationAnalyser{}
+var _ MeasuredValuesReporter = &ContinuousValueDeviationAnalyser{}
 
 //deviationByPodName float64: 1=no deviation at all, 0.2=80% deviation down, 1.7=70% deviation up
 type deviationByPodName map[string]float64
 type continuousValueAnalyser interface {
-	doAnalysis() (deviationByPodName, error)
+	doAnalysis(ctx context.Context) (deviationByPodName, error)
 }
 
 //ContinuousValueDeviationConfig Configuration for ContinuousValueDeviationAnalyser
@@ -26,11 +29,15 @@ type ContinuousValueDeviationAnalyser struct {
 	ConfigSpecific ContinuousValueDeviationConfig
 	ConfigAnalyser Config
 
-	analyser continuousValueAnalyser
+	analyser         continuousValueAnalyser
+	insufficientData []*kapiv1.Pod
+	measuredValues   map[string]string
 }
 
 //GetPodsOutOfBounds implements interface AnomalyDetector
-func (d *ContinuousValueDeviationAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, error) {
+func (d *ContinuousValueDeviationAnalyser) GetPodsOutOfBounds(ctx context.Context) ([]*kapiv1.Pod, error) {
+	d.insufficientData = nil
+	d.measuredValues = map[string]string{}
 	listOfPods, err := d.ConfigAnalyser.PodLister.List(d.ConfigAnalyser.Selector)
 	if err != nil {
 		return nil, fmt.Errorf("can't list pods, error:%v", err)
@@ -45,11 +52,27 @@ func (d *ContinuousValueDeviationAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod,
 	}
 	result := []*kapiv1.Pod{}
 
-	deviationByPods, err := d.analyser.doAnalysis()
+	deviationByPods, err := d.analyser.doAnalysis(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	//check if the key is GlobalKeyQuery that means that the result is applicable to all pods
+	if len(deviationByPods) == 1 {
+		if v, ok := deviationByPods[GlobalQueryKey]; ok {
+			for _, pod := range podByName {
+				deviationByPods[pod.Name] = v
+			}
+			delete(deviationByPods, GlobalQueryKey)
+		}
+	}
+
+	samplesByPodName := map[string]uint{}
+	for podName := range deviationByPods {
+		samplesByPodName[podName] = 1
+	}
+	d.insufficientData = podsWithInsufficientData(podByName, podWithNoTraffic, samplesByPodName, d.ConfigAnalyser.MinSamples)
+
 	if len(deviationByPods) == 0 {
 		return result, nil
 	}
@@ -61,22 +84,15 @@ func (d *ContinuousValueDeviationAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod,
 		return nil, zeroErr
 	}
 
-	//check if the key is GlobalKeyQuery that means that the result is applicable to all pods
-	if len(deviationByPods) == 1 {
-		if v, ok := deviationByPods[GlobalQueryKey]; ok {
-			for _, pod := range podByName {
-				deviationByPods[pod.Name] = v
-			}
-			delete(deviationByPods, GlobalQueryKey)
-		}
-	}
-
 	for podName, deviation := range deviationByPods {
 		_, found := podWithNoTraffic[podName]
 		if found {
 			continue
 		}
 
+		if _, ok := podByName[podName]; ok {
+			d.measuredValues[podName] = fmt.Sprintf("%.2f of the average", deviation)
+		}
 		if math.Abs(1-deviation) > maxDeviation {
 			if p, ok := podByName[podName]; ok {
 				// Only keeping known pod with too big deviation
@@ -86,3 +102,13 @@ func (d *ContinuousValueDeviationAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod,
 	}
 	return result, nil
 }
+
+//GetPodsWithInsufficientData implements interface InsufficientDataDetector
+func (d *ContinuousValueDeviationAnalyser) GetPodsWithInsufficientData() []*kapiv1.Pod {
+	return d.insufficientData
+}
+
+//GetMeasuredValues implements interface MeasuredValuesReporter
+func (d *ContinuousValueDeviationAnalyser) GetMeasuredValues() map[string]string {
+	return d.measuredValues
+}
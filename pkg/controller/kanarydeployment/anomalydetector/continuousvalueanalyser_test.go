@@ -1,6 +1,7 @@
 package anomalydetector
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -108,13 +109,67 @@ func TestContinuousValueDeviationAnalyser_GetPodsOutOfBounds(t *testing.T) {
 				},
 				analyser: tt.fields.analyser,
 			}
-			got, err := d.GetPodsOutOfBounds()
+			got, err := d.GetPodsOutOfBounds(context.Background())
 			if (err != nil) != tt.wantErr {
-				t.Errorf("ContinuousValueDeviationAnalyser.GetPodsOutOfBounds() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ContinuousValueDeviationAnalyser.GetPodsOutOfBounds(context.Background()) error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("ContinuousValueDeviationAnalyser.GetPodsOutOfBounds() len[%d] = %v, \n want  len[%d] = %v", len(got), got, len(tt.want), tt.want)
+				t.Errorf("ContinuousValueDeviationAnalyser.GetPodsOutOfBounds(context.Background()) len[%d] = %v, \n want  len[%d] = %v", len(got), got, len(tt.want), tt.want)
+			}
+		})
+	}
+}
+
+func TestContinuousValueDeviationAnalyser_GetPodsWithInsufficientData(t *testing.T) {
+	podLister := test.NewTestPodNamespaceLister(
+		[]*kapiv1.Pod{
+			test.PodGen("A", "test-ns", map[string]string{"app": "foo"}, nil, true, true),
+			test.PodGen("B", "test-ns", map[string]string{"app": "foo"}, nil, true, true),
+		}, "test-ns")
+
+	tests := []struct {
+		name               string
+		deviationByPodName deviationByPodName
+		want               []string
+	}{
+		{
+			name:               "no value at all",
+			deviationByPodName: deviationByPodName{},
+			want:               []string{"A", "B"},
+		},
+		{
+			name:               "one pod without value",
+			deviationByPodName: deviationByPodName{"A": 1.0},
+			want:               []string{"B"},
+		},
+		{
+			name:               "global query",
+			deviationByPodName: deviationByPodName{GlobalQueryKey: 1.0},
+			want:               []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &ContinuousValueDeviationAnalyser{
+				ConfigSpecific: ContinuousValueDeviationConfig{MaxDeviationPercent: 50},
+				ConfigAnalyser: Config{
+					Selector:   labels.Everything(),
+					PodLister:  podLister,
+					Logger:     logf.Log,
+					MinSamples: 1,
+				},
+				analyser: &testContinuousValueAnalyser{deviationByPodName: tt.deviationByPodName},
+			}
+			if _, err := d.GetPodsOutOfBounds(context.Background()); err != nil {
+				t.Fatalf("ContinuousValueDeviationAnalyser.GetPodsOutOfBounds(context.Background()) error = %v", err)
+			}
+			got := []string{}
+			for _, p := range d.GetPodsWithInsufficientData() {
+				got = append(got, p.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContinuousValueDeviationAnalyser.GetPodsWithInsufficientData() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -122,7 +177,7 @@ func TestContinuousValueDeviationAnalyser_GetPodsOutOfBounds(t *testing.T) {
 
 type testErrorContinuousValueAnalyser struct{}
 
-func (t *testErrorContinuousValueAnalyser) doAnalysis() (deviationByPodName, error) {
+func (t *testErrorContinuousValueAnalyser) doAnalysis(ctx context.Context) (deviationByPodName, error) {
 	return nil, fmt.Errorf("error")
 }
 
@@ -130,6 +185,6 @@ type testContinuousValueAnalyser struct {
 	deviationByPodName
 }
 
-func (t *testContinuousValueAnalyser) doAnalysis() (deviationByPodName, error) {
+func (t *testContinuousValueAnalyser) doAnalysis(ctx context.Context) (deviationByPodName, error) {
 	return t.deviationByPodName, nil
 }
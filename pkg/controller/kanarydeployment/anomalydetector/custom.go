@@ -1,6 +1,7 @@
 package anomalydetector
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
@@ -37,8 +38,12 @@ func (c *CustomAnomalyDetector) init() {
 }
 
 //GetPodsOutOfBounds implements the anomaly detector interface
-func (c *CustomAnomalyDetector) GetPodsOutOfBounds() ([]*kapiv1.Pod, error) {
-	response, err := c.client.Get("http://" + c.serviceURI)
+func (c *CustomAnomalyDetector) GetPodsOutOfBounds(ctx context.Context) ([]*kapiv1.Pod, error) {
+	request, err := http.NewRequest(http.MethodGet, "http://"+c.serviceURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error while building custom server request: %v", err)
+	}
+	response, err := c.client.Do(request.WithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("Error while contacting custom server: %v", err)
 	}
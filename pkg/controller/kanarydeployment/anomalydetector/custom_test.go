@@ -1,6 +1,7 @@
 package anomalydetector
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -128,16 +129,16 @@ func TestCustomAnomalyDetector_GetPodsOutOfBounds(t *testing.T) {
 			c.init()
 			handler.returnCode = tt.returnCode
 			handler.badcontent = tt.badContent
-			got, err := c.GetPodsOutOfBounds()
+			got, err := c.GetPodsOutOfBounds(context.Background())
 			if (err != nil) != tt.wantErr {
-				t.Errorf("CustomAnomalyDetector.GetPodsOutOfBounds() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("CustomAnomalyDetector.GetPodsOutOfBounds(context.Background()) error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if tt.wantErr == true {
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("CustomAnomalyDetector.GetPodsOutOfBounds()\ngot = %v\nwant= %v\n", got, tt.want)
+				t.Errorf("CustomAnomalyDetector.GetPodsOutOfBounds(context.Background())\ngot = %v\nwant= %v\n", got, tt.want)
 			}
 		})
 	}
@@ -1,6 +1,7 @@
 package anomalydetector
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/amadeusitgroup/kanary/pkg/pod"
@@ -14,10 +15,12 @@ type okkoCount struct {
 
 type okkoByPodName map[string]okkoCount
 type discreteValueAnalyser interface {
-	doAnalysis() (okkoByPodName, error)
+	doAnalysis(ctx context.Context) (okkoByPodName, error)
 }
 
 var _ AnomalyDetector = &DiscreteValueOutOfListAnalyser{}
+var _ InsufficientDataDetector = &DiscreteValueOutOfListAnalyser{}
+var _ MeasuredValuesReporter = &DiscreteValueOutOfListAnalyser{}
 
 //DiscreteValueOutOfListConfig configuration for DiscreteValueOutOfListAnalyser
 type DiscreteValueOutOfListConfig struct {
@@ -33,11 +36,15 @@ type DiscreteValueOutOfListAnalyser struct {
 	ConfigSpecific DiscreteValueOutOfListConfig
 	ConfigAnalyser Config
 
-	analyser discreteValueAnalyser
+	analyser         discreteValueAnalyser
+	insufficientData []*kapiv1.Pod
+	measuredValues   map[string]string
 }
 
 //GetPodsOutOfBounds implements interface AnomalyDetector
-func (d *DiscreteValueOutOfListAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, error) {
+func (d *DiscreteValueOutOfListAnalyser) GetPodsOutOfBounds(ctx context.Context) ([]*kapiv1.Pod, error) {
+	d.insufficientData = nil
+	d.measuredValues = map[string]string{}
 	listOfPods, err := d.ConfigAnalyser.PodLister.List(d.ConfigAnalyser.Selector)
 	if err != nil {
 		return nil, fmt.Errorf("can't list pods, error:%v", err)
@@ -52,7 +59,7 @@ func (d *DiscreteValueOutOfListAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, er
 		return nil, err
 	}
 	result := []*kapiv1.Pod{}
-	countersByPods, err := d.analyser.doAnalysis()
+	countersByPods, err := d.analyser.doAnalysis(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +74,12 @@ func (d *DiscreteValueOutOfListAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, er
 		}
 	}
 
+	samplesByPodName := map[string]uint{}
+	for podName, counter := range countersByPods {
+		samplesByPodName[podName] = counter.ok + counter.ko
+	}
+	d.insufficientData = podsWithInsufficientData(podByName, podWithNoTraffic, samplesByPodName, d.ConfigAnalyser.MinSamples)
+
 	for podName, counter := range countersByPods {
 		_, found := podWithNoTraffic[podName]
 		if found {
@@ -74,6 +87,9 @@ func (d *DiscreteValueOutOfListAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, er
 		}
 
 		sum := counter.ok + counter.ko
+		if _, ok := podByName[podName]; ok {
+			d.measuredValues[podName] = fmt.Sprintf("%d/%d bad values", counter.ko, sum)
+		}
 		if sum >= 1 {
 			ratio := counter.ko * 100 / sum
 			if ratio > d.ConfigSpecific.TolerancePercent {
@@ -86,3 +102,13 @@ func (d *DiscreteValueOutOfListAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, er
 	}
 	return result, nil
 }
+
+//GetPodsWithInsufficientData implements interface InsufficientDataDetector
+func (d *DiscreteValueOutOfListAnalyser) GetPodsWithInsufficientData() []*kapiv1.Pod {
+	return d.insufficientData
+}
+
+//GetMeasuredValues implements interface MeasuredValuesReporter
+func (d *DiscreteValueOutOfListAnalyser) GetMeasuredValues() map[string]string {
+	return d.measuredValues
+}
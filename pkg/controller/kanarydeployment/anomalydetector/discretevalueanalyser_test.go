@@ -1,6 +1,7 @@
 package anomalydetector
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
@@ -162,14 +163,14 @@ func TestDiscreteValueOutOfListAnalyser_GetPodsOutOfBounds(t *testing.T) {
 				},
 				analyser: tt.fields.analyser,
 			}
-			got, err := d.GetPodsOutOfBounds()
+			got, err := d.GetPodsOutOfBounds(context.Background())
 			if (err != nil) != tt.wantErr {
-				t.Errorf("DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds(context.Background()) error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
 			if len(got) != len(tt.want) {
-				t.Errorf("Got DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds() = %v,\n want %v", got, tt.want)
+				t.Errorf("Got DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds(context.Background()) = %v,\n want %v", got, tt.want)
 				return
 			}
 
@@ -177,16 +178,113 @@ func TestDiscreteValueOutOfListAnalyser_GetPodsOutOfBounds(t *testing.T) {
 			sort.SliceStable(got, func(i, j int) bool { return tt.want[i].Name < tt.want[j].Name })
 
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Got DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds() = %v,\n want %v", got, tt.want)
+				t.Errorf("Got DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds(context.Background()) = %v,\n want %v", got, tt.want)
 			}
 		})
 	}
 
 }
 
+func TestDiscreteValueOutOfListAnalyser_GetPodsWithInsufficientData(t *testing.T) {
+	podLister := test.NewTestPodNamespaceLister(
+		[]*kapiv1.Pod{
+			test.PodGen("A", "test-ns", map[string]string{"app": "foo"}, nil, true, true),
+			test.PodGen("B", "test-ns", map[string]string{"app": "foo"}, nil, true, true),
+			test.PodGen("C", "test-ns", map[string]string{"app": "foo"}, nil, true, true),
+		}, "test-ns")
+
+	tests := []struct {
+		name          string
+		minSamples    uint
+		okkoByPodName okkoByPodName
+		exclusionFunc func(*kapiv1.Pod) (bool, error)
+		want          []string
+	}{
+		{
+			name:          "check disabled",
+			minSamples:    0,
+			okkoByPodName: okkoByPodName{},
+			want:          []string{},
+		},
+		{
+			name:          "pods without samples",
+			minSamples:    1,
+			okkoByPodName: okkoByPodName{"A": {10, 0}, "B": {0, 0}},
+			want:          []string{"B", "C"},
+		},
+		{
+			name:          "request volume below the minimum",
+			minSamples:    20,
+			okkoByPodName: okkoByPodName{"A": {10, 10}, "B": {15, 0}, "C": {0, 30}},
+			want:          []string{"B"},
+		},
+		{
+			name:          "excluded pods are not reported",
+			minSamples:    1,
+			okkoByPodName: okkoByPodName{"A": {10, 0}},
+			exclusionFunc: func(p *kapiv1.Pod) (bool, error) { return p.Name == "C", nil },
+			want:          []string{"B"},
+		},
+		{
+			name:          "global query",
+			minSamples:    5,
+			okkoByPodName: okkoByPodName{GlobalQueryKey: {3, 1}},
+			want:          []string{"A", "B", "C"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DiscreteValueOutOfListAnalyser{
+				ConfigSpecific: DiscreteValueOutOfListConfig{TolerancePercent: 50},
+				ConfigAnalyser: Config{
+					Selector:      labels.Everything(),
+					PodLister:     podLister,
+					Logger:        logf.Log,
+					ExclusionFunc: tt.exclusionFunc,
+					MinSamples:    tt.minSamples,
+				},
+				analyser: &testDiscreateValueAnalyser{okkoByPodName: tt.okkoByPodName},
+			}
+			if _, err := d.GetPodsOutOfBounds(context.Background()); err != nil {
+				t.Fatalf("DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds(context.Background()) error = %v", err)
+			}
+			got := []string{}
+			for _, p := range d.GetPodsWithInsufficientData() {
+				got = append(got, p.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiscreteValueOutOfListAnalyser.GetPodsWithInsufficientData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscreteValueOutOfListAnalyser_GetMeasuredValues(t *testing.T) {
+	d := &DiscreteValueOutOfListAnalyser{
+		ConfigSpecific: DiscreteValueOutOfListConfig{TolerancePercent: 50},
+		ConfigAnalyser: Config{
+			Selector: labels.Everything(),
+			PodLister: test.NewTestPodNamespaceLister(
+				[]*kapiv1.Pod{
+					test.PodGen("A", "test-ns", map[string]string{"app": "foo"}, nil, true, true),
+					test.PodGen("B", "test-ns", map[string]string{"app": "foo"}, nil, true, true),
+				}, "test-ns"),
+			Logger: logf.Log,
+		},
+		analyser: &testDiscreateValueAnalyser{okkoByPodName: okkoByPodName{"A": {10, 0}, "B": {2, 8}, "unknown": {1, 1}}},
+	}
+	if _, err := d.GetPodsOutOfBounds(context.Background()); err != nil {
+		t.Fatalf("DiscreteValueOutOfListAnalyser.GetPodsOutOfBounds(context.Background()) error = %v", err)
+	}
+	want := map[string]string{"A": "0/10 bad values", "B": "8/10 bad values"}
+	if got := d.GetMeasuredValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiscreteValueOutOfListAnalyser.GetMeasuredValues() = %v, want %v", got, want)
+	}
+}
+
 type testErrorDiscreateValueAnalyser struct{}
 
-func (t *testErrorDiscreateValueAnalyser) doAnalysis() (okkoByPodName, error) {
+func (t *testErrorDiscreateValueAnalyser) doAnalysis(ctx context.Context) (okkoByPodName, error) {
 	return nil, fmt.Errorf("error")
 }
 
@@ -194,6 +292,6 @@ type testDiscreateValueAnalyser struct {
 	okkoByPodName
 }
 
-func (t *testDiscreateValueAnalyser) doAnalysis() (okkoByPodName, error) {
+func (t *testDiscreateValueAnalyser) doAnalysis(ctx context.Context) (okkoByPodName, error) {
 	return t.okkoByPodName, nil
 }
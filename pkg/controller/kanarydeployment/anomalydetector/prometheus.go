@@ -33,8 +33,7 @@ type promDiscreteValueOutOfListAnalyser struct {
 	config     DiscreteValueOutOfListConfig
 }
 
-func (p *promDiscreteValueOutOfListAnalyser) doAnalysis() (okkoByPodName, error) {
-	ctx := context.Background()
+func (p *promDiscreteValueOutOfListAnalyser) doAnalysis(ctx context.Context) (okkoByPodName, error) {
 	tsNow := time.Now()
 
 	// promQL example: sum(delta(ms_rpc_count{job=\"kubernetes-pods\",run=\"foo\"}[10s])) by (code,kubernetes_pod_name)
@@ -113,8 +112,7 @@ func newPromContinuousValueDeviationAnalyser(promConfig ConfigPrometheusAnomalyD
 	return &promContinuousValueDeviationAnalyser{promConfig: promConfig, config: config}, nil
 }
 
-func (p *promContinuousValueDeviationAnalyser) doAnalysis() (deviationByPodName, error) {
-	ctx := context.Background()
+func (p *promContinuousValueDeviationAnalyser) doAnalysis(ctx context.Context) (deviationByPodName, error) {
 	tsNow := time.Now()
 
 	// promQL example: (rate(solution_price_sum{}[1m])/rate(solution_price_count{}[1m]) and delta(solution_price_count{}[1m])>70) / scalar(sum(rate(solution_price_sum{}[1m]))/sum(rate(solution_price_count{}[1m])))
@@ -161,8 +159,7 @@ func newPromValueInRangeAnalyser(promConfig ConfigPrometheusAnomalyDetector, con
 	return &promValueInRangeAnalyser{promConfig: promConfig, config: config}, nil
 }
 
-func (p *promValueInRangeAnalyser) doAnalysis() (inRangeByPodName, error) {
-	ctx := context.Background()
+func (p *promValueInRangeAnalyser) doAnalysis(ctx context.Context) (valueByPodName, error) {
 	tsNow := time.Now()
 
 	// promQL example: (rate(solution_price_sum{}[1m])/rate(solution_price_count{}[1m]) and delta(solution_price_count{}[1m])>70) / scalar(sum(rate(solution_price_sum{}[1m]))/sum(rate(solution_price_count{}[1m])))
@@ -177,17 +174,13 @@ func (p *promValueInRangeAnalyser) doAnalysis() (inRangeByPodName, error) {
 		return nil, fmt.Errorf("the prometheus query did not return a result in the form of expected type 'model.Vector': %s", err)
 	}
 
-	result := inRangeByPodName{}
+	result := valueByPodName{}
 	for _, sample := range vector {
 		podName, err := extractPodNameFromMetric(sample.Metric, p.promConfig)
 		if err != nil {
 			return nil, err
 		}
-		if float64(sample.Value) >= p.config.Min && float64(sample.Value) <= p.config.Max {
-			result[podName] = true
-		} else {
-			result[podName] = false
-		}
+		result[podName] = float64(sample.Value)
 	}
 	return result, nil
 }
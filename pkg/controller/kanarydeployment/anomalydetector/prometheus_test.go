@@ -219,7 +219,7 @@ func Test_promDiscreteValueOutOfListAnalyser_doAnalysis(t *testing.T) {
 					logger:     logf.Log,
 				},
 			}
-			got, err := p.doAnalysis()
+			got, err := p.doAnalysis(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("promDiscreteValueOutOfListAnalyser.doAnalysis() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -357,7 +357,7 @@ func Test_promContinuousValueDeviationAnalyser_doAnalysis(t *testing.T) {
 					logger:     logf.Log,
 				},
 			}
-			got, err := p.doAnalysis()
+			got, err := p.doAnalysis(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("promContinuousValueDeviationAnalyser.doAnalysis() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1,6 +1,10 @@
 package anomalydetector
 
-import kapiv1 "k8s.io/api/core/v1"
+import (
+	"sort"
+
+	kapiv1 "k8s.io/api/core/v1"
+)
 
 // ContainsString checks if the slice has the contains value in it.
 func ContainsString(slice []string, contains string) bool {
@@ -33,3 +37,21 @@ func PodByName(listOfPods []*kapiv1.Pod, exclusionFunc func(*kapiv1.Pod) (bool,
 	}
 	return podByName, podWithNoTraffic, nil
 }
+
+//podsWithInsufficientData returns the pods, not excluded from comparison, that have less than minSamples samples. Pods are sorted by name.
+func podsWithInsufficientData(podByName, excludeFromComparison map[string]*kapiv1.Pod, samplesByPodName map[string]uint, minSamples uint) []*kapiv1.Pod {
+	result := []*kapiv1.Pod{}
+	if minSamples == 0 {
+		return result
+	}
+	for name, p := range podByName {
+		if _, found := excludeFromComparison[name]; found {
+			continue
+		}
+		if samplesByPodName[name] < minSamples {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -1,6 +1,7 @@
 package anomalydetector
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/amadeusitgroup/kanary/pkg/pod"
@@ -8,11 +9,13 @@ import (
 )
 
 var _ AnomalyDetector = &ValueInRangeAnalyser{}
+var _ InsufficientDataDetector = &ValueInRangeAnalyser{}
+var _ MeasuredValuesReporter = &ValueInRangeAnalyser{}
 
-//inRangeByPodName true means in range
-type inRangeByPodName map[string]bool
+//valueByPodName value returned by the query for each pod
+type valueByPodName map[string]float64
 type valueInRangeAnalyser interface {
-	doAnalysis() (inRangeByPodName, error)
+	doAnalysis(ctx context.Context) (valueByPodName, error)
 }
 
 //ValueInRangeConfig Configuration for ValueInRangeAnalyser
@@ -26,11 +29,15 @@ type ValueInRangeAnalyser struct {
 	ConfigSpecific ValueInRangeConfig
 	ConfigAnalyser Config
 
-	analyser valueInRangeAnalyser
+	analyser         valueInRangeAnalyser
+	insufficientData []*kapiv1.Pod
+	measuredValues   map[string]string
 }
 
 //GetPodsOutOfBounds implements interface AnomalyDetector
-func (d *ValueInRangeAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, error) {
+func (d *ValueInRangeAnalyser) GetPodsOutOfBounds(ctx context.Context) ([]*kapiv1.Pod, error) {
+	d.insufficientData = nil
+	d.measuredValues = map[string]string{}
 	listOfPods, err := d.ConfigAnalyser.PodLister.List(d.ConfigAnalyser.Selector)
 	if err != nil {
 		return nil, fmt.Errorf("can't list pods, error:%v", err)
@@ -45,27 +52,36 @@ func (d *ValueInRangeAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, error) {
 	}
 	result := []*kapiv1.Pod{}
 
-	inRangeByPods, err := d.analyser.doAnalysis()
+	valueByPods, err := d.analyser.doAnalysis(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	//check if the key is GlobalKeyQuery that means that the result is applicable to all pods
-	if len(inRangeByPods) == 1 {
-		if v, ok := inRangeByPods[GlobalQueryKey]; ok {
+	if len(valueByPods) == 1 {
+		if v, ok := valueByPods[GlobalQueryKey]; ok {
 			for _, pod := range podByName {
-				inRangeByPods[pod.Name] = v
+				valueByPods[pod.Name] = v
 			}
-			delete(inRangeByPods, GlobalQueryKey)
+			delete(valueByPods, GlobalQueryKey)
 		}
 	}
 
-	for podName, inRange := range inRangeByPods {
+	samplesByPodName := map[string]uint{}
+	for podName := range valueByPods {
+		samplesByPodName[podName] = 1
+	}
+	d.insufficientData = podsWithInsufficientData(podByName, podWithNoTraffic, samplesByPodName, d.ConfigAnalyser.MinSamples)
+
+	for podName, value := range valueByPods {
 		_, found := podWithNoTraffic[podName]
 		if found {
 			continue
 		}
-		if !inRange {
+		if _, ok := podByName[podName]; ok {
+			d.measuredValues[podName] = fmt.Sprintf("%g", value)
+		}
+		if value < d.ConfigSpecific.Min || value > d.ConfigSpecific.Max {
 			if p, ok := podByName[podName]; ok {
 				result = append(result, p)
 			}
@@ -73,3 +89,13 @@ func (d *ValueInRangeAnalyser) GetPodsOutOfBounds() ([]*kapiv1.Pod, error) {
 	}
 	return result, nil
 }
+
+//GetPodsWithInsufficientData implements interface InsufficientDataDetector
+func (d *ValueInRangeAnalyser) GetPodsWithInsufficientData() []*kapiv1.Pod {
+	return d.insufficientData
+}
+
+//GetMeasuredValues implements interface MeasuredValuesReporter
+func (d *ValueInRangeAnalyser) GetMeasuredValues() map[string]string {
+	return d.measuredValues
+}
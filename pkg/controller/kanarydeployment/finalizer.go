@@ -0,0 +1,79 @@
+package kanarydeployment
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies"
+)
+
+// manageFinalizer adds the cleanup finalizer to the KanaryDeployments that update resources they don't own, and restores
+// these resources when the KanaryDeployment is deleted, before removing the finalizer.
+func (r *ReconcileKanaryDeployment) manageFinalizer(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (bool, reconcile.Result, error) {
+	if kd.DeletionTimestamp != nil {
+		if !hasFinalizer(kd) {
+			return true, reconcile.Result{}, nil
+		}
+		if err := r.cleanup(reqLogger, kd); err != nil {
+			reqLogger.Error(err, "failed to restore the resources updated by the KanaryDeployment")
+			return true, reconcile.Result{RequeueAfter: time.Second}, err
+		}
+		updatedKd := kd.DeepCopy()
+		updatedKd.Finalizers = nil
+		for _, finalizer := range kd.Finalizers {
+			if finalizer != kanaryv1alpha1.KanaryDeploymentFinalizer {
+				updatedKd.Finalizers = append(updatedKd.Finalizers, finalizer)
+			}
+		}
+		reqLogger.Info("Removing the cleanup finalizer")
+		return true, reconcile.Result{}, r.client.Update(context.TODO(), updatedKd)
+	}
+
+	if !needsFinalizer(kd) || hasFinalizer(kd) {
+		return false, reconcile.Result{}, nil
+	}
+	updatedKd := kd.DeepCopy()
+	updatedKd.Finalizers = append(updatedKd.Finalizers, kanaryv1alpha1.KanaryDeploymentFinalizer)
+	reqLogger.Info("Adding the cleanup finalizer")
+	if err := r.client.Update(context.TODO(), updatedKd); err != nil {
+		reqLogger.Error(err, "failed to add the cleanup finalizer")
+		return true, reconcile.Result{}, err
+	}
+	return true, reconcile.Result{Requeue: true}, nil
+}
+
+// cleanup restores the resources updated by the KanaryDeployment
+func (r *ReconcileKanaryDeployment) cleanup(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) error {
+	// the service targets the Deployment pods again, and the Deployment gets its replicas back, before the garbage collection of the canary Deployment
+	if err := strategies.RestoreServiceSelector(r.client, reqLogger, kd); err != nil {
+		return err
+	}
+	if err := strategies.RestorePromotionReplicas(r.client, reqLogger, kd); err != nil {
+		return err
+	}
+	// the StatefulSet gets its previous pod template and update strategy back
+	if err := strategies.RestoreStatefulSet(r.client, reqLogger, kd); err != nil {
+		return err
+	}
+	// the DaemonSet runs on the canary nodes again, and they are unlabelled
+	return strategies.RestoreDaemonSet(r.client, reqLogger, kd)
+}
+
+// needsFinalizer returns true if the KanaryDeployment may update resources it doesn't own
+func needsFinalizer(kd *kanaryv1alpha1.KanaryDeployment) bool {
+	return kd.Spec.Promotion != nil || kd.Spec.BlueGreen != nil || kd.Spec.StatefulSet != nil || kd.Spec.DaemonSet != nil
+}
+
+func hasFinalizer(kd *kanaryv1alpha1.KanaryDeployment) bool {
+	for _, finalizer := range kd.Finalizers {
+		if finalizer == kanaryv1alpha1.KanaryDeploymentFinalizer {
+			return true
+		}
+	}
+	return false
+}
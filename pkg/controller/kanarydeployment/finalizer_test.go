@@ -0,0 +1,194 @@
+package kanarydeployment
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+)
+
+func TestReconcileKanaryDeployment_manageFinalizer(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("TestReconcileKanaryDeployment_manageFinalizer")
+
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	name, namespace := "foo", "kanary"
+	promotion := &kanaryv1alpha1.KanaryDeploymentSpecPromotion{Steps: []int32{25, 50, 75}}
+	blueGreen := &kanaryv1alpha1.KanaryDeploymentSpecBlueGreen{}
+	depSelector := map[string]string{"app": "foo"}
+	newKD := func(promotion *kanaryv1alpha1.KanaryDeploymentSpecPromotion, blueGreen *kanaryv1alpha1.KanaryDeploymentSpecBlueGreen, status *kanaryv1alpha1.KanaryDeploymentStatus, deleted bool) *kanaryv1alpha1.KanaryDeployment {
+		kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, name, 4, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Status: status})
+		kd.Spec.Promotion = promotion
+		kd.Spec.BlueGreen = blueGreen
+		if deleted {
+			now := metav1.Now()
+			kd.DeletionTimestamp = &now
+			kd.Finalizers = []string{kanaryv1alpha1.KanaryDeploymentFinalizer}
+		}
+		return kd
+	}
+	promoting := &kanaryv1alpha1.KanaryDeploymentStatus{
+		Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{{Type: kanaryv1alpha1.SucceededKanaryDeploymentConditionType, Status: corev1.ConditionTrue}},
+		Promotion:  &kanaryv1alpha1.KanaryDeploymentStatusPromotion{Step: 2, Replicas: 4},
+	}
+	switched := &kanaryv1alpha1.KanaryDeploymentStatus{
+		Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{
+			{Type: kanaryv1alpha1.SucceededKanaryDeploymentConditionType, Status: corev1.ConditionTrue},
+			{Type: kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType, Status: corev1.ConditionTrue},
+		},
+		BlueGreen: &kanaryv1alpha1.KanaryDeploymentStatusBlueGreen{SwitchTime: metav1.Now(), ServiceSelector: depSelector},
+	}
+
+	daemonSetKD := newKD(nil, nil, &kanaryv1alpha1.KanaryDeploymentStatus{
+		DaemonSet: &kanaryv1alpha1.KanaryDeploymentStatusDaemonSet{Nodes: []string{"node-a"}, PreviousTemplate: &corev1.PodTemplateSpec{}},
+	}, true)
+	daemonSetKD.Spec.DaemonSet = &kanaryv1alpha1.KanaryDeploymentSpecDaemonSet{Name: name}
+	canaryNodeLabels := map[string]string{kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey: utils.GetCanaryNodeLabelValue(daemonSetKD)}
+	previousStrategy := appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+	statefulSetKD := newKD(nil, nil, &kanaryv1alpha1.KanaryDeploymentStatus{
+		StatefulSet: &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{Partition: 3, PreviousTemplate: &corev1.PodTemplateSpec{}, PreviousUpdateStrategy: &previousStrategy},
+	}, true)
+	statefulSetKD.Spec.StatefulSet = &kanaryv1alpha1.KanaryDeploymentSpecStatefulSet{Name: name}
+	partition := int32(3)
+
+	tests := []struct {
+		name            string
+		kd              *kanaryv1alpha1.KanaryDeployment
+		wantReturn      bool
+		wantFinalizer   bool
+		wantDepReplicas int32
+		wantSelector    map[string]string
+	}{
+		{
+			name:            "no finalizer needed",
+			kd:              newKD(nil, nil, nil, false),
+			wantDepReplicas: 1,
+		},
+		{
+			name:            "finalizer added",
+			kd:              newKD(promotion, nil, nil, false),
+			wantReturn:      true,
+			wantFinalizer:   true,
+			wantDepReplicas: 1,
+		},
+		{
+			name:            "deleted during the promotion",
+			kd:              newKD(promotion, nil, promoting, true),
+			wantReturn:      true,
+			wantDepReplicas: 4,
+		},
+		{
+			name:            "deleted during the blue/green rollback window",
+			kd:              newKD(nil, blueGreen, switched, true),
+			wantReturn:      true,
+			wantDepReplicas: 1,
+			wantSelector:    depSelector,
+		},
+		{
+			name:            "deleted during the StatefulSet canary",
+			kd:              statefulSetKD,
+			wantReturn:      true,
+			wantDepReplicas: 1,
+		},
+		{
+			name:            "deleted during the DaemonSet canary",
+			kd:              daemonSetKD,
+			wantReturn:      true,
+			wantDepReplicas: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ReconcileKanaryDeployment{
+				client: fake.NewFakeClient([]runtime.Object{
+					tt.kd,
+					utilstest.NewDeployment(name, namespace, 1, nil),
+					utilstest.NewService(name, namespace, utils.GetLabelsForKanaryPod(name), nil),
+					&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+					&appsv1.StatefulSet{
+						ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+						Spec: appsv1.StatefulSetSpec{UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+							Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+							RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+						}},
+					},
+					&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: canaryNodeLabels}},
+				}...),
+				scheme: s,
+			}
+			needsReturn, _, err := r.manageFinalizer(reqLogger, tt.kd)
+			if err != nil {
+				t.Fatalf("manageFinalizer() unexpected error: %v", err)
+			}
+			if needsReturn != tt.wantReturn {
+				t.Errorf("manageFinalizer() needsReturn = %v, want %v", needsReturn, tt.wantReturn)
+			}
+			if err = checkFinalizer(r, name, namespace, tt.wantFinalizer); err != nil {
+				t.Error(err)
+			}
+			dep := &appsv1.Deployment{}
+			if err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, dep); err != nil {
+				t.Fatalf("unable to get the deployment: %v", err)
+			}
+			if *dep.Spec.Replicas != tt.wantDepReplicas {
+				t.Errorf("deployment replicas = %d, want %d", *dep.Spec.Replicas, tt.wantDepReplicas)
+			}
+			if tt.kd.Spec.StatefulSet != nil {
+				sts := &appsv1.StatefulSet{}
+				if err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, sts); err != nil {
+					t.Fatalf("unable to get the statefulset: %v", err)
+				}
+				if sts.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType || sts.Spec.UpdateStrategy.RollingUpdate != nil {
+					t.Errorf("statefulset update strategy = %v, want the previous one", sts.Spec.UpdateStrategy)
+				}
+			}
+			if tt.kd.Spec.DaemonSet != nil {
+				node := &corev1.Node{}
+				if err = r.client.Get(context.TODO(), types.NamespacedName{Name: "node-a"}, node); err != nil {
+					t.Fatalf("unable to get the node: %v", err)
+				}
+				if _, ok := node.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey]; ok {
+					t.Errorf("canary node label not removed: %v", node.Labels)
+				}
+			}
+			if tt.wantSelector == nil {
+				return
+			}
+			service := &corev1.Service{}
+			if err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, service); err != nil {
+				t.Fatalf("unable to get the service: %v", err)
+			}
+			if !reflect.DeepEqual(service.Spec.Selector, tt.wantSelector) {
+				t.Errorf("service selector = %v, want %v", service.Spec.Selector, tt.wantSelector)
+			}
+		})
+	}
+}
+
+func checkFinalizer(r *ReconcileKanaryDeployment, name, namespace string, want bool) error {
+	kd := &kanaryv1alpha1.KanaryDeployment{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, kd); err != nil {
+		return fmt.Errorf("unable to get the KanaryDeployment: %v", err)
+	}
+	if got := hasFinalizer(kd); got != want {
+		return fmt.Errorf("KanaryDeployment finalizers = %v, want the cleanup finalizer: %v", kd.Finalizers, want)
+	}
+	return nil
+}
@@ -2,18 +2,21 @@ package kanarydeployment
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -64,7 +67,16 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	}
 
 	// Watch for changes to secondary resource Deployment and requeue the owner KanaryDeployment
-	err = c.Watch(&source.Kind{Type: &appsv1beta1.Deployment{}}, &handler.EnqueueRequestForOwner{
+	err = c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &kanaryv1alpha1.KanaryDeployment{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to secondary resource Job (validation job) and requeue the owner KanaryDeployment
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &kanaryv1alpha1.KanaryDeployment{},
 	})
@@ -110,6 +122,10 @@ func (r *ReconcileKanaryDeployment) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{}, err
 	}
 
+	if needsReturn, result, err := r.manageFinalizer(reqLogger, instance); needsReturn {
+		return result, err
+	}
+
 	if !kanaryv1alpha1.IsDefaultedKanaryDeployment(instance) {
 		reqLogger.Info("Defaulting values")
 		defaultedInstance := kanaryv1alpha1.DefaultKanaryDeployment(instance)
@@ -123,6 +139,28 @@ func (r *ReconcileKanaryDeployment) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	if errs := utils.ValidateKanaryDeployment(instance); len(errs) > 0 {
+		// an invalid spec is not applied, the KanaryDeployment is reconciled again when its spec is updated
+		err = utilerrors.NewAggregate(errs)
+		reqLogger.Error(err, "invalid KanaryDeployment spec")
+		newStatus := instance.Status.DeepCopy()
+		utils.UpdateKanaryDeploymentStatusCondition(newStatus, metav1.Now(), kanaryv1alpha1.ErroredKanaryDeploymentConditionType, corev1.ConditionTrue, fmt.Sprintf("invalid spec: %v", err), false)
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, instance, newStatus, reconcile.Result{}, nil)
+	}
+
+	if instance.Spec.StatefulSet != nil {
+		return r.reconcileStatefulSet(reqLogger, instance)
+	}
+	if instance.Spec.DaemonSet != nil {
+		return r.reconcileDaemonSet(reqLogger, instance)
+	}
+
+	if instance.Spec.Patch != nil {
+		if needsReturn, result, err := r.manageTemplateResolution(reqLogger, instance); needsReturn {
+			return result, err
+		}
+	}
+
 	// Check if the deployment already exists, if not create a new one
 	deployment, needsReturn, result, err := r.manageDeploymentCreationFunc(reqLogger, instance, utils.GetDeploymentName(instance), utils.NewDeploymentFromKanaryDeploymentTemplate)
 	if needsReturn {
@@ -135,12 +173,22 @@ func (r *ReconcileKanaryDeployment) Reconcile(request reconcile.Request) (reconc
 		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, instance, newstatus, *schedResult, nil)
 	}
 
-	var canarydeployment *appsv1beta1.Deployment
+	//Check pause and abort
+	if newstatus, pauseResult := strategies.ApplyPauseAndAbort(reqLogger, instance); newstatus != nil || pauseResult != nil {
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, instance, newstatus, *pauseResult, nil)
+	}
+
+	var canarydeployment *appsv1.Deployment
 	canarydeployment, needsReturn, result, err = r.manageCanaryDeploymentCreation(reqLogger, instance, utils.GetCanaryDeploymentName(instance))
 	if needsReturn {
 		return updateKanaryDeploymentStatus(r.client, reqLogger, instance, metav1.Now(), result, err)
 	}
 
+	needsReturn, result, err = r.manageVariantDeploymentsCreation(reqLogger, instance)
+	if needsReturn {
+		return updateKanaryDeploymentStatus(r.client, reqLogger, instance, metav1.Now(), result, err)
+	}
+
 	strategy, err := strategies.NewStrategy(&instance.Spec)
 	if err != nil {
 		reqLogger.Error(err, "failed to instance the KanaryDeployment strategies")
@@ -153,15 +201,175 @@ func (r *ReconcileKanaryDeployment) Reconcile(request reconcile.Request) (reconc
 	return strategy.Apply(r.client, reqLogger, instance, deployment, canarydeployment)
 }
 
-func (r *ReconcileKanaryDeployment) manageCanaryDeploymentCreation(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, name string) (*appsv1beta1.Deployment, bool, reconcile.Result, error) {
-	// check that the deployment template was not updated since the creation
+// manageTemplateResolution sets the template of a KanaryDeployment defined with a patch. The template is resolved from the main Deployment
+// spec when the run starts or when the patch is updated, then saved in the status so the run doesn't drift with the main Deployment.
+// The resolved template is only set in memory, the KanaryDeployment spec is not updated.
+func (r *ReconcileKanaryDeployment) manageTemplateResolution(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (bool, reconcile.Result, error) {
+	patchHash, err := comparison.GenerateMD5KanaryDeploymentPatch(kd.Spec.Patch)
+	if err != nil {
+		reqLogger.Error(err, "failed to generate the patch MD5")
+		return true, reconcile.Result{}, err
+	}
+	if kd.Status.Patch != nil && kd.Status.Patch.Hash == patchHash {
+		kd.Spec.Template = *kd.Status.Patch.Template.DeepCopy()
+		return false, reconcile.Result{}, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: utils.GetDeploymentName(kd), Namespace: kd.Namespace}, deployment)
+	if err != nil {
+		reqLogger.Error(err, "failed to get the Deployment to patch")
+		result, err := updateKanaryDeploymentStatus(r.client, reqLogger, kd, metav1.Now(), reconcile.Result{RequeueAfter: time.Second}, err)
+		return true, result, err
+	}
+	template, err := utils.NewTemplateFromKanaryDeploymentPatch(kd.Spec.Patch, deployment)
+	if err != nil {
+		reqLogger.Error(err, "failed to apply the patch to the Deployment spec")
+		result, err := updateKanaryDeploymentStatus(r.client, reqLogger, kd, metav1.Now(), reconcile.Result{}, err)
+		return true, result, err
+	}
+	templateHash, err := comparison.GenerateMD5DeploymentSpec(&template.Spec)
+	if err != nil {
+		reqLogger.Error(err, "failed to generate Deployment template MD5")
+		return true, reconcile.Result{}, err
+	}
+
+	newStatus := kd.Status.DeepCopy()
+	newStatus.Patch = &kanaryv1alpha1.KanaryDeploymentStatusPatch{
+		Hash:           patchHash,
+		TemplateHash:   templateHash,
+		Template:       *template,
+		ResolutionTime: metav1.Now(),
+	}
+	reqLogger.Info("Template resolved from the patch", "hash", templateHash)
+	result, err := utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newStatus, reconcile.Result{Requeue: true}, nil)
+	return true, result, err
+}
+
+// reconcileStatefulSet reconciles a KanaryDeployment targeting a StatefulSet: the canary pods are the highest ordinals of the StatefulSet,
+// updated thanks to its rolling update partition
+func (r *ReconcileKanaryDeployment) reconcileStatefulSet(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (reconcile.Result, error) {
+	statefulset := &appsv1.StatefulSet{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: kd.Spec.StatefulSet.Name, Namespace: kd.Namespace}, statefulset)
+	if err != nil {
+		reqLogger.Error(err, "failed to get StatefulSet")
+		return updateKanaryDeploymentStatus(r.client, reqLogger, kd, metav1.Now(), reconcile.Result{RequeueAfter: time.Second}, err)
+	}
+
+	//Check scheduling
+	if newstatus, schedResult := strategies.ApplyScheduling(reqLogger, kd); newstatus != nil || schedResult != nil {
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newstatus, *schedResult, nil)
+	}
+
+	//Check pause and abort
+	if newstatus, pauseResult := strategies.ApplyPauseAndAbort(reqLogger, kd); newstatus != nil || pauseResult != nil {
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newstatus, *pauseResult, nil)
+	}
+
 	currentHash, err := comparison.GenerateMD5DeploymentSpec(&kd.Spec.Template.Spec)
+	if err != nil {
+		reqLogger.Error(err, "failed to generate Deployment template MD5")
+		return reconcile.Result{}, err
+	}
+
+	// a template update or a restart request starts a new run, from the restored StatefulSet
+	if strategies.IsNewRunRequested(kd, currentHash) {
+		if err = strategies.RestoreStatefulSet(r.client, reqLogger, kd); err != nil {
+			return reconcile.Result{RequeueAfter: time.Second}, err
+		}
+		newStatus := strategies.NewRunStatus(kd, metav1.Now())
+		reqLogger.Info("Starting a new run", "revision", newStatus.Revision)
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newStatus, reconcile.Result{Requeue: true}, nil)
+	}
+
+	if kd.Status.StatefulSet == nil {
+		newStatus, result, err := strategies.StartStatefulSetCanary(r.client, reqLogger, kd, statefulset, currentHash)
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newStatus, result, err)
+	}
+
+	strategy, err := strategies.NewStrategy(&kd.Spec)
+	if err != nil {
+		reqLogger.Error(err, "failed to instance the KanaryDeployment strategies")
+		return reconcile.Result{}, err
+	}
+	if kd.Status.StatefulSet.EndTime == nil && !strategies.IsStatefulSetCanaryObserved(kd, statefulset) {
+		// the StatefulSet status changes don't trigger a reconcile
+		reqLogger.Info("Waiting for the StatefulSet controller to observe the canary", "StatefulSet", statefulset.Name)
+		return reconcile.Result{RequeueAfter: time.Second}, nil
+	}
+	target := strategies.NewStatefulSetCanaryTarget(kd, statefulset)
+	return strategy.Apply(r.client, reqLogger, kd, target, target)
+}
+
+// reconcileDaemonSet reconciles a KanaryDeployment targeting a DaemonSet: the canary pods are run by a canary DaemonSet on the canary nodes,
+// excluded from the DaemonSet
+func (r *ReconcileKanaryDeployment) reconcileDaemonSet(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (reconcile.Result, error) {
+	daemonset := &appsv1.DaemonSet{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: kd.Spec.DaemonSet.Name, Namespace: kd.Namespace}, daemonset)
+	if err != nil {
+		reqLogger.Error(err, "failed to get DaemonSet")
+		return updateKanaryDeploymentStatus(r.client, reqLogger, kd, metav1.Now(), reconcile.Result{RequeueAfter: time.Second}, err)
+	}
+
+	//Check scheduling
+	if newstatus, schedResult := strategies.ApplyScheduling(reqLogger, kd); newstatus != nil || schedResult != nil {
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newstatus, *schedResult, nil)
+	}
+
+	//Check pause and abort
+	if newstatus, pauseResult := strategies.ApplyPauseAndAbort(reqLogger, kd); newstatus != nil || pauseResult != nil {
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newstatus, *pauseResult, nil)
+	}
+
+	currentHash, err := comparison.GenerateMD5DeploymentSpec(&kd.Spec.Template.Spec)
+	if err != nil {
+		reqLogger.Error(err, "failed to generate Deployment template MD5")
+		return reconcile.Result{}, err
+	}
+
+	// a template update or a restart request starts a new run, from the restored DaemonSet
+	if strategies.IsNewRunRequested(kd, currentHash) {
+		if err = strategies.RestoreDaemonSet(r.client, reqLogger, kd); err != nil {
+			return reconcile.Result{RequeueAfter: time.Second}, err
+		}
+		newStatus := strategies.NewRunStatus(kd, metav1.Now())
+		reqLogger.Info("Starting a new run", "revision", newStatus.Revision)
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newStatus, reconcile.Result{Requeue: true}, nil)
+	}
+
+	if kd.Status.DaemonSet == nil {
+		newStatus, result, err := strategies.StartDaemonSetCanary(r.client, reqLogger, kd, daemonset, r.scheme, currentHash)
+		return utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newStatus, result, err)
+	}
+
+	strategy, err := strategies.NewStrategy(&kd.Spec)
+	if err != nil {
+		reqLogger.Error(err, "failed to instance the KanaryDeployment strategies")
+		return reconcile.Result{}, err
+	}
+	canary, err := utils.NewCanaryDaemonSetFromKanaryDeploymentTemplate(kd, daemonset, r.scheme, false)
+	if err != nil {
+		reqLogger.Error(err, "failed to create the canary DaemonSet artifact")
+		return reconcile.Result{}, err
+	}
+	target := strategies.NewDaemonSetCanaryTarget(kd, canary)
+	return strategy.Apply(r.client, reqLogger, kd, target, target)
+}
+
+func (r *ReconcileKanaryDeployment) manageCanaryDeploymentCreation(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, name string) (*appsv1.Deployment, bool, reconcile.Result, error) {
+	// check that the deployment and variants templates were not updated since the creation
+	currentHash, err := comparison.GenerateMD5KanaryDeploymentRun(&kd.Spec)
 	if err != nil {
 		reqLogger.Error(err, "failed to generate Deployment template MD5")
 		return nil, true, reconcile.Result{}, err
 	}
 
-	deployment := &appsv1beta1.Deployment{}
+	// a template update or a restart request starts a new run
+	if strategies.IsNewRunRequested(kd, currentHash) {
+		return r.startNewRun(reqLogger, kd, name)
+	}
+
+	deployment := &appsv1.Deployment{}
 	result := reconcile.Result{}
 	err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: kd.Namespace}, deployment)
 	if err != nil && errors.IsNotFound(err) {
@@ -179,6 +387,10 @@ func (r *ReconcileKanaryDeployment) manageCanaryDeploymentCreation(reqLogger log
 		}
 		newStatus := kd.Status.DeepCopy()
 		newStatus.CurrentHash = currentHash
+		newStatus.ObservedRestart = kd.Spec.Restart
+		if newStatus.Revision == 0 {
+			newStatus.Revision = 1
+		}
 		utils.UpdateKanaryDeploymentStatusCondition(newStatus, metav1.Now(), kanaryv1alpha1.ActivatedKanaryDeploymentConditionType, corev1.ConditionTrue, "", false)
 		result.Requeue = true
 		result, err = utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newStatus, result, err)
@@ -189,19 +401,93 @@ func (r *ReconcileKanaryDeployment) manageCanaryDeploymentCreation(reqLogger log
 		return deployment, true, reconcile.Result{}, err
 	}
 
-	if kd.Status.CurrentHash != "" && kd.Status.CurrentHash != currentHash {
-		err = r.client.Delete(context.TODO(), deployment)
-		if err != nil {
+	if deployment.DeletionTimestamp != nil {
+		// canary Deployment of the previous run still being deleted
+		return deployment, true, reconcile.Result{RequeueAfter: time.Second}, nil
+	}
+
+	return deployment, false, reconcile.Result{}, err
+}
+
+// manageVariantDeploymentsCreation creates the canary Deployment of each variant
+func (r *ReconcileKanaryDeployment) manageVariantDeploymentsCreation(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (bool, reconcile.Result, error) {
+	for i := range kd.Spec.Variants {
+		variant := &kd.Spec.Variants[i]
+		deployment := &appsv1.Deployment{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: utils.GetVariantDeploymentName(kd, variant.Name), Namespace: kd.Namespace}, deployment)
+		if err != nil && errors.IsNotFound(err) {
+			deployment, err = utils.NewVariantDeploymentFromKanaryDeploymentTemplate(r.client, kd, variant, r.scheme)
+			if err != nil {
+				reqLogger.Error(err, "failed to create the variant Deployment artifact", "variant", variant.Name)
+				return true, reconcile.Result{}, err
+			}
+
+			reqLogger.Info("Creating a new variant Deployment", "variant", variant.Name)
+			if err = r.client.Create(context.TODO(), deployment); err != nil {
+				reqLogger.Error(err, "failed to create new variant Deployment", "variant", variant.Name)
+				return true, reconcile.Result{}, err
+			}
+			// Deployment created successfully - return and requeue
+			return true, reconcile.Result{Requeue: true}, nil
+		} else if err != nil {
+			reqLogger.Error(err, "failed to get the variant Deployment", "variant", variant.Name)
+			return true, reconcile.Result{}, err
+		}
+
+		if deployment.DeletionTimestamp != nil {
+			// variant canary Deployment of the previous run still being deleted
+			return true, reconcile.Result{RequeueAfter: time.Second}, nil
+		}
+	}
+	return false, reconcile.Result{}, nil
+}
+
+// startNewRun deletes the canary Deployment of the current run, and resets the status with the current run archived in the history
+func (r *ReconcileKanaryDeployment) startNewRun(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, name string) (*appsv1.Deployment, bool, reconcile.Result, error) {
+	// in blue/green mode, the service must target the Deployment pods again before the canary deletion
+	if err := strategies.RestoreServiceSelector(r.client, reqLogger, kd); err != nil {
+		return nil, true, reconcile.Result{RequeueAfter: time.Second}, err
+	}
+
+	// the Deployment gets its replicas back before the deletion of the canary Deployment serving the promoted replicas
+	if err := strategies.RestorePromotionReplicas(r.client, reqLogger, kd); err != nil {
+		return nil, true, reconcile.Result{RequeueAfter: time.Second}, err
+	}
+
+	// the variants canary Deployments are recreated too, with their current template
+	names := []string{name}
+	for _, variant := range kd.Spec.Variants {
+		names = append(names, utils.GetVariantDeploymentName(kd, variant.Name))
+	}
+	for _, depName := range names {
+		deployment := &appsv1.Deployment{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: depName, Namespace: kd.Namespace}, deployment)
+		if err == nil {
+			err = r.client.Delete(context.TODO(), deployment)
+		}
+		if err != nil && !errors.IsNotFound(err) {
 			reqLogger.Error(err, "failed to delete deprecated Deployment")
 			return deployment, true, reconcile.Result{RequeueAfter: time.Second}, err
 		}
 	}
 
-	return deployment, false, reconcile.Result{}, err
+	if err := strategies.DeleteRunResources(r.client, reqLogger, kd); err != nil {
+		reqLogger.Error(err, "failed to delete the resources of the previous run")
+		return nil, true, reconcile.Result{RequeueAfter: time.Second}, err
+	}
+
+	newStatus := strategies.NewRunStatus(kd, metav1.Now())
+	reqLogger.Info("Starting a new run", "revision", newStatus.Revision)
+	result, err := utils.UpdateKanaryDeploymentStatus(r.client, subResourceDisabled, reqLogger, kd, newStatus, reconcile.Result{Requeue: true}, nil)
+	if err == nil {
+		// keep the instance in sync, to not restore the previous run status with the caller status update
+		kd.Status = *newStatus
+	}
+	return nil, true, result, err
 }
 
-func (r *ReconcileKanaryDeployment) manageDeploymentCreationFunc(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, name string, createFunc func(*kanaryv1alpha1.KanaryDeployment, *runtime.Scheme, bool) (*appsv1beta1.Deployment, error)) (*appsv1beta1.Deployment, bool, reconcile.Result, error) {
-	deployment := &appsv1beta1.Deployment{}
+func (r *ReconcileKanaryDeployment) manageDeploymentCreationFunc(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, name string, createFunc func(*kanaryv1alpha1.KanaryDeployment, *runtime.Scheme, bool) (*appsv1.Deployment, error)) (*appsv1.Deployment, bool, reconcile.Result, error) {
+	deployment := &appsv1.Deployment{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: kd.Namespace}, deployment)
 	if err != nil && errors.IsNotFound(err) {
 		deployment, err = createFunc(kd, r.scheme, false)
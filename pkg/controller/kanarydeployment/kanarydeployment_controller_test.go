@@ -7,7 +7,7 @@ import (
 	"testing"
 	"time"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/client-go/kubernetes/scheme"
@@ -23,6 +23,7 @@ import (
 
 	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
 	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
 )
 
@@ -136,7 +137,7 @@ func TestReconcileKanaryDeployment_Reconcile(t *testing.T) {
 				Requeue: true,
 			},
 			wantFunc: func(r *ReconcileKanaryDeployment) error {
-				deployment := &appsv1beta1.Deployment{}
+				deployment := &appsv1.Deployment{}
 				err := r.client.Get(context.TODO(), types.NamespacedName{Name: name + "-kanary-" + name, Namespace: namespace}, deployment)
 				if err != nil && errors.IsNotFound(err) {
 					return fmt.Errorf("unable to get the created canary deployment, %v", err)
@@ -154,6 +155,41 @@ func TestReconcileKanaryDeployment_Reconcile(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "[INIT] invalid spec",
+
+			request: reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      name,
+					Namespace: namespace,
+				},
+			},
+			fields: fields{
+				scheme: s,
+				client: fake.NewFakeClient([]runtime.Object{
+					kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{
+						Traffic: &kanaryv1alpha1.KanaryDeploymentSpecTraffic{Source: kanaryv1alpha1.NoneKanaryDeploymentSpecTrafficSource, LoadGenerator: &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{}},
+					}),
+					utilstest.NewDeployment(name, namespace, defaultReplicas, nil),
+				}...),
+			},
+			want: reconcile.Result{},
+			wantFunc: func(r *ReconcileKanaryDeployment) error {
+				deployment := &appsv1.Deployment{}
+				err := r.client.Get(context.TODO(), types.NamespacedName{Name: name + "-kanary-" + name, Namespace: namespace}, deployment)
+				if err == nil || !errors.IsNotFound(err) {
+					return fmt.Errorf("the canary deployment should not be created, %v", err)
+				}
+				kd := &kanaryv1alpha1.KanaryDeployment{}
+				if err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, kd); err != nil {
+					return err
+				}
+				if !utils.IsKanaryDeploymentErrored(&kd.Status) {
+					return fmt.Errorf("the KanaryDeployment should be errored, %v", kd.Status.Conditions)
+				}
+				return nil
+			},
+		},
 		{
 			name: "[INIT] service is not defined",
 
@@ -283,6 +319,65 @@ func TestReconcileKanaryDeployment_Reconcile(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "[RUN] deployment template updated after a failure",
+
+			request: reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      name,
+					Namespace: namespace,
+				},
+			},
+			fields: fields{
+				scheme: s,
+				client: fake.NewFakeClient([]runtime.Object{
+					kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{
+						Status: &kanaryv1alpha1.KanaryDeploymentStatus{
+							CurrentHash: "previous-hash",
+							Revision:    1,
+							Report:      kanaryv1alpha1.KanaryDeploymentStatusReport{Status: string(kanaryv1alpha1.FailedKanaryDeploymentConditionType)},
+							Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{
+								kanaryv1alpha1.KanaryDeploymentCondition{
+									Status: corev1.ConditionTrue,
+									Type:   kanaryv1alpha1.ScheduledKanaryDeploymentConditionType,
+								},
+								kanaryv1alpha1.KanaryDeploymentCondition{
+									Status:  corev1.ConditionTrue,
+									Type:    kanaryv1alpha1.FailedKanaryDeploymentConditionType,
+									Message: "KanaryDeployment failed, promQL query reported an issue",
+								},
+							},
+						},
+					}),
+					utilstest.NewDeployment(name, namespace, defaultReplicas, nil),
+					utilstest.NewDeployment(name+"-kanary-"+name, namespace, 1, nil),
+				}...),
+			},
+			want: reconcile.Result{
+				Requeue: true,
+			},
+			wantFunc: func(r *ReconcileKanaryDeployment) error {
+				deployment := &appsv1.Deployment{}
+				err := r.client.Get(context.TODO(), types.NamespacedName{Name: name + "-kanary-" + name, Namespace: namespace}, deployment)
+				if err == nil || !errors.IsNotFound(err) {
+					return fmt.Errorf("the canary deployment of the previous run should be deleted, %v", err)
+				}
+				kd := &kanaryv1alpha1.KanaryDeployment{}
+				if err = r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, kd); err != nil {
+					return err
+				}
+				if kd.Status.Revision != 2 || kd.Status.CurrentHash != "" {
+					return fmt.Errorf("a new run should be started, revision: %d, hash: %q", kd.Status.Revision, kd.Status.CurrentHash)
+				}
+				if utils.IsKanaryDeploymentFailed(&kd.Status) || !utils.IsKanaryDeploymentScheduled(&kd.Status) {
+					return fmt.Errorf("the conditions should be reset except the scheduling, %v", kd.Status.Conditions)
+				}
+				if len(kd.Status.History) != 1 || kd.Status.History[0].Outcome != "Failed" || kd.Status.History[0].Reason != "KanaryDeployment failed, promQL query reported an issue" {
+					return fmt.Errorf("the previous run should be archived, %v", kd.Status.History)
+				}
+				return nil
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -306,3 +401,58 @@ func TestReconcileKanaryDeployment_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileKanaryDeployment_manageTemplateResolution(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("TestReconcileKanaryDeployment_manageTemplateResolution")
+
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	name, namespace := "foo", "kanary"
+	deployment := utilstest.NewDeployment(name, namespace, 3, &utilstest.NewDeploymentOptions{Selector: map[string]string{"app": "foo"}})
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "foo", Image: "foo:1"}}
+	kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, name, 3, nil)
+	kd.Spec.Template = kanaryv1alpha1.DeploymentTemplate{}
+	kd.Spec.Patch = &kanaryv1alpha1.KanaryDeploymentSpecPatch{
+		Type:   kanaryv1alpha1.StrategicMergeKanaryDeploymentSpecPatchType,
+		Images: map[string]string{"foo": "foo:2"},
+	}
+	r := &ReconcileKanaryDeployment{
+		client: fake.NewFakeClient([]runtime.Object{deployment, kd}...),
+		scheme: s,
+	}
+	getKanaryDeployment := func() *kanaryv1alpha1.KanaryDeployment {
+		current := &kanaryv1alpha1.KanaryDeployment{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, current); err != nil {
+			t.Fatalf("unable to get the KanaryDeployment: %v", err)
+		}
+		return current
+	}
+
+	// the template is resolved from the main Deployment and saved in the status
+	needsReturn, _, err := r.manageTemplateResolution(reqLogger, getKanaryDeployment())
+	if err != nil || !needsReturn {
+		t.Fatalf("manageTemplateResolution() = %v, %v, want the status updated", needsReturn, err)
+	}
+	kd = getKanaryDeployment()
+	if kd.Status.Patch == nil || kd.Status.Patch.TemplateHash == "" || kd.Status.Patch.Template.Spec.Template.Spec.Containers[0].Image != "foo:2" {
+		t.Fatalf("manageTemplateResolution() status patch = %v, want the resolved template", kd.Status.Patch)
+	}
+	if len(kd.Spec.Template.Spec.Template.Spec.Containers) > 0 {
+		t.Errorf("manageTemplateResolution() updated the KanaryDeployment template")
+	}
+
+	// the resolved template doesn't drift with the main Deployment
+	deployment.Spec.Template.Spec.Containers[0].Image = "foo:3"
+	if err = r.client.Update(context.TODO(), deployment); err != nil {
+		t.Fatalf("unable to update the Deployment: %v", err)
+	}
+	needsReturn, _, err = r.manageTemplateResolution(reqLogger, kd)
+	if err != nil || needsReturn {
+		t.Fatalf("manageTemplateResolution() = %v, %v, want the saved template used", needsReturn, err)
+	}
+	if kd.Spec.Template.Spec.Template.Spec.Containers[0].Image != "foo:2" {
+		t.Errorf("manageTemplateResolution() template image = %s, want foo:2", kd.Spec.Template.Spec.Template.Spec.Containers[0].Image)
+	}
+}
@@ -0,0 +1,106 @@
+package strategies
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// isRollbackWindowDone returns true once the blue/green rollback window is over
+func isRollbackWindowDone(kd *kanaryv1alpha1.KanaryDeployment) bool {
+	if kd.Status.BlueGreen == nil {
+		return false
+	}
+	return time.Since(kd.Status.BlueGreen.SwitchTime.Time) >= kd.Spec.BlueGreen.RollbackWindow.Duration
+}
+
+// switchService runs the blue/green switch of a succeeded KanaryDeployment: the service selector is switched to the canary pods,
+// and the previous Deployment pods are kept during the rollback window. Setting abort during the window switches the service back.
+func switchService(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	if kd.Status.BlueGreen == nil {
+		service, err := getService(kclient, kd)
+		if err != nil {
+			return &kd.Status, reconcile.Result{}, err
+		}
+		previousSelector := service.Spec.Selector
+		if err = setServiceSelector(kclient, reqLogger, service, utils.GetLabelsForKanaryPod(kd.Name)); err != nil {
+			return &kd.Status, reconcile.Result{}, err
+		}
+		status := kd.Status.DeepCopy()
+		status.BlueGreen = &kanaryv1alpha1.KanaryDeploymentStatusBlueGreen{SwitchTime: metav1.Now(), ServiceSelector: previousSelector}
+		utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType, corev1.ConditionTrue, "Service switched to the canary pods", false)
+		reqLogger.Info("Service switched to the canary pods")
+		return status, reconcile.Result{Requeue: true, RequeueAfter: kd.Spec.BlueGreen.RollbackWindow.Duration}, nil
+	}
+
+	if kd.Spec.Abort {
+		if err := RestoreServiceSelector(kclient, reqLogger, kd); err != nil {
+			return &kd.Status, reconcile.Result{}, err
+		}
+		status := kd.Status.DeepCopy()
+		utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType, corev1.ConditionFalse, "Service switched back to the Deployment pods", false)
+		utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.SucceededKanaryDeploymentConditionType, corev1.ConditionFalse, "Blue/green switch rolled back", false)
+		utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.FailedKanaryDeploymentConditionType, corev1.ConditionTrue, "KanaryDeployment aborted, service switched back to the Deployment pods", false)
+		reqLogger.Info("Blue/green switch rolled back")
+		return status, reconcile.Result{Requeue: true}, nil
+	}
+
+	remaining := kd.Spec.BlueGreen.RollbackWindow.Duration - time.Since(kd.Status.BlueGreen.SwitchTime.Time)
+	reqLogger.Info("Check Blue/green rollback window", "Periodic-Requeue", remaining)
+	return &kd.Status, reconcile.Result{RequeueAfter: remaining}, nil
+}
+
+// restoreService switches the service back to the Deployment pods once the updated Deployment rollout is complete,
+// the service then targets the new version with the Deployment pods
+func restoreService(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, status *kanaryv1alpha1.KanaryDeploymentStatus, result reconcile.Result, err error) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	if err != nil || status.Rollout == nil || status.Rollout.Status != kanaryv1alpha1.CompleteRolloutStatus || !utils.IsKanaryDeploymentServiceSwitched(status) {
+		return status, result, err
+	}
+	if err = RestoreServiceSelector(kclient, reqLogger, kd); err != nil {
+		return status, result, err
+	}
+	if status == &kd.Status {
+		status = kd.Status.DeepCopy()
+	}
+	utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType, corev1.ConditionFalse, "Service restored to the updated Deployment pods", false)
+	reqLogger.Info("Service restored to the updated Deployment pods")
+	return status, result, nil
+}
+
+// RestoreServiceSelector restores the service selector saved by the blue/green switch, if the service targets the canary pods
+func RestoreServiceSelector(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) error {
+	if kd.Status.BlueGreen == nil || !utils.IsKanaryDeploymentServiceSwitched(&kd.Status) {
+		return nil
+	}
+	service, err := getService(kclient, kd)
+	if err != nil {
+		return err
+	}
+	return setServiceSelector(kclient, reqLogger, service, kd.Status.BlueGreen.ServiceSelector)
+}
+
+func getService(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment) (*corev1.Service, error) {
+	service := &corev1.Service{}
+	err := kclient.Get(context.TODO(), client.ObjectKey{Name: kd.Spec.ServiceName, Namespace: kd.Namespace}, service)
+	return service, err
+}
+
+func setServiceSelector(kclient client.Client, reqLogger logr.Logger, service *corev1.Service, selector map[string]string) error {
+	updatedService := service.DeepCopy()
+	updatedService.Spec.Selector = selector
+	if err := kclient.Update(context.TODO(), updatedService); err != nil {
+		reqLogger.Error(err, "failed to update the Service selector", "Namespace", service.Namespace, "Service", service.Name)
+		return err
+	}
+	return nil
+}
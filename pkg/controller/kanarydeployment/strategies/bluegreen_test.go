@@ -0,0 +1,180 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+func Test_switchService(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_switchService")
+
+	depSelector := map[string]string{"app": "foo"}
+	canarySelector := utils.GetLabelsForKanaryPod("foo")
+	succeeded := kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.SucceededKanaryDeploymentConditionType, Status: corev1.ConditionTrue}
+	switched := kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType, Status: corev1.ConditionTrue}
+
+	tests := []struct {
+		name             string
+		abort            bool
+		serviceSelector  map[string]string
+		blueGreen        *kanaryv1alpha1.KanaryDeploymentStatusBlueGreen
+		conditions       []kanaryv1alpha1.KanaryDeploymentCondition
+		wantSelector     map[string]string
+		wantSwitched     bool
+		wantFailed       bool
+		wantRequeueAfter bool
+	}{
+		{
+			name:             "switch the service",
+			serviceSelector:  depSelector,
+			conditions:       []kanaryv1alpha1.KanaryDeploymentCondition{succeeded},
+			wantSelector:     canarySelector,
+			wantSwitched:     true,
+			wantRequeueAfter: true,
+		},
+		{
+			name:             "within the rollback window",
+			serviceSelector:  canarySelector,
+			blueGreen:        &kanaryv1alpha1.KanaryDeploymentStatusBlueGreen{SwitchTime: metav1.NewTime(time.Now().Add(-time.Minute)), ServiceSelector: depSelector},
+			conditions:       []kanaryv1alpha1.KanaryDeploymentCondition{succeeded, switched},
+			wantSelector:     canarySelector,
+			wantSwitched:     true,
+			wantRequeueAfter: true,
+		},
+		{
+			name:            "abort during the rollback window",
+			abort:           true,
+			serviceSelector: canarySelector,
+			blueGreen:       &kanaryv1alpha1.KanaryDeploymentStatusBlueGreen{SwitchTime: metav1.NewTime(time.Now().Add(-time.Minute)), ServiceSelector: depSelector},
+			conditions:      []kanaryv1alpha1.KanaryDeploymentCondition{succeeded, switched},
+			wantSelector:    depSelector,
+			wantFailed:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Selector: tt.serviceSelector},
+			}
+			kclient := fake.NewFakeClient(service)
+			kd := &kanaryv1alpha1.KanaryDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+					ServiceName: "foo",
+					Abort:       tt.abort,
+					BlueGreen:   &kanaryv1alpha1.KanaryDeploymentSpecBlueGreen{RollbackWindow: &metav1.Duration{Duration: 10 * time.Minute}},
+				},
+				Status: kanaryv1alpha1.KanaryDeploymentStatus{Conditions: tt.conditions, BlueGreen: tt.blueGreen},
+			}
+
+			status, result, err := switchService(kclient, reqLogger, kd)
+			if err != nil {
+				t.Fatalf("switchService() unexpected error: %v", err)
+			}
+			if gotSwitched := utils.IsKanaryDeploymentServiceSwitched(status); gotSwitched != tt.wantSwitched {
+				t.Errorf("switchService() switched = %v, want %v", gotSwitched, tt.wantSwitched)
+			}
+			if gotFailed := utils.IsKanaryDeploymentFailed(status); gotFailed != tt.wantFailed {
+				t.Errorf("switchService() failed = %v, want %v", gotFailed, tt.wantFailed)
+			}
+			if tt.wantFailed && utils.IsKanaryDeploymentSucceeded(status) {
+				t.Errorf("switchService() succeeded condition not reset after an abort")
+			}
+			if gotRequeueAfter := result.RequeueAfter > 0; gotRequeueAfter != tt.wantRequeueAfter {
+				t.Errorf("switchService() requeueAfter = %v, want %v", result.RequeueAfter, tt.wantRequeueAfter)
+			}
+			if status.BlueGreen == nil || !reflect.DeepEqual(status.BlueGreen.ServiceSelector, depSelector) {
+				t.Errorf("switchService() blueGreen = %v, want the service selector %v", status.BlueGreen, depSelector)
+			}
+
+			current := &corev1.Service{}
+			if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+				t.Fatalf("unable to get the service: %v", err)
+			}
+			if !reflect.DeepEqual(current.Spec.Selector, tt.wantSelector) {
+				t.Errorf("service selector = %v, want %v", current.Spec.Selector, tt.wantSelector)
+			}
+		})
+	}
+}
+
+func Test_restoreService(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_restoreService")
+
+	depSelector := map[string]string{"app": "foo"}
+	canarySelector := utils.GetLabelsForKanaryPod("foo")
+	switched := kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType, Status: corev1.ConditionTrue}
+
+	tests := []struct {
+		name         string
+		rollout      kanaryv1alpha1.RolloutStatus
+		wantSelector map[string]string
+		wantSwitched bool
+	}{
+		{
+			name:         "rollout in progress",
+			rollout:      kanaryv1alpha1.InProgressRolloutStatus,
+			wantSelector: canarySelector,
+			wantSwitched: true,
+		},
+		{
+			name:         "rollout complete",
+			rollout:      kanaryv1alpha1.CompleteRolloutStatus,
+			wantSelector: depSelector,
+			wantSwitched: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Selector: canarySelector},
+			}
+			kclient := fake.NewFakeClient(service)
+			kd := &kanaryv1alpha1.KanaryDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+					ServiceName: "foo",
+					BlueGreen:   &kanaryv1alpha1.KanaryDeploymentSpecBlueGreen{RollbackWindow: &metav1.Duration{Duration: 10 * time.Minute}},
+				},
+				Status: kanaryv1alpha1.KanaryDeploymentStatus{
+					Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{switched},
+					BlueGreen:  &kanaryv1alpha1.KanaryDeploymentStatusBlueGreen{SwitchTime: metav1.NewTime(time.Now().Add(-time.Hour)), ServiceSelector: depSelector},
+					Rollout:    &kanaryv1alpha1.KanaryDeploymentStatusRollout{Status: tt.rollout},
+				},
+			}
+
+			status, _, err := restoreService(kclient, reqLogger, kd, &kd.Status, reconcile.Result{}, nil)
+			if err != nil {
+				t.Fatalf("restoreService() unexpected error: %v", err)
+			}
+			if gotSwitched := utils.IsKanaryDeploymentServiceSwitched(status); gotSwitched != tt.wantSwitched {
+				t.Errorf("restoreService() switched = %v, want %v", gotSwitched, tt.wantSwitched)
+			}
+
+			current := &corev1.Service{}
+			if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+				t.Fatalf("unable to get the service: %v", err)
+			}
+			if !reflect.DeepEqual(current.Spec.Selector, tt.wantSelector) {
+				t.Errorf("service selector = %v, want %v", current.Spec.Selector, tt.wantSelector)
+			}
+		})
+	}
+}
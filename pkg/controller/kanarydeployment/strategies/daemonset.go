@@ -0,0 +1,332 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// StartDaemonSetCanary starts the canary of a DaemonSet: the canary nodes are labelled, the DaemonSet pods are moved away from them
+// with a node affinity, and the canary DaemonSet runs the KanaryDeployment pod template on them. The previous DaemonSet pod template
+// is saved in the status, to be restored on failure.
+func StartDaemonSetCanary(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, ds *appsv1.DaemonSet, scheme *runtime.Scheme, currentHash string) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	nodes, err := selectCanaryNodes(kclient, kd, ds)
+	if err != nil {
+		reqLogger.Error(err, "failed to list the canary nodes")
+		return &kd.Status, reconcile.Result{}, err
+	}
+	if len(nodes) == 0 {
+		err = fmt.Errorf("no node selected for the canary of the DaemonSet %s", ds.Name)
+		reqLogger.Error(err, "failed to select the canary nodes")
+		return &kd.Status, reconcile.Result{}, err
+	}
+	labelValue := utils.GetCanaryNodeLabelValue(kd)
+	for _, node := range nodes {
+		if err = setCanaryNodeLabel(kclient, reqLogger, node, labelValue); err != nil {
+			return &kd.Status, reconcile.Result{}, err
+		}
+	}
+
+	// the template saved is the one without the canary nodes exclusion, in case a previous start was interrupted
+	previousTemplate := includeCanaryNodes(&ds.Spec.Template)
+	updateDs := ds.DeepCopy()
+	updateDs.Spec.Template = *excludeCanaryNodes(previousTemplate, labelValue)
+	if err = kclient.Update(context.TODO(), updateDs); err != nil {
+		reqLogger.Error(err, "failed to update the DaemonSet", "Namespace", ds.Namespace, "DaemonSet", ds.Name)
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	canary, err := utils.NewCanaryDaemonSetFromKanaryDeploymentTemplate(kd, ds, scheme, true)
+	if err != nil {
+		reqLogger.Error(err, "failed to create the canary DaemonSet artifact")
+		return &kd.Status, reconcile.Result{}, err
+	}
+	if err = kclient.Create(context.TODO(), canary); err != nil && !apierrors.IsAlreadyExists(err) {
+		reqLogger.Error(err, "failed to create the canary DaemonSet", "Namespace", canary.Namespace, "DaemonSet", canary.Name)
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	status := kd.Status.DeepCopy()
+	status.DaemonSet = &kanaryv1alpha1.KanaryDeploymentStatusDaemonSet{
+		Nodes:            nodes,
+		PreviousTemplate: previousTemplate,
+	}
+	status.CurrentHash = currentHash
+	status.ObservedRestart = kd.Spec.Restart
+	if status.Revision == 0 {
+		status.Revision = 1
+	}
+	utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.ActivatedKanaryDeploymentConditionType, corev1.ConditionTrue, "", false)
+	reqLogger.Info("DaemonSet canary started", "DaemonSet", ds.Name, "nodes", nodes)
+	return status, reconcile.Result{Requeue: true}, nil
+}
+
+// selectCanaryNodes returns the names of the canary nodes, among the nodes matching the DaemonSet node selector: the nodes matching
+// the canary node selector, or the first percentage of the nodes in name order. It returns an error if one of them is already a canary
+// node of another KanaryDeployment.
+func selectCanaryNodes(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment, ds *appsv1.DaemonSet) ([]string, error) {
+	selector := labels.Set{}
+	for k, v := range ds.Spec.Template.Spec.NodeSelector {
+		selector[k] = v
+	}
+	for k, v := range kd.Spec.DaemonSet.NodeSelector {
+		selector[k] = v
+	}
+	nodeList := &corev1.NodeList{}
+	if err := kclient.List(context.TODO(), &client.ListOptions{LabelSelector: selector.AsSelector()}, nodeList); err != nil {
+		return nil, err
+	}
+	nodes := []string{}
+	owners := map[string]string{}
+	for _, node := range nodeList.Items {
+		nodes = append(nodes, node.Name)
+		owners[node.Name] = node.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey]
+	}
+	sort.Strings(nodes)
+
+	if len(kd.Spec.DaemonSet.NodeSelector) == 0 && kd.Spec.DaemonSet.NodePercent != nil && len(nodes) > 0 {
+		count := (len(nodes)*int(*kd.Spec.DaemonSet.NodePercent) + 99) / 100
+		if count < 1 {
+			count = 1
+		}
+		if count < len(nodes) {
+			nodes = nodes[:count]
+		}
+	}
+
+	labelValue := utils.GetCanaryNodeLabelValue(kd)
+	for _, node := range nodes {
+		if owner := owners[node]; owner != "" && owner != labelValue {
+			return nil, fmt.Errorf("the node %s is already a canary node of the KanaryDeployment %s", node, owner)
+		}
+	}
+	return nodes, nil
+}
+
+// excludeCanaryNodes returns the pod template with a node affinity excluding the nodes with the canary node label value
+func excludeCanaryNodes(template *corev1.PodTemplateSpec, labelValue string) *corev1.PodTemplateSpec {
+	excluded := template.DeepCopy()
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{labelValue},
+	}
+	if excluded.Spec.Affinity == nil {
+		excluded.Spec.Affinity = &corev1.Affinity{}
+	}
+	if excluded.Spec.Affinity.NodeAffinity == nil {
+		excluded.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := excluded.Spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(terms.NodeSelectorTerms) == 0 {
+		terms.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	// the node selector terms are ORed, so the requirement is added to each of them
+	for i := range terms.NodeSelectorTerms {
+		terms.NodeSelectorTerms[i].MatchExpressions = append(terms.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return excluded
+}
+
+// includeCanaryNodes returns the pod template without the node affinity excluding the canary nodes
+func includeCanaryNodes(template *corev1.PodTemplateSpec) *corev1.PodTemplateSpec {
+	included := template.DeepCopy()
+	affinity := included.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return included
+	}
+	terms := []corev1.NodeSelectorTerm{}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		expressions := []corev1.NodeSelectorRequirement{}
+		for _, expression := range term.MatchExpressions {
+			if expression.Key != kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey {
+				expressions = append(expressions, expression)
+			}
+		}
+		if len(expressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		term.MatchExpressions = expressions
+		terms = append(terms, term)
+	}
+
+	if len(terms) > 0 {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+		return included
+	}
+	affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = nil
+	if affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity = nil
+	}
+	if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
+		included.Spec.Affinity = nil
+	}
+	return included
+}
+
+// NewDaemonSetCanaryTarget returns the Deployment validated in place of the canary DaemonSet pods: it is named after the canary
+// DaemonSet, and selects its pods.
+func NewDaemonSetCanaryTarget(kd *kanaryv1alpha1.KanaryDeployment, canary *appsv1.DaemonSet) *appsv1.Deployment {
+	var replicas *int32
+	if kd.Status.DaemonSet != nil {
+		replicas = kanaryv1alpha1.NewInt32(int32(len(kd.Status.DaemonSet.Nodes)))
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      canary.Name,
+			Namespace: canary.Namespace,
+			Labels:    canary.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas,
+			Selector: canary.Spec.Selector,
+			Template: canary.Spec.Template,
+		},
+	}
+}
+
+// completeDaemonSetCanary completes the DaemonSet canary once the validation is completed: on success the DaemonSet is updated with the
+// KanaryDeployment pod template, on failure or in dry-run mode its previous pod template is restored. In both cases the canary DaemonSet
+// is deleted and the canary nodes are unlabelled.
+func completeDaemonSetCanary(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	if kd.Status.DaemonSet == nil || kd.Status.DaemonSet.EndTime != nil {
+		return &kd.Status, reconcile.Result{}, nil
+	}
+
+	if !utils.IsKanaryDeploymentSucceeded(&kd.Status) || kd.Spec.Validations.NoUpdate {
+		if err := restoreDaemonSet(kclient, reqLogger, kd, kd.Status.DaemonSet.PreviousTemplate); err != nil {
+			return &kd.Status, reconcile.Result{}, err
+		}
+		status := kd.Status.DeepCopy()
+		now := metav1.Now()
+		status.DaemonSet.EndTime = &now
+		reqLogger.Info("DaemonSet restored", "DaemonSet", kd.Spec.DaemonSet.Name)
+		return status, reconcile.Result{}, nil
+	}
+
+	if err := restoreDaemonSet(kclient, reqLogger, kd, &kd.Spec.Template.Spec.Template); err != nil {
+		return &kd.Status, reconcile.Result{}, err
+	}
+	status := kd.Status.DeepCopy()
+	now := metav1.Now()
+	status.DaemonSet.EndTime = &now
+	utils.UpdateKanaryDeploymentStatusCondition(status, now, kanaryv1alpha1.DeploymentUpdatedKanaryDeploymentConditionType, corev1.ConditionTrue, "DaemonSet updated on all the nodes", false)
+	reqLogger.Info("DaemonSet updated on all the nodes", "DaemonSet", kd.Spec.DaemonSet.Name)
+	return status, reconcile.Result{}, nil
+}
+
+// RestoreDaemonSet restores the previous pod template of the DaemonSet, if its canary is in progress
+func RestoreDaemonSet(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) error {
+	if kd.Status.DaemonSet == nil || kd.Status.DaemonSet.EndTime != nil {
+		return nil
+	}
+	return restoreDaemonSet(kclient, reqLogger, kd, kd.Status.DaemonSet.PreviousTemplate)
+}
+
+// restoreDaemonSet deletes the canary DaemonSet, sets the pod template of the DaemonSet on all the nodes and unlabels the canary nodes
+func restoreDaemonSet(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, template *corev1.PodTemplateSpec) error {
+	canary := &appsv1.DaemonSet{}
+	err := kclient.Get(context.TODO(), client.ObjectKey{Name: utils.GetCanaryDaemonSetName(kd), Namespace: kd.Namespace}, canary)
+	if err == nil {
+		err = kclient.Delete(context.TODO(), canary)
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		reqLogger.Error(err, "failed to delete the canary DaemonSet", "Namespace", kd.Namespace, "DaemonSet", utils.GetCanaryDaemonSetName(kd))
+		return err
+	}
+
+	// the canary nodes are unlabelled even if the DaemonSet was deleted in the meantime
+	ds := &appsv1.DaemonSet{}
+	err = kclient.Get(context.TODO(), client.ObjectKey{Name: kd.Spec.DaemonSet.Name, Namespace: kd.Namespace}, ds)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		updateDs := ds.DeepCopy()
+		if template != nil {
+			updateDs.Spec.Template = *template.DeepCopy()
+		} else {
+			updateDs.Spec.Template = *includeCanaryNodes(&ds.Spec.Template)
+		}
+		if err = kclient.Update(context.TODO(), updateDs); err != nil {
+			reqLogger.Error(err, "failed to update the DaemonSet", "Namespace", ds.Namespace, "DaemonSet", ds.Name)
+			return err
+		}
+	}
+
+	labelValue := utils.GetCanaryNodeLabelValue(kd)
+	for _, node := range kd.Status.DaemonSet.Nodes {
+		if err = removeCanaryNodeLabel(kclient, reqLogger, node, labelValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setCanaryNodeLabel sets the canary node label of a node to the KanaryDeployment label value. It returns an error if the node is
+// already a canary node of another KanaryDeployment.
+func setCanaryNodeLabel(kclient client.Client, reqLogger logr.Logger, name, labelValue string) error {
+	node := &corev1.Node{}
+	if err := kclient.Get(context.TODO(), client.ObjectKey{Name: name}, node); err != nil {
+		reqLogger.Error(err, "failed to get the canary node", "Node", name)
+		return err
+	}
+	owner := node.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey]
+	if owner == labelValue {
+		return nil
+	}
+	if owner != "" {
+		return fmt.Errorf("the node %s is already a canary node of the KanaryDeployment %s", name, owner)
+	}
+	updateNode := node.DeepCopy()
+	if updateNode.Labels == nil {
+		updateNode.Labels = map[string]string{}
+	}
+	updateNode.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey] = labelValue
+	if err := kclient.Update(context.TODO(), updateNode); err != nil {
+		reqLogger.Error(err, "failed to update the canary node label", "Node", name)
+		return err
+	}
+	return nil
+}
+
+// removeCanaryNodeLabel removes the canary node label of a node, if it is set to the KanaryDeployment label value
+func removeCanaryNodeLabel(kclient client.Client, reqLogger logr.Logger, name, labelValue string) error {
+	node := &corev1.Node{}
+	if err := kclient.Get(context.TODO(), client.ObjectKey{Name: name}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		reqLogger.Error(err, "failed to get the canary node", "Node", name)
+		return err
+	}
+	if node.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey] != labelValue {
+		return nil
+	}
+	updateNode := node.DeepCopy()
+	delete(updateNode.Labels, kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey)
+	if err := kclient.Update(context.TODO(), updateNode); err != nil {
+		reqLogger.Error(err, "failed to remove the canary node label", "Node", name)
+		return err
+	}
+	return nil
+}
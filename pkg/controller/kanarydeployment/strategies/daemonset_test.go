@@ -0,0 +1,251 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+func newDaemonSetKanaryDeployment(status kanaryv1alpha1.KanaryDeploymentStatus, noUpdate bool) *kanaryv1alpha1.KanaryDeployment {
+	return &kanaryv1alpha1.KanaryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+			Template: kanaryv1alpha1.DeploymentTemplate{
+				Spec: appsv1.DeploymentSpec{Template: newStatefulSetPodTemplate("foo:2")},
+			},
+			DaemonSet:   &kanaryv1alpha1.KanaryDeploymentSpecDaemonSet{Name: "foo", NodePercent: kanaryv1alpha1.NewInt32(50)},
+			Validations: kanaryv1alpha1.KanaryDeploymentSpecValidationList{NoUpdate: noUpdate},
+		},
+		Status: status,
+	}
+}
+
+func newDaemonSet(template corev1.PodTemplateSpec) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			Template: template,
+		},
+	}
+}
+
+func newNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func Test_StartDaemonSetCanary(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_StartDaemonSetCanary")
+
+	ds := newDaemonSet(newStatefulSetPodTemplate("foo:1"))
+	kclient := fake.NewFakeClient(ds, newNode("node-c", nil), newNode("node-a", nil), newNode("node-d", nil), newNode("node-b", nil))
+	kd := newDaemonSetKanaryDeployment(kanaryv1alpha1.KanaryDeploymentStatus{}, false)
+
+	status, _, err := StartDaemonSetCanary(kclient, reqLogger, kd, ds, utils.PrepareSchemeForOwnerRef(), "hash")
+	if err != nil {
+		t.Fatalf("StartDaemonSetCanary() unexpected error: %v", err)
+	}
+	if status.DaemonSet == nil || len(status.DaemonSet.Nodes) != 2 || status.DaemonSet.Nodes[0] != "node-a" || status.DaemonSet.Nodes[1] != "node-b" {
+		t.Fatalf("StartDaemonSetCanary() daemonSet = %v, want the nodes node-a and node-b", status.DaemonSet)
+	}
+	if status.DaemonSet.PreviousTemplate.Spec.Containers[0].Image != "foo:1" || status.DaemonSet.PreviousTemplate.Spec.Affinity != nil {
+		t.Errorf("StartDaemonSetCanary() previous template not saved: %v", status.DaemonSet.PreviousTemplate)
+	}
+	if status.CurrentHash != "hash" || status.Revision != 1 {
+		t.Errorf("StartDaemonSetCanary() run not started, hash %q revision %d", status.CurrentHash, status.Revision)
+	}
+
+	for name, want := range map[string]string{"node-a": "default.foo", "node-b": "default.foo", "node-c": "", "node-d": ""} {
+		node := &corev1.Node{}
+		if err = kclient.Get(context.TODO(), types.NamespacedName{Name: name}, node); err != nil {
+			t.Fatalf("unable to get the node %s: %v", name, err)
+		}
+		if got := node.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey]; got != want {
+			t.Errorf("node %s canary label = %q, want %q", name, got, want)
+		}
+	}
+
+	current := &appsv1.DaemonSet{}
+	if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+		t.Fatalf("unable to get the daemonset: %v", err)
+	}
+	if current.Spec.Template.Spec.Containers[0].Image != "foo:1" || current.Spec.Template.Spec.Affinity == nil {
+		t.Errorf("daemonset template = %v, want the previous image excluded from the canary nodes", current.Spec.Template.Spec)
+	}
+
+	canary := &appsv1.DaemonSet{}
+	if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo-kanary-foo", Namespace: "default"}, canary); err != nil {
+		t.Fatalf("unable to get the canary daemonset: %v", err)
+	}
+	if canary.Spec.Template.Spec.Containers[0].Image != "foo:2" || canary.Spec.Template.Spec.NodeSelector[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey] != "default.foo" {
+		t.Errorf("canary daemonset template = %v, want the new image on the canary nodes", canary.Spec.Template.Spec)
+	}
+	if _, ok := canary.Spec.Template.Labels["app"]; ok {
+		t.Errorf("canary daemonset pod labels = %v, should not match the daemonset selector", canary.Spec.Template.Labels)
+	}
+}
+
+func Test_StartDaemonSetCanary_nodeOfAnotherKanaryDeployment(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_StartDaemonSetCanary_nodeOfAnotherKanaryDeployment")
+
+	ds := newDaemonSet(newStatefulSetPodTemplate("foo:1"))
+	otherNode := newNode("node-a", map[string]string{kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey: "other.foo"})
+	kclient := fake.NewFakeClient(ds, otherNode, newNode("node-b", nil))
+	kd := newDaemonSetKanaryDeployment(kanaryv1alpha1.KanaryDeploymentStatus{}, false)
+
+	if _, _, err := StartDaemonSetCanary(kclient, reqLogger, kd, ds, utils.PrepareSchemeForOwnerRef(), "hash"); err == nil {
+		t.Fatalf("StartDaemonSetCanary() expected an error, the node-a is a canary node of another KanaryDeployment")
+	}
+	for name, want := range map[string]string{"node-a": "other.foo", "node-b": ""} {
+		node := &corev1.Node{}
+		if err := kclient.Get(context.TODO(), types.NamespacedName{Name: name}, node); err != nil {
+			t.Fatalf("unable to get the node %s: %v", name, err)
+		}
+		if got := node.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey]; got != want {
+			t.Errorf("node %s canary label = %q, want %q", name, got, want)
+		}
+	}
+	current := &appsv1.DaemonSet{}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+		t.Fatalf("unable to get the daemonset: %v", err)
+	}
+	if current.Spec.Template.Spec.Affinity != nil {
+		t.Errorf("daemonset template = %v, want no canary nodes exclusion", current.Spec.Template.Spec)
+	}
+}
+
+func Test_completeDaemonSetCanary(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_completeDaemonSetCanary")
+
+	previousTemplate := newStatefulSetPodTemplate("foo:1")
+	tests := []struct {
+		name        string
+		condition   kanaryv1alpha1.KanaryDeploymentConditionType
+		noUpdate    bool
+		wantImage   string
+		wantUpdated bool
+	}{
+		{
+			name:        "succeeded",
+			condition:   kanaryv1alpha1.SucceededKanaryDeploymentConditionType,
+			wantImage:   "foo:2",
+			wantUpdated: true,
+		},
+		{
+			name:      "failed",
+			condition: kanaryv1alpha1.FailedKanaryDeploymentConditionType,
+			wantImage: "foo:1",
+		},
+		{
+			name:      "succeeded in dry-run mode",
+			condition: kanaryv1alpha1.SucceededKanaryDeploymentConditionType,
+			noUpdate:  true,
+			wantImage: "foo:1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kd := newDaemonSetKanaryDeployment(kanaryv1alpha1.KanaryDeploymentStatus{
+				Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{{Type: tt.condition, Status: corev1.ConditionTrue}},
+				DaemonSet: &kanaryv1alpha1.KanaryDeploymentStatusDaemonSet{
+					Nodes:            []string{"node-a"},
+					PreviousTemplate: &previousTemplate,
+				},
+			}, tt.noUpdate)
+			ds := newDaemonSet(*excludeCanaryNodes(&previousTemplate, "default.foo"))
+			canary, _ := utils.NewCanaryDaemonSetFromKanaryDeploymentTemplate(kd, ds, nil, false)
+			canaryNode := newNode("node-a", map[string]string{kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey: "default.foo"})
+			kclient := fake.NewFakeClient([]runtime.Object{ds, canary, canaryNode}...)
+
+			status, _, err := completeDaemonSetCanary(kclient, reqLogger, kd)
+			if err != nil {
+				t.Fatalf("completeDaemonSetCanary() unexpected error: %v", err)
+			}
+			if status.DaemonSet.EndTime == nil {
+				t.Errorf("completeDaemonSetCanary() end time not set")
+			}
+			if gotUpdated := utils.IsKanaryDeploymentDeploymentUpdated(status); gotUpdated != tt.wantUpdated {
+				t.Errorf("completeDaemonSetCanary() updated = %v, want %v", gotUpdated, tt.wantUpdated)
+			}
+
+			current := &appsv1.DaemonSet{}
+			if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+				t.Fatalf("unable to get the daemonset: %v", err)
+			}
+			if current.Spec.Template.Spec.Containers[0].Image != tt.wantImage || current.Spec.Template.Spec.Affinity != nil {
+				t.Errorf("daemonset template = %v, want the image %s on all the nodes", current.Spec.Template.Spec, tt.wantImage)
+			}
+			if err = kclient.Get(context.TODO(), types.NamespacedName{Name: canary.Name, Namespace: "default"}, &appsv1.DaemonSet{}); !apierrors.IsNotFound(err) {
+				t.Errorf("canary daemonset not deleted, err: %v", err)
+			}
+			node := &corev1.Node{}
+			if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "node-a"}, node); err != nil {
+				t.Fatalf("unable to get the node: %v", err)
+			}
+			if _, ok := node.Labels[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey]; ok {
+				t.Errorf("canary node label not removed: %v", node.Labels)
+			}
+		})
+	}
+}
+
+func Test_excludeCanaryNodes(t *testing.T) {
+	zone := corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+	tests := []struct {
+		name     string
+		affinity *corev1.Affinity
+	}{
+		{
+			name: "no affinity",
+		},
+		{
+			name: "node affinity terms",
+			affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{zone}}, {MatchExpressions: []corev1.NodeSelectorRequirement{zone}}},
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := newStatefulSetPodTemplate("foo:1")
+			template.Spec.Affinity = tt.affinity
+
+			excluded := excludeCanaryNodes(&template, "foo")
+			for _, term := range excluded.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+				last := term.MatchExpressions[len(term.MatchExpressions)-1]
+				if last.Key != kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey || last.Operator != corev1.NodeSelectorOpNotIn {
+					t.Errorf("excludeCanaryNodes() term = %v, want the canary nodes excluded", term)
+				}
+			}
+
+			included := includeCanaryNodes(excluded)
+			if (included.Spec.Affinity == nil) != (tt.affinity == nil) {
+				t.Fatalf("includeCanaryNodes() affinity = %v, want %v", included.Spec.Affinity, tt.affinity)
+			}
+			if tt.affinity != nil {
+				for _, term := range included.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+					if len(term.MatchExpressions) != 1 || term.MatchExpressions[0].Key != "zone" {
+						t.Errorf("includeCanaryNodes() term = %v, want the initial term", term)
+					}
+				}
+			}
+		})
+	}
+}
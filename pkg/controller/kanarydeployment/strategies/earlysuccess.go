@@ -0,0 +1,71 @@
+package strategies
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+// recordEarlySuccess returns the early success progress updated with the evaluation results, or nil if the early success rule is not defined.
+// A passed evaluation is counted at most once per minInterval, and an evaluation that did not pass resets the count.
+func recordEarlySuccess(config *kanaryv1alpha1.KanaryDeploymentSpecValidationEarlySuccess, previous *kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess, results []*validation.Result, now metav1.Time, minInterval time.Duration) *kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess {
+	if config == nil || !isEvaluationPassed(results) {
+		return nil
+	}
+	if previous == nil {
+		return &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 1, LastPassTime: now}
+	}
+	if now.Sub(previous.LastPassTime.Time) < minInterval {
+		return previous.DeepCopy()
+	}
+	return &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: previous.ConsecutivePasses + 1, LastPassTime: now}
+}
+
+// isEvaluationPassed returns true if all the validation items passed with enough data
+func isEvaluationPassed(results []*validation.Result) bool {
+	if len(results) == 0 {
+		return false
+	}
+	for _, result := range results {
+		if result.IsFailed || result.InsufficientData != "" || result.Inconclusive != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isEarlySuccess returns true if the early success rule is fulfilled: enough consecutive passed evaluations and enough samples served by the kanary.
+// The manual and external validation items already end the validation with their verdict, the rule is not applied with them.
+func (s *strategy) isEarlySuccess(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canarydep *appsv1.Deployment, progress *kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess) bool {
+	config := kd.Spec.Validations.EarlySuccess
+	if config == nil || progress == nil || progress.ConsecutivePasses < *config.ConsecutivePasses {
+		return false
+	}
+	for _, item := range s.validationsItems {
+		if item.Manual != nil || item.External != nil {
+			return false
+		}
+	}
+	if config.SamplesQuery == "" || config.MinSamples == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(kd.Spec.Validations.Timeout, defaultValidationTimeout))
+	defer cancel()
+	samples, err := validation.GetSamples(ctx, kclient, reqLogger, kd, canarydep)
+	if err != nil {
+		reqLogger.Error(err, "Early success samples query error")
+		return false
+	}
+	reqLogger.Info("Early success", "samples", samples, "minSamples", *config.MinSamples)
+	return samples >= *config.MinSamples
+}
@@ -0,0 +1,136 @@
+package strategies
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+func Test_recordEarlySuccess(t *testing.T) {
+	now := metav1.Now()
+	recently := metav1.NewTime(now.Add(-5 * time.Second))
+	longAgo := metav1.NewTime(now.Add(-time.Minute))
+	config := &kanaryv1alpha1.KanaryDeploymentSpecValidationEarlySuccess{ConsecutivePasses: kanaryv1alpha1.NewInt32(3)}
+
+	tests := []struct {
+		name     string
+		config   *kanaryv1alpha1.KanaryDeploymentSpecValidationEarlySuccess
+		previous *kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess
+		results  []*validation.Result
+		want     *kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess
+	}{
+		{
+			name:    "rule not defined",
+			results: []*validation.Result{{}},
+		},
+		{
+			name:    "first pass",
+			config:  config,
+			results: []*validation.Result{{}, {}},
+			want:    &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 1, LastPassTime: now},
+		},
+		{
+			name:     "next pass",
+			config:   config,
+			previous: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 2, LastPassTime: longAgo},
+			results:  []*validation.Result{{}},
+			want:     &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 3, LastPassTime: now},
+		},
+		{
+			name:     "pass counted once per interval",
+			config:   config,
+			previous: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 2, LastPassTime: recently},
+			results:  []*validation.Result{{}},
+			want:     &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 2, LastPassTime: recently},
+		},
+		{
+			name:     "failure resets the count",
+			config:   config,
+			previous: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 2, LastPassTime: longAgo},
+			results:  []*validation.Result{{}, {IsFailed: true}},
+		},
+		{
+			name:     "insufficient data resets the count",
+			config:   config,
+			previous: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 2, LastPassTime: longAgo},
+			results:  []*validation.Result{{InsufficientData: "no data for pod A"}},
+		},
+		{
+			name:     "inconclusive evaluation resets the count",
+			config:   config,
+			previous: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 2, LastPassTime: longAgo},
+			results:  []*validation.Result{{Inconclusive: "promQL validation error 1/3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recordEarlySuccess(tt.config, tt.previous, tt.results, now, 10*time.Second); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recordEarlySuccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_strategy_isEarlySuccess(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_strategy_isEarlySuccess")
+
+	promQL := kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}}
+	manual := kanaryv1alpha1.KanaryDeploymentSpecValidation{Manual: &kanaryv1alpha1.KanaryDeploymentSpecValidationManual{}}
+	config := &kanaryv1alpha1.KanaryDeploymentSpecValidationEarlySuccess{ConsecutivePasses: kanaryv1alpha1.NewInt32(3)}
+
+	tests := []struct {
+		name     string
+		config   *kanaryv1alpha1.KanaryDeploymentSpecValidationEarlySuccess
+		items    []kanaryv1alpha1.KanaryDeploymentSpecValidation
+		progress *kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess
+		want     bool
+	}{
+		{
+			name:  "rule not defined",
+			items: []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+		},
+		{
+			name:     "not enough consecutive passes",
+			config:   config,
+			items:    []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+			progress: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 2},
+		},
+		{
+			name:     "enough consecutive passes",
+			config:   config,
+			items:    []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+			progress: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 3},
+			want:     true,
+		},
+		{
+			name:     "samples query without minimum",
+			config:   &kanaryv1alpha1.KanaryDeploymentSpecValidationEarlySuccess{ConsecutivePasses: kanaryv1alpha1.NewInt32(3), SamplesQuery: "sum(requests_total)"},
+			items:    []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+			progress: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 3},
+			want:     true,
+		},
+		{
+			name:     "manual validation",
+			config:   config,
+			items:    []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL, manual},
+			progress: &kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess{ConsecutivePasses: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &strategy{validationsItems: tt.items}
+			kd := &kanaryv1alpha1.KanaryDeployment{}
+			kd.Spec.Validations.EarlySuccess = tt.config
+			if got := s.isEarlySuccess(nil, reqLogger, kd, nil, tt.progress); got != tt.want {
+				t.Errorf("strategy.isEarlySuccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package strategies
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// defaultMaxConsecutiveErrors number of consecutive errors tolerated for a validation item, when not defined in the spec
+const defaultMaxConsecutiveErrors = int32(3)
+
+// applyErrorPolicies replaces the results of the validation items in error according to their error policy, and returns
+// the consecutive errors of each validation item, or nil if no validation item is in error.
+// An error is counted at most once per minInterval, since the status update of each error triggers a new reconcile.
+func applyErrorPolicies(previous []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors, items []kanaryv1alpha1.KanaryDeploymentSpecValidation, results []*validation.Result, errs []error, now metav1.Time, minInterval time.Duration) []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors {
+	validationErrors := make([]kanaryv1alpha1.KanaryDeploymentStatusValidationErrors, len(items))
+	inError := false
+	for i := range items {
+		current := &validationErrors[i]
+		current.Validation = utils.GetValidationItemName(&items[i])
+		if errs[i] == nil {
+			continue
+		}
+		inError = true
+		if i < len(previous) && previous[i].Validation == current.Validation {
+			previous[i].DeepCopyInto(current)
+		}
+		if current.LastErrorTime == nil || now.Sub(current.LastErrorTime.Time) >= minInterval {
+			current.ConsecutiveErrors++
+			current.LastError = errs[i].Error()
+			current.LastErrorTime = now.DeepCopy()
+		}
+		results[i] = newErrorPolicyResult(items[i].ErrorPolicy, current)
+	}
+	if !inError {
+		return nil
+	}
+	return validationErrors
+}
+
+// newErrorPolicyResult returns the result of a validation item in error: inconclusive until the maximum number of
+// consecutive errors is reached, then depending of the error policy action.
+func newErrorPolicyResult(policy *kanaryv1alpha1.KanaryDeploymentSpecValidationErrorPolicy, validationErrors *kanaryv1alpha1.KanaryDeploymentStatusValidationErrors) *validation.Result {
+	maxErrors, action := defaultMaxConsecutiveErrors, kanaryv1alpha1.PauseErrorPolicyAction
+	if policy != nil {
+		if policy.MaxConsecutiveErrors != nil {
+			maxErrors = *policy.MaxConsecutiveErrors
+		}
+		if policy.Action != "" {
+			action = policy.Action
+		}
+	}
+
+	if validationErrors.ConsecutiveErrors < maxErrors {
+		message := fmt.Sprintf("%s validation error %d/%d: %s", validationErrors.Validation, validationErrors.ConsecutiveErrors, maxErrors, validationErrors.LastError)
+		return &validation.Result{Comment: message, Inconclusive: message}
+	}
+	switch action {
+	case kanaryv1alpha1.FailErrorPolicyAction:
+		return &validation.Result{
+			IsFailed: true,
+			Comment:  fmt.Sprintf("%s validation failed after %d consecutive errors: %s", validationErrors.Validation, validationErrors.ConsecutiveErrors, validationErrors.LastError),
+		}
+	case kanaryv1alpha1.PassErrorPolicyAction:
+		return &validation.Result{
+			Comment: fmt.Sprintf("%s validation passed after %d consecutive errors: %s", validationErrors.Validation, validationErrors.ConsecutiveErrors, validationErrors.LastError),
+		}
+	}
+	message := fmt.Sprintf("%s validation paused after %d consecutive errors: %s", validationErrors.Validation, validationErrors.ConsecutiveErrors, validationErrors.LastError)
+	return &validation.Result{Comment: message, Inconclusive: message}
+}
+
+// computeInconclusive returns the messages of the inconclusive validation results
+func computeInconclusive(results []*validation.Result) string {
+	messages := []string{}
+	for _, result := range results {
+		if result.Inconclusive != "" {
+			messages = append(messages, result.Inconclusive)
+		}
+	}
+	return strings.Join(messages, ",")
+}
+
+// setInconclusiveCondition sets the Inconclusive condition to True with the message, or to False if every validation item is conclusive.
+func setInconclusiveCondition(status *kanaryv1alpha1.KanaryDeploymentStatus, message string) {
+	setValidationCondition(status, kanaryv1alpha1.InconclusiveKanaryDeploymentConditionType, message)
+}
@@ -0,0 +1,130 @@
+package strategies
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+func Test_applyErrorPolicies(t *testing.T) {
+	now := metav1.Now()
+	recently := metav1.NewTime(now.Add(-10 * time.Second))
+	longAgo := metav1.NewTime(now.Add(-time.Minute))
+	newItem := func(policy *kanaryv1alpha1.KanaryDeploymentSpecValidationErrorPolicy) kanaryv1alpha1.KanaryDeploymentSpecValidation {
+		return kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}, ErrorPolicy: policy}
+	}
+	newPolicy := func(maxErrors int32, action kanaryv1alpha1.ErrorPolicyAction) *kanaryv1alpha1.KanaryDeploymentSpecValidationErrorPolicy {
+		return &kanaryv1alpha1.KanaryDeploymentSpecValidationErrorPolicy{MaxConsecutiveErrors: kanaryv1alpha1.NewInt32(maxErrors), Action: action}
+	}
+	previous := func(errors int32, lastErrorTime metav1.Time) []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors {
+		return []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors{{Validation: "promQL", ConsecutiveErrors: errors, LastError: "prometheus unavailable", LastErrorTime: &lastErrorTime}}
+	}
+	providerError := fmt.Errorf("prometheus unavailable")
+
+	tests := []struct {
+		name       string
+		previous   []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors
+		items      []kanaryv1alpha1.KanaryDeploymentSpecValidation
+		results    []*validation.Result
+		errs       []error
+		want       []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors
+		wantResult []*validation.Result
+	}{
+		{
+			name:       "no error",
+			previous:   previous(2, longAgo),
+			items:      []kanaryv1alpha1.KanaryDeploymentSpecValidation{newItem(nil)},
+			results:    []*validation.Result{{}},
+			errs:       []error{nil},
+			wantResult: []*validation.Result{{}},
+		},
+		{
+			name:    "first error",
+			items:   []kanaryv1alpha1.KanaryDeploymentSpecValidation{newItem(nil), newItem(nil)},
+			results: []*validation.Result{{}, {IsFailed: true}},
+			errs:    []error{providerError, nil},
+			want:    []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors{previous(1, now)[0], {Validation: "promQL"}},
+			wantResult: []*validation.Result{
+				{Comment: "promQL validation error 1/3: prometheus unavailable", Inconclusive: "promQL validation error 1/3: prometheus unavailable"},
+				{IsFailed: true},
+			},
+		},
+		{
+			name:       "error counted once per interval",
+			previous:   previous(1, recently),
+			items:      []kanaryv1alpha1.KanaryDeploymentSpecValidation{newItem(nil)},
+			results:    []*validation.Result{{}},
+			errs:       []error{providerError},
+			want:       previous(1, recently),
+			wantResult: []*validation.Result{{Comment: "promQL validation error 1/3: prometheus unavailable", Inconclusive: "promQL validation error 1/3: prometheus unavailable"}},
+		},
+		{
+			name:       "paused",
+			previous:   previous(2, longAgo),
+			items:      []kanaryv1alpha1.KanaryDeploymentSpecValidation{newItem(nil)},
+			results:    []*validation.Result{{}},
+			errs:       []error{providerError},
+			want:       previous(3, now),
+			wantResult: []*validation.Result{{Comment: "promQL validation paused after 3 consecutive errors: prometheus unavailable", Inconclusive: "promQL validation paused after 3 consecutive errors: prometheus unavailable"}},
+		},
+		{
+			name:       "failed",
+			previous:   previous(1, longAgo),
+			items:      []kanaryv1alpha1.KanaryDeploymentSpecValidation{newItem(newPolicy(2, kanaryv1alpha1.FailErrorPolicyAction))},
+			results:    []*validation.Result{{}},
+			errs:       []error{providerError},
+			want:       previous(2, now),
+			wantResult: []*validation.Result{{IsFailed: true, Comment: "promQL validation failed after 2 consecutive errors: prometheus unavailable"}},
+		},
+		{
+			name:       "passed",
+			items:      []kanaryv1alpha1.KanaryDeploymentSpecValidation{newItem(newPolicy(1, kanaryv1alpha1.PassErrorPolicyAction))},
+			results:    []*validation.Result{{}},
+			errs:       []error{providerError},
+			want:       previous(1, now),
+			wantResult: []*validation.Result{{Comment: "promQL validation passed after 1 consecutive errors: prometheus unavailable"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyErrorPolicies(tt.previous, tt.items, tt.results, tt.errs, now, 30*time.Second)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyErrorPolicies() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.results, tt.wantResult) {
+				t.Errorf("applyErrorPolicies() results = %v, want %v", tt.results, tt.wantResult)
+			}
+		})
+	}
+}
+
+func Test_computeInconclusive(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []*validation.Result
+		want    string
+	}{
+		{
+			name:    "conclusive",
+			results: []*validation.Result{{}, {IsFailed: true}},
+		},
+		{
+			name:    "inconclusive",
+			results: []*validation.Result{{Inconclusive: "promQL validation error 1/3"}, {}, {Inconclusive: "slo validation error 2/3"}},
+			want:    "promQL validation error 1/3,slo validation error 2/3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeInconclusive(tt.results); got != tt.want {
+				t.Errorf("computeInconclusive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
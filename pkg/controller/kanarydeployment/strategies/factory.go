@@ -8,11 +8,10 @@ import (
 
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -27,12 +26,16 @@ import (
 
 // Interface represent the strategy interface
 type Interface interface {
-	Apply(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1beta1.Deployment) (result reconcile.Result, err error)
+	Apply(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment) (result reconcile.Result, err error)
 }
 
 // NewStrategy return new instance of the strategy
 func NewStrategy(spec *kanaryv1alpha1.KanaryDeploymentSpec) (Interface, error) {
 	scaleStatic := scale.NewStatic(spec.Scale.Static)
+	if spec.BlueGreen != nil {
+		// in blue/green mode, the canary Deployment runs at full size
+		scaleStatic = scale.NewStatic(&kanaryv1alpha1.KanaryDeploymentSpecScaleStatic{Replicas: utils.GetCanaryReplicasValue(&kanaryv1alpha1.KanaryDeployment{Spec: *spec})})
+	}
 	scaleHPA := scale.NewHPA(spec.Scale.HPA)
 	scaleImpls := map[scale.Interface]bool{
 		scaleStatic: false,
@@ -46,9 +49,11 @@ func NewStrategy(spec *kanaryv1alpha1.KanaryDeploymentSpec) (Interface, error) {
 
 	trafficKanaryService := traffic.NewKanaryService(&spec.Traffic)
 	trafficMirror := traffic.NewMirror(&spec.Traffic)
+	trafficLoadGenerator := traffic.NewLoadGenerator(&spec.Traffic)
 	trafficImpls := map[traffic.Interface]bool{
 		trafficKanaryService: false,
 		trafficMirror:        false,
+		trafficLoadGenerator: spec.Traffic.LoadGenerator != nil,
 	}
 
 	switch spec.Traffic.Source {
@@ -59,40 +64,91 @@ func NewStrategy(spec *kanaryv1alpha1.KanaryDeploymentSpec) (Interface, error) {
 	default:
 	}
 
-	var validationsImpls []validation.Interface
-	for _, v := range spec.Validations.Items {
-		if v.Manual != nil {
-			validationsImpls = append(validationsImpls, validation.NewManual(&spec.Validations, &v))
-		} else if v.LabelWatch != nil {
-			validationsImpls = append(validationsImpls, validation.NewLabelWatch(&spec.Validations, &v))
-		} else if v.PromQL != nil {
-			validationsImpls = append(validationsImpls, validation.NewPromql(&spec.Validations, &v))
-		}
+	if spec.StatefulSet != nil || spec.DaemonSet != nil {
+		// the StatefulSet canary pods are scaled by the StatefulSet partition, and receive the StatefulSet traffic.
+		// the DaemonSet canary pods run one per canary node, and receive the node traffic
+		scaleImpls = map[scale.Interface]bool{}
+		trafficImpls = map[traffic.Interface]bool{}
+	}
+
+	validationsImpls, validationsItems := newValidations(&spec.Validations, spec.Validations.Items)
+	// The post-promotion validation items are evaluated against the main Deployment, by a strategy of their own
+	var postPromotion *strategy
+	if spec.Validations.PostPromotion != nil {
+		postPromotion = &strategy{}
+		postPromotion.validations, postPromotion.validationsItems = newValidations(&spec.Validations, spec.Validations.PostPromotion.Items)
+	}
+
+	var promotion *strategy
+	if spec.Promotion != nil {
+		promotion = &strategy{}
+		promotion.validations, promotion.validationsItems = newValidations(&spec.Validations, spec.Promotion.Items)
 	}
 
 	return &strategy{
 		scale:               scaleImpls,
 		traffic:             trafficImpls,
 		validations:         validationsImpls,
+		validationsItems:    validationsItems,
+		postPromotion:       postPromotion,
+		promotion:           promotion,
+		scoring:             spec.Validations.Scoring,
 		subResourceDisabled: os.Getenv(config.KanaryStatusSubresourceDisabledEnvVar) == "1",
 	}, nil
 }
 
+// newValidations returns the validation implementations of the items, with the items that have an implementation
+func newValidations(list *kanaryv1alpha1.KanaryDeploymentSpecValidationList, items []kanaryv1alpha1.KanaryDeploymentSpecValidation) ([]validation.Interface, []kanaryv1alpha1.KanaryDeploymentSpecValidation) {
+	var validationsImpls []validation.Interface
+	var validationsItems []kanaryv1alpha1.KanaryDeploymentSpecValidation
+	for _, v := range items {
+		var impl validation.Interface
+		if v.Manual != nil {
+			impl = validation.NewManual(list, &v)
+		} else if v.LabelWatch != nil {
+			impl = validation.NewLabelWatch(list, &v)
+		} else if v.PromQL != nil {
+			impl = validation.NewPromql(list, &v)
+		} else if v.PodHealth != nil {
+			impl = validation.NewPodHealth(list, &v)
+		} else if v.Logs != nil {
+			impl = validation.NewLogs(list, &v)
+		} else if v.Job != nil {
+			impl = validation.NewJob(list, &v)
+		} else if v.Alerts != nil {
+			impl = validation.NewAlerts(list, &v)
+		} else if v.External != nil {
+			impl = validation.NewExternal(list, &v)
+		} else if v.SLO != nil {
+			impl = validation.NewSLO(list, &v)
+		}
+		if impl != nil {
+			validationsImpls = append(validationsImpls, impl)
+			validationsItems = append(validationsItems, v)
+		}
+	}
+	return validationsImpls, validationsItems
+}
+
 type strategy struct {
 	scale               map[scale.Interface]bool
 	traffic             map[traffic.Interface]bool
 	validations         []validation.Interface
+	validationsItems    []kanaryv1alpha1.KanaryDeploymentSpecValidation
+	postPromotion       *strategy
+	promotion           *strategy
+	scoring             *kanaryv1alpha1.KanaryDeploymentSpecValidationScoring
 	subResourceDisabled bool
 }
 
-func (s *strategy) Apply(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1beta1.Deployment) (result reconcile.Result, err error) {
+func (s *strategy) Apply(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment) (result reconcile.Result, err error) {
 	var newStatus *kanaryv1alpha1.KanaryDeploymentStatus
 	newStatus, result, err = s.process(kclient, reqLogger, kd, dep, canarydep)
 	utils.UpdateKanaryDeploymentStatusConditionsFailure(newStatus, metav1.Now(), err)
 	return utils.UpdateKanaryDeploymentStatus(kclient, s.subResourceDisabled, reqLogger, kd, newStatus, result, err) //Try with plain resource
 }
 
-func (s *strategy) process(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1beta1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+func (s *strategy) process(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
 
 	reqLogger.Info("Cleanup scale")
 	// First cleanup if needed
@@ -151,6 +207,15 @@ func (s *strategy) process(kclient client.Client, reqLogger logr.Logger, kd *kan
 		}
 	}
 
+	// The validation timeline is anchored to the canary run start, recorded once the canary is deployed
+	if kd.Status.StartTime == nil && !utils.IsKanaryDeploymentValidationRunning(&kd.Status) && !utils.IsKanaryDeploymentValidationCompleted(&kd.Status) {
+		status := kd.Status.DeepCopy()
+		now := metav1.Now()
+		status.StartTime = &now
+		reqLogger.Info("Run Started")
+		return status, reconcile.Result{Requeue: true}, nil
+	}
+
 	//before going to validation step, let's check that initial delay period is completed
 	if reaminingDelay, done := validation.IsInitialDelayDone(kd); !done {
 		reqLogger.Info("Check Validation", "requeue-initial-delay", reaminingDelay)
@@ -171,28 +236,53 @@ func (s *strategy) process(kclient client.Client, reqLogger logr.Logger, kd *kan
 		validationDeadlineDone := validation.IsDeadlinePeriodDone(kd)
 
 		//Run validation for all strategies
-		var results []*validation.Result
-		var errs []error
-		for _, validationItem := range s.validations {
-			var result *validation.Result
-			result, err := validationItem.Validation(kclient, reqLogger, kd, dep, canarydep)
+		results, itemErrs := s.runValidations(kclient, reqLogger, kd, dep, canarydep)
+		for i, err := range itemErrs {
 			if err != nil {
-				errs = append(errs, err)
+				reqLogger.Error(err, "Validation error", "validation", utils.GetValidationItemName(&s.validationsItems[i]))
 			}
-			results = append(results, result)
-		}
-		if len(errs) > 0 {
-			return &kd.Status, reconcile.Result{Requeue: true}, utilerrors.NewAggregate(errs)
 		}
+		// The provider errors make the validation items inconclusive, until their error policy applies
+		validationErrors := applyErrorPolicies(kd.Status.ValidationErrors, s.validationsItems, results, itemErrs, metav1.Now(), kd.Spec.Validations.MaxIntervalPeriod.Duration/2)
 
 		var forceSucceededNow bool
 		var failMessages string
 		failMessages, forceSucceededNow = computeStatus(results)
+
+		// Without enough data to judge the kanary, a validation can extend the validation period
+		insufficientData, extendValidation := computeInsufficientData(results)
+		// An inconclusive validation item prevents the kanary success, even after the validation deadline
+		inconclusive := computeInconclusive(results)
+		validationDone := validationDeadlineDone && !extendValidation && inconclusive == ""
+
+		// With scoring, the kanary fails only if the score is too low or if a critical validation fails
+		var score *kanaryv1alpha1.KanaryDeploymentStatusScore
+		if s.scoring != nil {
+			score = computeScore(s.scoring, s.validationsItems, results)
+			failMessages = scoreFailMessages(s.scoring, score, validationDone)
+		}
 		failed := failMessages != ""
+		evaluation := &validationEvaluation{
+			results:          results,
+			score:            score,
+			insufficientData: insufficientData,
+			inconclusive:     inconclusive,
+			validationErrors: validationErrors,
+			earlySuccess:     recordEarlySuccess(kd.Spec.Validations.EarlySuccess, kd.Status.EarlySuccess, results, metav1.Now(), kd.Spec.Validations.MaxIntervalPeriod.Duration/2),
+		}
+
+		// With variants, each candidate gets a verdict. With the best variant promotion, the kanary fails only if all the candidates fail.
+		if len(kd.Spec.Variants) > 0 {
+			evaluation.variants = s.evaluateVariants(kclient, reqLogger, kd, dep, results, validationDone)
+			if kd.Spec.PromoteBestVariant {
+				failMessages = variantsFailMessages(evaluation.variants, validationDone)
+				failed = failMessages != ""
+			}
+		}
 
 		// If any strategy fails, the kanary should fail
 		if failed {
-			status := kd.Status.DeepCopy()
+			status := s.newValidationStatus(kd, evaluation, true)
 			utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.FailedKanaryDeploymentConditionType, corev1.ConditionTrue, fmt.Sprintf("KanaryDeployment failed, %s", failMessages), false)
 			utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.RunningKanaryDeploymentConditionType, corev1.ConditionFalse, "Validation ended with failure detected", false)
 			reqLogger.Info("Check Validation", "in failed", failMessages, "updated status", fmt.Sprintf("%#v", status))
@@ -201,17 +291,29 @@ func (s *strategy) process(kclient client.Client, reqLogger logr.Logger, kd *kan
 
 		// So there is no failure, does someone force for an early Success ?
 		if forceSucceededNow {
-			status := kd.Status.DeepCopy()
+			status := s.newValidationStatus(kd, evaluation, true)
 			utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.SucceededKanaryDeploymentConditionType, corev1.ConditionTrue, "Forced Success", false)
 			utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.RunningKanaryDeploymentConditionType, corev1.ConditionFalse, "Validation ended with success forced", false)
 			return status, reconcile.Result{Requeue: true}, nil
 		}
 
+		// Or was enough evidence collected to succeed before the validation deadline ?
+		if !validationDone && !kd.Spec.PromoteBestVariant && s.isEarlySuccess(kclient, reqLogger, kd, canarydep, evaluation.earlySuccess) {
+			status := s.newValidationStatus(kd, evaluation, true)
+			utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.SucceededKanaryDeploymentConditionType, corev1.ConditionTrue, "Early Success", false)
+			utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.RunningKanaryDeploymentConditionType, corev1.ConditionFalse, fmt.Sprintf("Validation ended with early success after %d consecutive passed evaluations", evaluation.earlySuccess.ConsecutivePasses), false)
+			return status, reconcile.Result{Requeue: true}, nil
+		}
+
 		// No failure, so if we have not reached the validation deadline, let's requeue for next validation
-		if !validationDeadlineDone && !failed {
+		if !validationDone && !failed {
 			d := validation.GetNextValidationCheckDuration(kd)
+			if validationDeadlineDone {
+				// validation period extended to get enough data, or to get a conclusive evaluation
+				d = kd.Spec.Validations.MaxIntervalPeriod.Duration
+			}
 			reqLogger.Info("Check Validation", "Periodic-Requeue", d)
-			return &kd.Status, reconcile.Result{RequeueAfter: d}, nil
+			return s.newValidationStatus(kd, evaluation, false), reconcile.Result{RequeueAfter: d}, nil
 		}
 
 		// Validation completed and everything is ok while we have reached the end of the validation period...
@@ -219,34 +321,89 @@ func (s *strategy) process(kclient client.Client, reqLogger logr.Logger, kd *kan
 		//Particular case of the manual strategy with None as StatusAfterDeadline
 		if validation.IsStatusAfterDeadlineNone(kd) {
 			// No automation, no requeue, wait for manual input
-			return &kd.Status, reconcile.Result{}, nil
+			return s.newValidationStatus(kd, evaluation, false), reconcile.Result{}, nil
 		}
 
 		//Looks like it is a success for the kanary!
-		status := kd.Status.DeepCopy()
+		status := s.newValidationStatus(kd, evaluation, true)
 		utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.SucceededKanaryDeploymentConditionType, corev1.ConditionTrue, "Validation ended with success", false)
 		utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.RunningKanaryDeploymentConditionType, corev1.ConditionFalse, "Validation ended with success", false)
 		return status, reconcile.Result{Requeue: true}, nil
 	}
 
+	// With a StatefulSet, the validation outcome is applied through the StatefulSet partition
+	if kd.Spec.StatefulSet != nil {
+		return completeStatefulSetCanary(kclient, reqLogger, kd)
+	}
+	// With a DaemonSet, the validation outcome is applied to the DaemonSet on all the nodes
+	if kd.Spec.DaemonSet != nil {
+		return completeDaemonSetCanary(kclient, reqLogger, kd)
+	}
+
 	//In case of succeeded kanary, we may need to update the deployment
 	if utils.IsKanaryDeploymentSucceeded(&kd.Status) {
 		if kd.Spec.Validations.NoUpdate {
 			return &kd.Status, reconcile.Result{}, nil // nothing else to do... the kanary succeeded, and we are in dry-run mode
 		}
 
-		var newDep *appsv1beta1.Deployment
-		newDep, err := utils.UpdateDeploymentWithKanaryDeploymentTemplate(kd, dep)
+		// Once updated, the Deployment rollout is tracked, and the Deployment is verified by the post-promotion validation items
+		// In blue/green mode, the service is switched back to the Deployment pods once the rollout is complete
+		if utils.IsKanaryDeploymentDeploymentUpdated(&kd.Status) {
+			status, result := &kd.Status, reconcile.Result{}
+			var err error
+			if s.postPromotion != nil && !utils.IsKanaryDeploymentRolledBack(&kd.Status) {
+				status, result, err = s.verifyPromotion(kclient, reqLogger, kd, dep)
+			}
+			status, result, err = trackRollout(kd, dep, status, result, err)
+			if kd.Spec.BlueGreen != nil {
+				return restoreService(kclient, reqLogger, kd, status, result, err)
+			}
+			return status, result, err
+		}
+
+		// In blue/green mode, the service is switched to the canary pods during the rollback window, before the Deployment update
+		if kd.Spec.BlueGreen != nil && !isRollbackWindowDone(kd) {
+			return switchService(kclient, reqLogger, kd)
+		}
+
+		// With the progressive promotion, the canary Deployment replaces the Deployment step by step before the Deployment update
+		if s.promotion != nil && !isPromotionDone(kd.Spec.Promotion, kd.Status.Promotion) {
+			return s.promote(kclient, reqLogger, kd, dep, canarydep)
+		}
+
+		var newDep *appsv1.Deployment
+		// with the best variant promotion, the Deployment is updated with the template of the selected candidate
+		newDep, err := utils.UpdateDeploymentWithKanaryDeploymentTemplate(getPromotedKanaryDeployment(kd), dep)
 		if err != nil {
 			reqLogger.Error(err, "failed to update the Deployment artifact", "Namespace", newDep.Namespace, "Deployment", newDep.Name)
 			return &kd.Status, reconcile.Result{}, err
 		}
+		// the Deployment was scaled down by the progressive promotion
+		var promotedReplicas *int32
+		if kd.Status.Promotion != nil {
+			promotedReplicas = &kd.Status.Promotion.Replicas
+			if newDep.Spec.Replicas == nil {
+				newDep.Spec.Replicas = promotedReplicas
+			}
+		}
 		err = kclient.Update(context.TODO(), newDep)
 		if err != nil {
 			reqLogger.Error(err, "failed to update the Deployment", "Namespace", newDep.Namespace, "Deployment", newDep.Name, "newDep", *newDep)
 			return &kd.Status, reconcile.Result{}, err
 		}
 		status := kd.Status.DeepCopy()
+		if s.postPromotion != nil {
+			status.PostPromotion = newPostPromotionStatus(dep, metav1.Now())
+			if promotedReplicas != nil {
+				status.PostPromotion.PreviousTemplate.Spec.Replicas = promotedReplicas
+			}
+		}
+		status.Rollout = &kanaryv1alpha1.KanaryDeploymentStatusRollout{
+			Status:             kanaryv1alpha1.InProgressRolloutStatus,
+			Message:            "deployment updated with the kanary template",
+			Generation:         newDep.Generation,
+			LastTransitionTime: metav1.Now(),
+		}
 		utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.DeploymentUpdatedKanaryDeploymentConditionType, corev1.ConditionTrue, "Deployment updated successfully", false)
 		return status, reconcile.Result{Requeue: true}, nil
 	}
@@ -283,6 +440,53 @@ func computeStatus(results []*validation.Result) (failMessages string, forceSucc
 	return failMessages, forceSuccessNow
 }
 
+// validationEvaluation outcome of an evaluation of the validation items
+type validationEvaluation struct {
+	results          []*validation.Result
+	score            *kanaryv1alpha1.KanaryDeploymentStatusScore
+	insufficientData string
+	inconclusive     string
+	validationErrors []kanaryv1alpha1.KanaryDeploymentStatusValidationErrors
+	earlySuccess     *kanaryv1alpha1.KanaryDeploymentStatusEarlySuccess
+	variants         []kanaryv1alpha1.KanaryDeploymentStatusVariant
+}
+
+// newValidationStatus returns a copy of the status updated with the evaluation: the validation score, the insufficient data and inconclusive
+// warnings, the consecutive errors and passes, the variants verdicts and the measurement history. The final evaluation is always
+// recorded in the history, and selects the variant to promote with the best variant promotion.
+func (s *strategy) newValidationStatus(kd *kanaryv1alpha1.KanaryDeployment, evaluation *validationEvaluation, final bool) *kanaryv1alpha1.KanaryDeploymentStatus {
+	newStatus := kd.Status.DeepCopy()
+	newStatus.Score = evaluation.score
+	if evaluation.variants != nil {
+		newStatus.Variants = evaluation.variants
+	}
+	if final && kd.Spec.PromoteBestVariant {
+		newStatus.PromotedVariant = getBestVariant(evaluation.variants, true)
+	}
+	newStatus.ExcludedPods = getExcludedPods(evaluation.results)
+	newStatus.ValidationErrors = evaluation.validationErrors
+	newStatus.EarlySuccess = evaluation.earlySuccess
+	setInsufficientDataCondition(newStatus, evaluation.insufficientData)
+	setInconclusiveCondition(newStatus, evaluation.inconclusive)
+	recordValidationHistory(newStatus, s.validationsItems, evaluation.results, metav1.Now(), kd.Spec.Validations.MaxIntervalPeriod.Duration/2, final)
+	return newStatus
+}
+
+// getExcludedPods returns the pods excluded by the validation items, a pod being listed once with its first exclusion reason
+func getExcludedPods(results []*validation.Result) []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod {
+	var excludedPods []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod
+	found := map[string]bool{}
+	for _, result := range results {
+		for _, pod := range result.ExcludedPods {
+			if !found[pod.Pod] {
+				found[pod.Pod] = true
+				excludedPods = append(excludedPods, pod)
+			}
+		}
+	}
+	return excludedPods
+}
+
 func needReturn(result *reconcile.Result) bool {
 	if result.Requeue || int64(result.RequeueAfter) > int64(0) {
 		return true
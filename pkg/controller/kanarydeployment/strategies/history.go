@@ -0,0 +1,54 @@
+package strategies
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// maxValidationHistory maximum number of measurements kept for each validation item
+const maxValidationHistory = 10
+
+// recordValidationHistory appends the validation results to the bounded measurement history of the status.
+// Unless final is set, a measurement is recorded at most once per minInterval to not update the status at each reconcile.
+func recordValidationHistory(status *kanaryv1alpha1.KanaryDeploymentStatus, items []kanaryv1alpha1.KanaryDeploymentSpecValidation, results []*validation.Result, now metav1.Time, minInterval time.Duration, final bool) {
+	if !isSameValidationHistory(status.ValidationHistory, items) {
+		status.ValidationHistory = make([]kanaryv1alpha1.KanaryDeploymentStatusValidationHistory, len(items))
+		for i := range items {
+			status.ValidationHistory[i].Validation = utils.GetValidationItemName(&items[i])
+		}
+	}
+
+	for i, result := range results {
+		history := &status.ValidationHistory[i]
+		if n := len(history.Measurements); n > 0 && !final && now.Sub(history.Measurements[n-1].Time.Time) < minInterval {
+			continue
+		}
+		history.Measurements = append(history.Measurements, kanaryv1alpha1.KanaryDeploymentStatusMeasurement{
+			Time:    now,
+			Failed:  result.IsFailed,
+			Comment: result.Comment,
+			Values:  result.Measurements,
+		})
+		if len(history.Measurements) > maxValidationHistory {
+			history.Measurements = history.Measurements[len(history.Measurements)-maxValidationHistory:]
+		}
+	}
+}
+
+// isSameValidationHistory returns true if the history matches the validation items
+func isSameValidationHistory(history []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory, items []kanaryv1alpha1.KanaryDeploymentSpecValidation) bool {
+	if len(history) != len(items) {
+		return false
+	}
+	for i := range items {
+		if history[i].Validation != utils.GetValidationItemName(&items[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package strategies
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+func Test_recordValidationHistory(t *testing.T) {
+	now := metav1.Now()
+	before := metav1.NewTime(now.Add(-time.Minute))
+	items := []kanaryv1alpha1.KanaryDeploymentSpecValidation{{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}}}
+	values := []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0.8", Failed: true}}
+	results := []*validation.Result{{IsFailed: true, Comment: "promQL failed", Measurements: values}}
+	measurement := kanaryv1alpha1.KanaryDeploymentStatusMeasurement{Time: now, Failed: true, Comment: "promQL failed", Values: values}
+	previous := kanaryv1alpha1.KanaryDeploymentStatusMeasurement{Time: before}
+
+	fullHistory := []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{}
+	for i := 0; i < maxValidationHistory; i++ {
+		fullHistory = append(fullHistory, previous)
+	}
+
+	tests := []struct {
+		name        string
+		history     []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory
+		minInterval time.Duration
+		final       bool
+		want        []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory
+	}{
+		{
+			name: "first measurement",
+			want: []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{
+				{Validation: "promQL", Measurements: []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{measurement}},
+			},
+		},
+		{
+			name:        "previous measurement too recent",
+			history:     []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{{Validation: "promQL", Measurements: []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{previous}}},
+			minInterval: 5 * time.Minute,
+			want:        []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{{Validation: "promQL", Measurements: []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{previous}}},
+		},
+		{
+			name:        "final measurement always recorded",
+			history:     []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{{Validation: "promQL", Measurements: []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{previous}}},
+			minInterval: 5 * time.Minute,
+			final:       true,
+			want:        []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{{Validation: "promQL", Measurements: []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{previous, measurement}}},
+		},
+		{
+			name:        "bounded history",
+			history:     []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{{Validation: "promQL", Measurements: fullHistory}},
+			minInterval: 30 * time.Second,
+			want:        []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{{Validation: "promQL", Measurements: append(append([]kanaryv1alpha1.KanaryDeploymentStatusMeasurement{}, fullHistory[1:]...), measurement)}},
+		},
+		{
+			name:    "validation items changed",
+			history: []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{{Validation: "podHealth", Measurements: []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{previous}}},
+			want: []kanaryv1alpha1.KanaryDeploymentStatusValidationHistory{
+				{Validation: "promQL", Measurements: []kanaryv1alpha1.KanaryDeploymentStatusMeasurement{measurement}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &kanaryv1alpha1.KanaryDeploymentStatus{ValidationHistory: tt.history}
+			recordValidationHistory(status, items, results, now, tt.minInterval, tt.final)
+			if !reflect.DeepEqual(status.ValidationHistory, tt.want) {
+				t.Errorf("recordValidationHistory() = %v, want %v", status.ValidationHistory, tt.want)
+			}
+		})
+	}
+}
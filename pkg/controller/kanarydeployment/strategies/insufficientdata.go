@@ -0,0 +1,44 @@
+package strategies
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// computeInsufficientData returns the insufficient data warnings of the validation results, and if one of them requests to extend the validation period
+func computeInsufficientData(results []*validation.Result) (message string, extendValidation bool) {
+	messages := []string{}
+	for _, result := range results {
+		if result.InsufficientData != "" {
+			messages = append(messages, result.InsufficientData)
+		}
+		extendValidation = extendValidation || result.ExtendValidation
+	}
+	return strings.Join(messages, ","), extendValidation
+}
+
+// setInsufficientDataCondition sets the InsufficientData condition to True with the warning message, or to False if there is no warning.
+func setInsufficientDataCondition(status *kanaryv1alpha1.KanaryDeploymentStatus, message string) {
+	setValidationCondition(status, kanaryv1alpha1.InsufficientDataKanaryDeploymentConditionType, message)
+}
+
+// setValidationCondition sets the condition to True with the message, or to False if the message is empty.
+// The condition is left untouched when nothing changed to avoid a status update at each validation.
+func setValidationCondition(status *kanaryv1alpha1.KanaryDeploymentStatus, conditionType kanaryv1alpha1.KanaryDeploymentConditionType, message string) {
+	conditionStatus := corev1.ConditionFalse
+	if message != "" {
+		conditionStatus = corev1.ConditionTrue
+	}
+	for _, condition := range status.Conditions {
+		if condition.Type == conditionType && condition.Status == conditionStatus && condition.Message == message {
+			return
+		}
+	}
+	utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), conditionType, conditionStatus, message, false)
+}
@@ -0,0 +1,106 @@
+package strategies
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+func Test_computeInsufficientData(t *testing.T) {
+	tests := []struct {
+		name                 string
+		results              []*validation.Result
+		wantMessage          string
+		wantExtendValidation bool
+	}{
+		{
+			name:    "enough data",
+			results: []*validation.Result{{}, {IsFailed: true}},
+		},
+		{
+			name:        "warnings",
+			results:     []*validation.Result{{InsufficientData: "no data for pod A"}, {}, {InsufficientData: "no data for pod B"}},
+			wantMessage: "no data for pod A,no data for pod B",
+		},
+		{
+			name:                 "extension requested",
+			results:              []*validation.Result{{}, {InsufficientData: "no data for pod A", ExtendValidation: true}},
+			wantMessage:          "no data for pod A",
+			wantExtendValidation: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMessage, gotExtendValidation := computeInsufficientData(tt.results)
+			if gotMessage != tt.wantMessage {
+				t.Errorf("computeInsufficientData() message = %v, want %v", gotMessage, tt.wantMessage)
+			}
+			if gotExtendValidation != tt.wantExtendValidation {
+				t.Errorf("computeInsufficientData() extendValidation = %v, want %v", gotExtendValidation, tt.wantExtendValidation)
+			}
+		})
+	}
+}
+
+func Test_setInsufficientDataCondition(t *testing.T) {
+	warning := kanaryv1alpha1.KanaryDeploymentCondition{
+		Type:    kanaryv1alpha1.InsufficientDataKanaryDeploymentConditionType,
+		Status:  corev1.ConditionTrue,
+		Message: "no data for pod A",
+	}
+	tests := []struct {
+		name           string
+		conditions     []kanaryv1alpha1.KanaryDeploymentCondition
+		message        string
+		wantConditions int
+		wantStatus     corev1.ConditionStatus
+		wantUnchanged  bool
+	}{
+		{
+			name:           "no warning, no condition",
+			wantConditions: 0,
+		},
+		{
+			name:           "new warning",
+			message:        "no data for pod A",
+			wantConditions: 1,
+			wantStatus:     corev1.ConditionTrue,
+		},
+		{
+			name:           "same warning, condition untouched",
+			conditions:     []kanaryv1alpha1.KanaryDeploymentCondition{warning},
+			message:        "no data for pod A",
+			wantConditions: 1,
+			wantStatus:     corev1.ConditionTrue,
+			wantUnchanged:  true,
+		},
+		{
+			name:           "warning gone",
+			conditions:     []kanaryv1alpha1.KanaryDeploymentCondition{warning},
+			wantConditions: 1,
+			wantStatus:     corev1.ConditionFalse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &kanaryv1alpha1.KanaryDeploymentStatus{Conditions: tt.conditions}
+			setInsufficientDataCondition(status, tt.message)
+			if len(status.Conditions) != tt.wantConditions {
+				t.Fatalf("setInsufficientDataCondition() conditions = %v, want %d conditions", status.Conditions, tt.wantConditions)
+			}
+			if tt.wantConditions == 0 {
+				return
+			}
+			got := status.Conditions[0]
+			if got.Status != tt.wantStatus || got.Message != tt.message {
+				t.Errorf("setInsufficientDataCondition() condition = %v, want status %v and message %q", got, tt.wantStatus, tt.message)
+			}
+			if tt.wantUnchanged != got.LastUpdateTime.IsZero() {
+				t.Errorf("setInsufficientDataCondition() LastUpdateTime = %v, unchanged expected: %v", got.LastUpdateTime, tt.wantUnchanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package strategies
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// ApplyPauseAndAbort applies the pause and abort controls of a KanaryDeployment under validation,
+// status: the modified status, with the pause recorded to stop the validation clock
+// reconcile result: should the item be requeued, a paused KanaryDeployment waits for its spec update
+// if status and result are nil, the next step in the reconcile sequence should be engaged
+func ApplyPauseAndAbort(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (*kanaryv1alpha1.KanaryDeploymentStatus, *reconcile.Result) {
+	if utils.IsKanaryDeploymentValidationCompleted(&kd.Status) {
+		return nil, nil
+	}
+
+	now := metav1.Now()
+	if kd.Spec.Abort {
+		status := kd.Status.DeepCopy()
+		endPause(status, now)
+		utils.UpdateKanaryDeploymentStatusCondition(status, now, kanaryv1alpha1.FailedKanaryDeploymentConditionType, corev1.ConditionTrue, "KanaryDeployment aborted", false)
+		utils.UpdateKanaryDeploymentStatusCondition(status, now, kanaryv1alpha1.RunningKanaryDeploymentConditionType, corev1.ConditionFalse, "Validation aborted", false)
+		reqLogger.Info("Abort")
+		return status, &reconcile.Result{Requeue: true} // the failure cleanup happens at the next reconcile
+	}
+
+	if kd.Spec.Paused {
+		if kd.Status.PauseTime != nil {
+			return &kd.Status, &reconcile.Result{} // still paused, nothing to do until resumed
+		}
+		status := kd.Status.DeepCopy()
+		status.PauseTime = &now
+		utils.UpdateKanaryDeploymentStatusCondition(status, now, kanaryv1alpha1.PausedKanaryDeploymentConditionType, corev1.ConditionTrue, "KanaryDeployment paused", false)
+		reqLogger.Info("Pause")
+		return status, &reconcile.Result{}
+	}
+
+	if kd.Status.PauseTime != nil {
+		status := kd.Status.DeepCopy()
+		endPause(status, now)
+		reqLogger.Info("Resume")
+		return status, &reconcile.Result{Requeue: true}
+	}
+	return nil, nil
+}
+
+// endPause adds the current pause to the paused duration. A pause before the run start does not delay the validation timeline.
+func endPause(status *kanaryv1alpha1.KanaryDeploymentStatus, now metav1.Time) {
+	if status.PauseTime == nil {
+		return
+	}
+	if status.StartTime != nil {
+		var paused time.Duration
+		if status.PausedDuration != nil {
+			paused = status.PausedDuration.Duration
+		}
+		paused += now.Sub(status.PauseTime.Time)
+		status.PausedDuration = &metav1.Duration{Duration: paused}
+	}
+	status.PauseTime = nil
+	utils.UpdateKanaryDeploymentStatusCondition(status, now, kanaryv1alpha1.PausedKanaryDeploymentConditionType, corev1.ConditionFalse, "KanaryDeployment resumed", false)
+}
@@ -0,0 +1,116 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+func TestApplyPauseAndAbort(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("TestApplyPauseAndAbort")
+
+	started := metav1.NewTime(time.Now().Add(-time.Hour))
+	pausedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	running := []kanaryv1alpha1.KanaryDeploymentCondition{{Type: kanaryv1alpha1.RunningKanaryDeploymentConditionType, Status: corev1.ConditionTrue}}
+	paused := append(running, kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.PausedKanaryDeploymentConditionType, Status: corev1.ConditionTrue})
+	succeeded := []kanaryv1alpha1.KanaryDeploymentCondition{{Type: kanaryv1alpha1.SucceededKanaryDeploymentConditionType, Status: corev1.ConditionTrue}}
+
+	tests := []struct {
+		name             string
+		paused           bool
+		abort            bool
+		status           kanaryv1alpha1.KanaryDeploymentStatus
+		wantNil          bool
+		wantRequeue      bool
+		wantFailed       bool
+		wantPaused       bool
+		wantPausedAround time.Duration
+	}{
+		{
+			name:    "running",
+			status:  kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, Conditions: running},
+			wantNil: true,
+		},
+		{
+			name:       "pause",
+			paused:     true,
+			status:     kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, Conditions: running},
+			wantPaused: true,
+		},
+		{
+			name:       "still paused",
+			paused:     true,
+			status:     kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, PauseTime: &pausedAt, Conditions: paused},
+			wantPaused: true,
+		},
+		{
+			name:             "resume",
+			status:           kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, PauseTime: &pausedAt, PausedDuration: &metav1.Duration{Duration: 5 * time.Minute}, Conditions: running},
+			wantRequeue:      true,
+			wantPausedAround: 15 * time.Minute,
+		},
+		{
+			name:             "resume before the run start",
+			status:           kanaryv1alpha1.KanaryDeploymentStatus{PauseTime: &pausedAt},
+			wantRequeue:      true,
+			wantPausedAround: 0,
+		},
+		{
+			name:             "abort while paused",
+			paused:           true,
+			abort:            true,
+			status:           kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, PauseTime: &pausedAt, Conditions: running},
+			wantRequeue:      true,
+			wantFailed:       true,
+			wantPausedAround: 10 * time.Minute,
+		},
+		{
+			name:    "abort after the validation",
+			abort:   true,
+			status:  kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, Conditions: succeeded},
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kd := &kanaryv1alpha1.KanaryDeployment{}
+			kd.Spec.Paused = tt.paused
+			kd.Spec.Abort = tt.abort
+			kd.Status = tt.status
+
+			status, result := ApplyPauseAndAbort(reqLogger, kd)
+			if tt.wantNil {
+				if status != nil || result != nil {
+					t.Errorf("ApplyPauseAndAbort() = %v, %v, want nil", status, result)
+				}
+				return
+			}
+			if status == nil || result == nil {
+				t.Fatalf("ApplyPauseAndAbort() = %v, %v, want a status and a result", status, result)
+			}
+			if result.Requeue != tt.wantRequeue {
+				t.Errorf("ApplyPauseAndAbort() requeue = %v, want %v", result.Requeue, tt.wantRequeue)
+			}
+			if got := utils.IsKanaryDeploymentFailed(status); got != tt.wantFailed {
+				t.Errorf("ApplyPauseAndAbort() failed = %v, want %v", got, tt.wantFailed)
+			}
+			if got := status.PauseTime != nil && utils.IsKanaryDeploymentPaused(status); got != tt.wantPaused {
+				t.Errorf("ApplyPauseAndAbort() paused = %v, want %v", got, tt.wantPaused)
+			}
+			var paused time.Duration
+			if status.PausedDuration != nil {
+				paused = status.PausedDuration.Duration
+			}
+			if paused < tt.wantPausedAround || paused > tt.wantPausedAround+time.Minute {
+				t.Errorf("ApplyPauseAndAbort() pausedDuration = %s, want %s", paused, tt.wantPausedAround)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// newPostPromotionStatus returns the post-promotion progress recorded when the Deployment is updated with the kanary template.
+// The labels, annotations and spec of the Deployment before its update are kept to roll it back.
+func newPostPromotionStatus(dep *appsv1.Deployment, now metav1.Time) *kanaryv1alpha1.KanaryDeploymentStatusPostPromotion {
+	previous := dep.DeepCopy()
+	return &kanaryv1alpha1.KanaryDeploymentStatusPostPromotion{
+		PromotionTime: now,
+		PreviousTemplate: &kanaryv1alpha1.DeploymentTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      previous.Labels,
+				Annotations: previous.Annotations,
+			},
+			Spec: previous.Spec,
+		},
+	}
+}
+
+// verifyPromotion evaluates the post-promotion validation items against the main Deployment pods until the end of the
+// post-promotion period. The Deployment is rolled back to its previous template at the first failure.
+// A provider error leaves the verification running: it ends with the first conclusive evaluation after the period.
+func (s *strategy) verifyPromotion(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	progress := kd.Status.PostPromotion
+	if progress == nil || progress.EndTime != nil {
+		return &kd.Status, reconcile.Result{}, nil // nothing else to do... the verification is over
+	}
+
+	reqLogger.Info("Check Post-promotion Verification")
+	results, itemErrs := s.postPromotion.runValidations(kclient, reqLogger, kd, dep, dep)
+	errored := false
+	for i, err := range itemErrs {
+		if err != nil {
+			errored = true
+			reqLogger.Error(err, "Post-promotion validation error", "validation", utils.GetValidationItemName(&s.postPromotion.validationsItems[i]))
+		}
+	}
+
+	if failMessages, _ := computeStatus(results); failMessages != "" {
+		return rollbackDeployment(kclient, reqLogger, kd, dep, failMessages)
+	}
+
+	remaining := time.Until(progress.PromotionTime.Add(kd.Spec.Validations.PostPromotion.Period.Duration))
+	if remaining > 0 || errored {
+		d := kd.Spec.Validations.MaxIntervalPeriod.Duration
+		if remaining > 0 && remaining < d {
+			d = remaining
+		}
+		reqLogger.Info("Check Post-promotion Verification", "Periodic-Requeue", d)
+		return &kd.Status, reconcile.Result{RequeueAfter: d}, nil
+	}
+
+	status := kd.Status.DeepCopy()
+	now := metav1.Now()
+	status.PostPromotion.EndTime = &now
+	reqLogger.Info("Post-promotion verification ended with success")
+	return status, reconcile.Result{}, nil
+}
+
+// rollbackDeployment restores the labels, annotations and spec of the Deployment before its update, and sets the RolledBack condition
+func rollbackDeployment(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep *appsv1.Deployment, failMessages string) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	previous := kd.Status.PostPromotion.PreviousTemplate
+	if previous == nil {
+		return &kd.Status, reconcile.Result{}, fmt.Errorf("unable to roll back the Deployment, previous template not recorded")
+	}
+	newDep := dep.DeepCopy()
+	newDep.Labels = previous.Labels
+	newDep.Annotations = previous.Annotations
+	newDep.Spec = *previous.Spec.DeepCopy()
+	if err := kclient.Update(context.TODO(), newDep); err != nil {
+		reqLogger.Error(err, "failed to roll back the Deployment", "Namespace", newDep.Namespace, "Deployment", newDep.Name)
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	status := kd.Status.DeepCopy()
+	now := metav1.Now()
+	status.PostPromotion.EndTime = &now
+	utils.UpdateKanaryDeploymentStatusCondition(status, now, kanaryv1alpha1.RolledBackKanaryDeploymentConditionType, corev1.ConditionTrue, fmt.Sprintf("Deployment rolled back, %s", failMessages), false)
+	reqLogger.Info("Post-promotion verification", "rolled back", failMessages)
+	return status, reconcile.Result{}, nil
+}
@@ -0,0 +1,136 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+func Test_strategy_verifyPromotion(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_strategy_verifyPromotion")
+
+	newDeployment := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Labels: map[string]string{"version": image}},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: image}}},
+				},
+			},
+		}
+	}
+	promQL := kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}}
+
+	tests := []struct {
+		name           string
+		promotedSince  time.Duration
+		ended          bool
+		result         *validation.Result
+		err            error
+		wantRequeue    bool
+		wantEnded      bool
+		wantRolledBack bool
+	}{
+		{
+			name:          "verification running",
+			promotedSince: time.Minute,
+			result:        &validation.Result{},
+			wantRequeue:   true,
+		},
+		{
+			name:          "verification succeeded",
+			promotedSince: 15 * time.Minute,
+			result:        &validation.Result{},
+			wantEnded:     true,
+		},
+		{
+			name:          "provider error after the period",
+			promotedSince: 15 * time.Minute,
+			result:        &validation.Result{},
+			err:           fmt.Errorf("prometheus unreachable"),
+			wantRequeue:   true,
+		},
+		{
+			name:           "verification failed",
+			promotedSince:  time.Minute,
+			result:         &validation.Result{IsFailed: true, Comment: "error rate too high"},
+			wantEnded:      true,
+			wantRolledBack: true,
+		},
+		{
+			name:          "verification already ended",
+			promotedSince: 15 * time.Minute,
+			ended:         true,
+			result:        &validation.Result{IsFailed: true, Comment: "error rate too high"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDeployment("v2")
+			kclient := fake.NewFakeClient(dep)
+			progress := newPostPromotionStatus(newDeployment("v1"), metav1.NewTime(time.Now().Add(-tt.promotedSince)))
+			if tt.ended {
+				progress.EndTime = &progress.PromotionTime
+			}
+			kd := &kanaryv1alpha1.KanaryDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+					Validations: kanaryv1alpha1.KanaryDeploymentSpecValidationList{
+						MaxIntervalPeriod: &metav1.Duration{Duration: 20 * time.Second},
+						PostPromotion: &kanaryv1alpha1.KanaryDeploymentSpecValidationPostPromotion{
+							Period: &metav1.Duration{Duration: 10 * time.Minute},
+							Items:  []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+						},
+					},
+				},
+				Status: kanaryv1alpha1.KanaryDeploymentStatus{PostPromotion: progress},
+			}
+			s := &strategy{
+				postPromotion: &strategy{
+					validations:      []validation.Interface{&sleepValidation{result: tt.result, err: tt.err}},
+					validationsItems: kd.Spec.Validations.PostPromotion.Items,
+				},
+			}
+
+			status, result, err := s.verifyPromotion(kclient, reqLogger, kd, dep)
+			if err != nil {
+				t.Fatalf("strategy.verifyPromotion() unexpected error: %v", err)
+			}
+			if gotRequeue := result.RequeueAfter > 0; gotRequeue != tt.wantRequeue {
+				t.Errorf("strategy.verifyPromotion() requeue = %v, want %v", result.RequeueAfter, tt.wantRequeue)
+			}
+			if gotEnded := status.PostPromotion.EndTime != nil && !tt.ended; gotEnded != tt.wantEnded {
+				t.Errorf("strategy.verifyPromotion() ended = %v, want %v", gotEnded, tt.wantEnded)
+			}
+			if gotRolledBack := utils.IsKanaryDeploymentRolledBack(status); gotRolledBack != tt.wantRolledBack {
+				t.Errorf("strategy.verifyPromotion() rolled back = %v, want %v", gotRolledBack, tt.wantRolledBack)
+			}
+
+			current := &appsv1.Deployment{}
+			if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+				t.Fatalf("unable to get the deployment: %v", err)
+			}
+			wantImage := "v2"
+			if tt.wantRolledBack {
+				wantImage = "v1"
+			}
+			if image := current.Spec.Template.Spec.Containers[0].Image; image != wantImage || current.Labels["version"] != wantImage {
+				t.Errorf("deployment image = %s, version label = %s, want %s", image, current.Labels["version"], wantImage)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// isPromotionDone returns true once the last step of the progressive promotion is completed
+func isPromotionDone(config *kanaryv1alpha1.KanaryDeploymentSpecPromotion, progress *kanaryv1alpha1.KanaryDeploymentStatusPromotion) bool {
+	return progress != nil && int(progress.Step) >= len(config.Steps)
+}
+
+// getPromotionReplicas returns the replicas of the canary Deployment and of the Deployment at a promotion step
+func getPromotionReplicas(replicas, percent int32) (canaryReplicas, depReplicas int32) {
+	canaryReplicas = (replicas*percent + 99) / 100
+	if canaryReplicas < 1 {
+		canaryReplicas = 1
+	}
+	depReplicas = replicas - canaryReplicas
+	if depReplicas < 0 {
+		depReplicas = 0
+	}
+	return canaryReplicas, depReplicas
+}
+
+// promote runs the progressive promotion: the canary Deployment is scaled up step by step while the Deployment is scaled down.
+// During each step, the promotion validation items are evaluated against the canary pods, and the first failure reverts the promotion.
+// A provider error extends the current step until the next conclusive evaluation.
+func (s *strategy) promote(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	config := kd.Spec.Promotion
+	maxInterval := kd.Spec.Validations.MaxIntervalPeriod.Duration
+	progress := kd.Status.Promotion
+	if progress == nil {
+		replicas := int32(1)
+		if dep.Spec.Replicas != nil {
+			replicas = *dep.Spec.Replicas
+		}
+		progress = &kanaryv1alpha1.KanaryDeploymentStatusPromotion{Step: 0, StepStartTime: metav1.Now(), Replicas: replicas}
+		return s.startPromotionStep(kclient, reqLogger, kd, dep, canarydep, progress)
+	}
+
+	reqLogger.Info("Check Promotion", "step", progress.Step)
+	results, itemErrs := s.promotion.runValidations(kclient, reqLogger, kd, dep, canarydep)
+	errored := false
+	for i, err := range itemErrs {
+		if err != nil {
+			errored = true
+			reqLogger.Error(err, "Promotion validation error", "validation", utils.GetValidationItemName(&s.promotion.validationsItems[i]))
+		}
+	}
+
+	if failMessages, _ := computeStatus(results); failMessages != "" {
+		return revertPromotion(kclient, reqLogger, kd, dep, canarydep, failMessages)
+	}
+
+	remaining := time.Until(progress.StepStartTime.Add(config.StepDuration.Duration))
+	if remaining > 0 || errored {
+		d := maxInterval
+		if remaining > 0 && remaining < d {
+			d = remaining
+		}
+		reqLogger.Info("Check Promotion", "Periodic-Requeue", d)
+		return &kd.Status, reconcile.Result{RequeueAfter: d}, nil
+	}
+
+	next := progress.DeepCopy()
+	next.Step++
+	next.StepStartTime = metav1.Now()
+	if isPromotionDone(config, next) {
+		status := kd.Status.DeepCopy()
+		status.Promotion = next
+		reqLogger.Info("Promotion steps completed")
+		return status, reconcile.Result{Requeue: true}, nil
+	}
+	return s.startPromotionStep(kclient, reqLogger, kd, dep, canarydep, next)
+}
+
+// startPromotionStep splits the replicas between the canary Deployment and the Deployment for the step, and records the step
+func (s *strategy) startPromotionStep(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment, progress *kanaryv1alpha1.KanaryDeploymentStatusPromotion) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	percent := kd.Spec.Promotion.Steps[progress.Step]
+	canaryReplicas, depReplicas := getPromotionReplicas(progress.Replicas, percent)
+	// the canary Deployment is scaled up first, to keep the serving capacity
+	if err := setDeploymentReplicas(kclient, reqLogger, canarydep, canaryReplicas); err != nil {
+		return &kd.Status, reconcile.Result{}, err
+	}
+	if err := setDeploymentReplicas(kclient, reqLogger, dep, depReplicas); err != nil {
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	status := kd.Status.DeepCopy()
+	status.Promotion = progress
+	reqLogger.Info("Promotion step started", "step", progress.Step, "percent", percent, "canary replicas", canaryReplicas, "deployment replicas", depReplicas)
+	return status, reconcile.Result{RequeueAfter: kd.Spec.Validations.MaxIntervalPeriod.Duration}, nil
+}
+
+// revertPromotion restores the replicas of the Deployment and of the canary Deployment, and fails the KanaryDeployment
+func revertPromotion(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment, failMessages string) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	progress := kd.Status.Promotion
+	if err := setDeploymentReplicas(kclient, reqLogger, dep, progress.Replicas); err != nil {
+		return &kd.Status, reconcile.Result{}, err
+	}
+	canaryReplicas := int32(1)
+	if replicas := utils.GetCanaryReplicasValue(kd); replicas != nil {
+		canaryReplicas = *replicas
+	}
+	if err := setDeploymentReplicas(kclient, reqLogger, canarydep, canaryReplicas); err != nil {
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	status := kd.Status.DeepCopy()
+	message := fmt.Sprintf("promotion step %d (%d%%) failed, %s", progress.Step, kd.Spec.Promotion.Steps[progress.Step], failMessages)
+	utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.SucceededKanaryDeploymentConditionType, corev1.ConditionFalse, "Promotion reverted", false)
+	utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.FailedKanaryDeploymentConditionType, corev1.ConditionTrue, fmt.Sprintf("KanaryDeployment failed, %s", message), false)
+	reqLogger.Info("Promotion reverted", "reason", message)
+	return status, reconcile.Result{Requeue: true}, nil
+}
+
+// RestorePromotionReplicas scales the Deployment back to its replicas from before the progressive promotion, when the promotion
+// is interrupted before the Deployment update: the canary Deployment serving the promoted replicas is about to be deleted.
+func RestorePromotionReplicas(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) error {
+	if kd.Status.Promotion == nil || utils.IsKanaryDeploymentDeploymentUpdated(&kd.Status) {
+		return nil
+	}
+	dep := &appsv1.Deployment{}
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: utils.GetDeploymentName(kd), Namespace: kd.Namespace}, dep)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return setDeploymentReplicas(kclient, reqLogger, dep, kd.Status.Promotion.Replicas)
+}
+
+// setDeploymentReplicas updates the replicas of the Deployment if needed
+func setDeploymentReplicas(kclient client.Client, reqLogger logr.Logger, dep *appsv1.Deployment, replicas int32) error {
+	if dep.Spec.Replicas != nil && *dep.Spec.Replicas == replicas {
+		return nil
+	}
+	updateDep := dep.DeepCopy()
+	updateDep.Spec.Replicas = &replicas
+	if err := kclient.Update(context.TODO(), updateDep); err != nil {
+		reqLogger.Error(err, "failed to update Deployment replicas", "Namespace", updateDep.Namespace, "Deployment", updateDep.Name)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+func Test_getPromotionReplicas(t *testing.T) {
+	tests := []struct {
+		name           string
+		replicas       int32
+		percent        int32
+		wantCanary     int32
+		wantDeployment int32
+	}{
+		{name: "exact split", replicas: 4, percent: 25, wantCanary: 1, wantDeployment: 3},
+		{name: "rounded up canary", replicas: 3, percent: 50, wantCanary: 2, wantDeployment: 1},
+		{name: "at least one canary", replicas: 0, percent: 25, wantCanary: 1, wantDeployment: 0},
+		{name: "single replica", replicas: 1, percent: 75, wantCanary: 1, wantDeployment: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCanary, gotDeployment := getPromotionReplicas(tt.replicas, tt.percent)
+			if gotCanary != tt.wantCanary || gotDeployment != tt.wantDeployment {
+				t.Errorf("getPromotionReplicas() = %d/%d, want %d/%d", gotCanary, gotDeployment, tt.wantCanary, tt.wantDeployment)
+			}
+		})
+	}
+}
+
+func Test_strategy_promote(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_strategy_promote")
+
+	newDeployment := func(name string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+	succeeded := []kanaryv1alpha1.KanaryDeploymentCondition{{Type: kanaryv1alpha1.SucceededKanaryDeploymentConditionType, Status: corev1.ConditionTrue}}
+	promQL := kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}}
+
+	tests := []struct {
+		name               string
+		depReplicas        int32
+		canaryReplicas     int32
+		progress           *kanaryv1alpha1.KanaryDeploymentStatusPromotion
+		result             *validation.Result
+		wantStep           int32
+		wantRequeueAfter   bool
+		wantDepReplicas    int32
+		wantCanaryReplicas int32
+		wantFailed         bool
+	}{
+		{
+			name:               "first step",
+			depReplicas:        4,
+			canaryReplicas:     1,
+			result:             &validation.Result{},
+			wantStep:           0,
+			wantRequeueAfter:   true,
+			wantDepReplicas:    3,
+			wantCanaryReplicas: 1,
+		},
+		{
+			name:               "step in progress",
+			depReplicas:        3,
+			canaryReplicas:     1,
+			progress:           &kanaryv1alpha1.KanaryDeploymentStatusPromotion{Step: 0, StepStartTime: metav1.NewTime(time.Now().Add(-time.Minute)), Replicas: 4},
+			result:             &validation.Result{},
+			wantStep:           0,
+			wantRequeueAfter:   true,
+			wantDepReplicas:    3,
+			wantCanaryReplicas: 1,
+		},
+		{
+			name:               "next step",
+			depReplicas:        3,
+			canaryReplicas:     1,
+			progress:           &kanaryv1alpha1.KanaryDeploymentStatusPromotion{Step: 0, StepStartTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)), Replicas: 4},
+			result:             &validation.Result{},
+			wantStep:           1,
+			wantRequeueAfter:   true,
+			wantDepReplicas:    2,
+			wantCanaryReplicas: 2,
+		},
+		{
+			name:               "last step completed",
+			depReplicas:        1,
+			canaryReplicas:     3,
+			progress:           &kanaryv1alpha1.KanaryDeploymentStatusPromotion{Step: 2, StepStartTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)), Replicas: 4},
+			result:             &validation.Result{},
+			wantStep:           3,
+			wantDepReplicas:    1,
+			wantCanaryReplicas: 3,
+		},
+		{
+			name:               "step failed",
+			depReplicas:        2,
+			canaryReplicas:     2,
+			progress:           &kanaryv1alpha1.KanaryDeploymentStatusPromotion{Step: 1, StepStartTime: metav1.NewTime(time.Now().Add(-time.Minute)), Replicas: 4},
+			result:             &validation.Result{IsFailed: true, Comment: "error rate too high"},
+			wantStep:           1,
+			wantDepReplicas:    4,
+			wantCanaryReplicas: 1,
+			wantFailed:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newDeployment("foo", tt.depReplicas)
+			canarydep := newDeployment("foo-kanary-foo", tt.canaryReplicas)
+			kclient := fake.NewFakeClient(dep, canarydep)
+			kd := &kanaryv1alpha1.KanaryDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+					Scale: kanaryv1alpha1.KanaryDeploymentSpecScale{Static: &kanaryv1alpha1.KanaryDeploymentSpecScaleStatic{Replicas: kanaryv1alpha1.NewInt32(1)}},
+					Validations: kanaryv1alpha1.KanaryDeploymentSpecValidationList{
+						MaxIntervalPeriod: &metav1.Duration{Duration: 20 * time.Second},
+					},
+					Promotion: &kanaryv1alpha1.KanaryDeploymentSpecPromotion{
+						Steps:        []int32{25, 50, 75},
+						StepDuration: &metav1.Duration{Duration: 5 * time.Minute},
+						Items:        []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+					},
+				},
+				Status: kanaryv1alpha1.KanaryDeploymentStatus{Conditions: succeeded, Promotion: tt.progress},
+			}
+			s := &strategy{
+				promotion: &strategy{
+					validations:      []validation.Interface{&sleepValidation{result: tt.result}},
+					validationsItems: kd.Spec.Promotion.Items,
+				},
+			}
+
+			status, result, err := s.promote(kclient, reqLogger, kd, dep, canarydep)
+			if err != nil {
+				t.Fatalf("strategy.promote() unexpected error: %v", err)
+			}
+			if status.Promotion == nil || status.Promotion.Step != tt.wantStep {
+				t.Errorf("strategy.promote() promotion = %v, want step %d", status.Promotion, tt.wantStep)
+			}
+			if gotRequeueAfter := result.RequeueAfter > 0; gotRequeueAfter != tt.wantRequeueAfter {
+				t.Errorf("strategy.promote() requeueAfter = %v, want %v", result.RequeueAfter, tt.wantRequeueAfter)
+			}
+			if gotFailed := utils.IsKanaryDeploymentFailed(status); gotFailed != tt.wantFailed {
+				t.Errorf("strategy.promote() failed = %v, want %v", gotFailed, tt.wantFailed)
+			}
+			if tt.wantFailed && utils.IsKanaryDeploymentSucceeded(status) {
+				t.Errorf("strategy.promote() succeeded condition not reset after a failure")
+			}
+
+			for name, want := range map[string]int32{"foo": tt.wantDepReplicas, "foo-kanary-foo": tt.wantCanaryReplicas} {
+				current := &appsv1.Deployment{}
+				if err = kclient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, current); err != nil {
+					t.Fatalf("unable to get the deployment %s: %v", name, err)
+				}
+				if *current.Spec.Replicas != want {
+					t.Errorf("deployment %s replicas = %d, want %d", name, *current.Spec.Replicas, want)
+				}
+			}
+		})
+	}
+}
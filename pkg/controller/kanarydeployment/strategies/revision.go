@@ -0,0 +1,111 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/traffic"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// maxRunHistory maximum number of previous runs kept in the status history
+const maxRunHistory = 10
+
+// IsNewRunRequested returns true if the deployment or variants templates were updated or a restart was requested since the current run started
+func IsNewRunRequested(kd *kanaryv1alpha1.KanaryDeployment, currentHash string) bool {
+	if kd.Status.CurrentHash == "" {
+		return false // no run started yet
+	}
+	return kd.Status.CurrentHash != currentHash || kd.Spec.Restart != kd.Status.ObservedRestart
+}
+
+// NewRunStatus returns the status of a new run: the current run is archived in the history and the conditions are reset,
+// except the scheduling one. The canary deployment is expected to be recreated with the current deployment template.
+func NewRunStatus(kd *kanaryv1alpha1.KanaryDeployment, now metav1.Time) *kanaryv1alpha1.KanaryDeploymentStatus {
+	revision := kd.Status.Revision
+	if revision == 0 {
+		revision = 1 // run started before the revisions were recorded
+	}
+	run := kanaryv1alpha1.KanaryDeploymentStatusRun{
+		Revision:  revision,
+		Hash:      kd.Status.CurrentHash,
+		StartTime: kd.Status.StartTime.DeepCopy(),
+		EndTime:   now,
+		Outcome:   kd.Status.Report.Status,
+	}
+	switch {
+	case utils.IsKanaryDeploymentFailed(&kd.Status):
+		run.Reason = getConditionMessage(&kd.Status, kanaryv1alpha1.FailedKanaryDeploymentConditionType)
+	case utils.IsKanaryDeploymentRolledBack(&kd.Status):
+		run.Reason = getConditionMessage(&kd.Status, kanaryv1alpha1.RolledBackKanaryDeploymentConditionType)
+	case utils.IsKanaryDeploymentValidationCompleted(&kd.Status):
+	case kd.Spec.Restart != kd.Status.ObservedRestart:
+		run.Reason = "interrupted by a restart request"
+	default:
+		run.Reason = "interrupted by a deployment template update"
+	}
+
+	status := &kanaryv1alpha1.KanaryDeploymentStatus{
+		Revision:        revision + 1,
+		ObservedRestart: kd.Spec.Restart,
+		History:         append(kd.Status.DeepCopy().History, run),
+	}
+	if len(status.History) > maxRunHistory {
+		status.History = status.History[len(status.History)-maxRunHistory:]
+	}
+	for _, condition := range kd.Status.Conditions {
+		if condition.Type == kanaryv1alpha1.ScheduledKanaryDeploymentConditionType {
+			status.Conditions = append(status.Conditions, condition)
+		}
+	}
+	return status
+}
+
+// DeleteRunResources deletes the resources created for the current run, to recreate them for the new run:
+// the validation Jobs, that would report the previous run result, and the load generator Deployment.
+func DeleteRunResources(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) error {
+	for _, item := range kd.Spec.Validations.Items {
+		if item.Job == nil {
+			continue
+		}
+		// the Job pods are deleted with the Job
+		if err := deleteRunResource(kclient, reqLogger, &batchv1.Job{}, validation.GetJobName(kd, item.Job), kd.Namespace, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			return err
+		}
+	}
+	return deleteRunResource(kclient, reqLogger, &appsv1.Deployment{}, traffic.GetLoadGeneratorDeploymentName(kd), kd.Namespace)
+}
+
+func deleteRunResource(kclient client.Client, reqLogger logr.Logger, obj runtime.Object, name, namespace string, opts ...client.DeleteOptionFunc) error {
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, obj)
+	if err == nil {
+		reqLogger.Info("Deleting a resource of the previous run", "Name", name)
+		err = kclient.Delete(context.TODO(), obj, opts...)
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete %s of the previous run: %v", name, err)
+	}
+	return nil
+}
+
+func getConditionMessage(status *kanaryv1alpha1.KanaryDeploymentStatus, conditionType kanaryv1alpha1.KanaryDeploymentConditionType) string {
+	for _, condition := range status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Message
+		}
+	}
+	return ""
+}
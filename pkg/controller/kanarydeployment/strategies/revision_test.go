@@ -0,0 +1,175 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+func TestIsNewRunRequested(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      kanaryv1alpha1.KanaryDeploymentStatus
+		restart     string
+		currentHash string
+		want        bool
+	}{
+		{
+			name:        "no run started",
+			restart:     "1",
+			currentHash: "hash",
+		},
+		{
+			name:        "same run",
+			status:      kanaryv1alpha1.KanaryDeploymentStatus{CurrentHash: "hash", ObservedRestart: "1"},
+			restart:     "1",
+			currentHash: "hash",
+		},
+		{
+			name:        "template updated",
+			status:      kanaryv1alpha1.KanaryDeploymentStatus{CurrentHash: "hash"},
+			currentHash: "new-hash",
+			want:        true,
+		},
+		{
+			name:        "restart requested",
+			status:      kanaryv1alpha1.KanaryDeploymentStatus{CurrentHash: "hash", ObservedRestart: "1"},
+			restart:     "2",
+			currentHash: "hash",
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kd := &kanaryv1alpha1.KanaryDeployment{}
+			kd.Spec.Restart = tt.restart
+			kd.Status = tt.status
+			if got := IsNewRunRequested(kd, tt.currentHash); got != tt.want {
+				t.Errorf("IsNewRunRequested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRunStatus(t *testing.T) {
+	now := metav1.Now()
+	started := metav1.NewTime(now.Add(-time.Hour))
+	scheduled := kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.ScheduledKanaryDeploymentConditionType, Status: corev1.ConditionTrue}
+	running := kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.RunningKanaryDeploymentConditionType, Status: corev1.ConditionTrue}
+	failed := kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.FailedKanaryDeploymentConditionType, Status: corev1.ConditionTrue, Message: "KanaryDeployment aborted"}
+	updated := kanaryv1alpha1.KanaryDeploymentCondition{Type: kanaryv1alpha1.DeploymentUpdatedKanaryDeploymentConditionType, Status: corev1.ConditionTrue}
+	fullHistory := make([]kanaryv1alpha1.KanaryDeploymentStatusRun, maxRunHistory)
+	for i := range fullHistory {
+		fullHistory[i] = kanaryv1alpha1.KanaryDeploymentStatusRun{Revision: int32(i + 1), Hash: fmt.Sprintf("hash-%d", i+1), Outcome: "Failed"}
+	}
+
+	tests := []struct {
+		name    string
+		restart string
+		status  kanaryv1alpha1.KanaryDeploymentStatus
+		want    *kanaryv1alpha1.KanaryDeploymentStatus
+	}{
+		{
+			name: "failed run",
+			status: kanaryv1alpha1.KanaryDeploymentStatus{
+				CurrentHash: "hash", Revision: 1, StartTime: &started,
+				Report:     kanaryv1alpha1.KanaryDeploymentStatusReport{Status: "Failed"},
+				Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{scheduled, failed},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatus{
+				Revision:   2,
+				History:    []kanaryv1alpha1.KanaryDeploymentStatusRun{{Revision: 1, Hash: "hash", StartTime: &started, EndTime: now, Outcome: "Failed", Reason: "KanaryDeployment aborted"}},
+				Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{scheduled},
+			},
+		},
+		{
+			name: "succeeded run",
+			status: kanaryv1alpha1.KanaryDeploymentStatus{
+				CurrentHash: "hash", Revision: 2,
+				Report:     kanaryv1alpha1.KanaryDeploymentStatusReport{Status: "DeploymentUpdated"},
+				Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{scheduled, updated},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatus{
+				Revision:   3,
+				History:    []kanaryv1alpha1.KanaryDeploymentStatusRun{{Revision: 2, Hash: "hash", EndTime: now, Outcome: "DeploymentUpdated"}},
+				Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{scheduled},
+			},
+		},
+		{
+			name:    "running run restarted",
+			restart: "again",
+			status: kanaryv1alpha1.KanaryDeploymentStatus{
+				CurrentHash: "hash",
+				Report:      kanaryv1alpha1.KanaryDeploymentStatusReport{Status: "Running"},
+				Conditions:  []kanaryv1alpha1.KanaryDeploymentCondition{scheduled, running},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatus{
+				Revision:        2,
+				ObservedRestart: "again",
+				History:         []kanaryv1alpha1.KanaryDeploymentStatusRun{{Revision: 1, Hash: "hash", EndTime: now, Outcome: "Running", Reason: "interrupted by a restart request"}},
+				Conditions:      []kanaryv1alpha1.KanaryDeploymentCondition{scheduled},
+			},
+		},
+		{
+			name: "bounded history",
+			status: kanaryv1alpha1.KanaryDeploymentStatus{
+				CurrentHash: "hash-11", Revision: 11, History: fullHistory,
+				Report: kanaryv1alpha1.KanaryDeploymentStatusReport{Status: "Running"},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatus{
+				Revision: 12,
+				History:  append(fullHistory[1:], kanaryv1alpha1.KanaryDeploymentStatusRun{Revision: 11, Hash: "hash-11", EndTime: now, Outcome: "Running", Reason: "interrupted by a deployment template update"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kd := &kanaryv1alpha1.KanaryDeployment{}
+			kd.Spec.Restart = tt.restart
+			kd.Status = tt.status
+			if got := NewRunStatus(kd, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRunStatus() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRunResources(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("TestDeleteRunResources")
+
+	kd := &kanaryv1alpha1.KanaryDeployment{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	kd.Spec.Validations.Items = []kanaryv1alpha1.KanaryDeploymentSpecValidation{{Job: &kanaryv1alpha1.KanaryDeploymentSpecValidationJob{}}}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "foo-kanary-job", Namespace: "default"}}
+	loadGenerator := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "foo-kanary-loadgenerator", Namespace: "default"}}
+	kclient := fake.NewFakeClient(job, loadGenerator)
+
+	if err := DeleteRunResources(kclient, reqLogger, kd); err != nil {
+		t.Fatalf("DeleteRunResources() unexpected error: %v", err)
+	}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: "default"}, &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Errorf("validation job not deleted, err: %v", err)
+	}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Name: loadGenerator.Name, Namespace: "default"}, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("load generator deployment not deleted, err: %v", err)
+	}
+
+	// nothing left to delete
+	if err := DeleteRunResources(kclient, reqLogger, kd); err != nil {
+		t.Errorf("DeleteRunResources() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package strategies
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+// progressDeadlineExceededReason reason of the Deployment Progressing condition once the progress deadline is exceeded
+const progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+// newRolloutStatus returns the rollout progress of the Deployment, computed like `kubectl rollout status`
+// from its observed generation, its replica counts and its Progressing condition.
+// The Deployment read from the cache may predate the update with the kanary template: the rollout
+// can't be complete before the generation recorded at the update is observed.
+func newRolloutStatus(dep *appsv1.Deployment, previous *kanaryv1alpha1.KanaryDeploymentStatusRollout, now metav1.Time) *kanaryv1alpha1.KanaryDeploymentStatusRollout {
+	var generation int64
+	if previous != nil {
+		generation = previous.Generation
+	}
+	rollout := &kanaryv1alpha1.KanaryDeploymentStatusRollout{
+		Generation:         generation,
+		ObservedGeneration: dep.Status.ObservedGeneration,
+		Replicas:           dep.Status.Replicas,
+		UpdatedReplicas:    dep.Status.UpdatedReplicas,
+		ReadyReplicas:      dep.Status.ReadyReplicas,
+		AvailableReplicas:  dep.Status.AvailableReplicas,
+		LastTransitionTime: now,
+	}
+	desired := int32(1)
+	if dep.Spec.Replicas != nil {
+		desired = *dep.Spec.Replicas
+	}
+
+	switch {
+	case dep.Generation < generation, dep.Status.ObservedGeneration < generation, dep.Status.ObservedGeneration < dep.Generation:
+		rollout.Status = kanaryv1alpha1.InProgressRolloutStatus
+		rollout.Message = "waiting for the deployment update to be observed"
+	case isProgressDeadlineExceeded(dep):
+		rollout.Status = kanaryv1alpha1.FailedRolloutStatus
+		rollout.Message = fmt.Sprintf("deployment exceeded its progress deadline: %s", getProgressingMessage(dep))
+	case dep.Status.UpdatedReplicas < desired:
+		rollout.Status = kanaryv1alpha1.InProgressRolloutStatus
+		rollout.Message = fmt.Sprintf("%d out of %d new replicas have been updated", dep.Status.UpdatedReplicas, desired)
+	case dep.Status.Replicas > dep.Status.UpdatedReplicas:
+		rollout.Status = kanaryv1alpha1.InProgressRolloutStatus
+		rollout.Message = fmt.Sprintf("%d old replicas are pending termination", dep.Status.Replicas-dep.Status.UpdatedReplicas)
+	case dep.Status.AvailableReplicas < dep.Status.UpdatedReplicas:
+		rollout.Status = kanaryv1alpha1.InProgressRolloutStatus
+		rollout.Message = fmt.Sprintf("%d of %d updated replicas are available", dep.Status.AvailableReplicas, dep.Status.UpdatedReplicas)
+	default:
+		rollout.Status = kanaryv1alpha1.CompleteRolloutStatus
+		rollout.Message = "deployment successfully rolled out"
+	}
+
+	if previous != nil && previous.Status == rollout.Status {
+		rollout.LastTransitionTime = previous.LastTransitionTime
+	}
+	return rollout
+}
+
+func isProgressDeadlineExceeded(dep *appsv1.Deployment) bool {
+	for _, condition := range dep.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Reason == progressDeadlineExceededReason
+		}
+	}
+	return false
+}
+
+func getProgressingMessage(dep *appsv1.Deployment) string {
+	for _, condition := range dep.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+// trackRollout returns the status updated with the rollout progress of the Deployment.
+// While the rollout is in progress, the KanaryDeployment is requeued at the latest after MaxIntervalPeriod.
+func trackRollout(kd *kanaryv1alpha1.KanaryDeployment, dep *appsv1.Deployment, status *kanaryv1alpha1.KanaryDeploymentStatus, result reconcile.Result, err error) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	rollout := newRolloutStatus(dep, kd.Status.Rollout, metav1.Now())
+	if !apiequality.Semantic.DeepEqual(status.Rollout, rollout) {
+		if status == &kd.Status {
+			status = kd.Status.DeepCopy()
+		}
+		status.Rollout = rollout
+	}
+	if rollout.Status == kanaryv1alpha1.InProgressRolloutStatus {
+		d := kd.Spec.Validations.MaxIntervalPeriod.Duration
+		if result.RequeueAfter == 0 || result.RequeueAfter > d {
+			result.RequeueAfter = d
+		}
+	}
+	return status, result, err
+}
@@ -0,0 +1,111 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+func Test_newRolloutStatus(t *testing.T) {
+	now := metav1.Now()
+	before := metav1.NewTime(now.Add(-time.Minute))
+	newDeployment := func(generation int64, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: kanaryv1alpha1.NewInt32(3)},
+			Status:     status,
+		}
+	}
+	deadlineExceeded := []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: progressDeadlineExceededReason, Message: `ReplicaSet "foo-5b7b9c7d4" has timed out progressing.`},
+	}
+
+	tests := []struct {
+		name               string
+		dep                *appsv1.Deployment
+		previous           *kanaryv1alpha1.KanaryDeploymentStatusRollout
+		wantStatus         kanaryv1alpha1.RolloutStatus
+		wantMessage        string
+		wantTransitionTime metav1.Time
+	}{
+		{
+			name:               "update not observed",
+			dep:                newDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			wantStatus:         kanaryv1alpha1.InProgressRolloutStatus,
+			wantMessage:        "waiting for the deployment update to be observed",
+			wantTransitionTime: now,
+		},
+		{
+			name:               "update not yet in the cache",
+			dep:                newDeployment(1, appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			previous:           &kanaryv1alpha1.KanaryDeploymentStatusRollout{Status: kanaryv1alpha1.InProgressRolloutStatus, Generation: 2, LastTransitionTime: before},
+			wantStatus:         kanaryv1alpha1.InProgressRolloutStatus,
+			wantMessage:        "waiting for the deployment update to be observed",
+			wantTransitionTime: before,
+		},
+		{
+			name:               "rollout complete once the update is observed",
+			dep:                newDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}),
+			previous:           &kanaryv1alpha1.KanaryDeploymentStatusRollout{Status: kanaryv1alpha1.InProgressRolloutStatus, Generation: 2, LastTransitionTime: before},
+			wantStatus:         kanaryv1alpha1.CompleteRolloutStatus,
+			wantMessage:        "deployment successfully rolled out",
+			wantTransitionTime: now,
+		},
+		{
+			name:               "replicas being updated",
+			dep:                newDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3}),
+			previous:           &kanaryv1alpha1.KanaryDeploymentStatusRollout{Status: kanaryv1alpha1.InProgressRolloutStatus, LastTransitionTime: before},
+			wantStatus:         kanaryv1alpha1.InProgressRolloutStatus,
+			wantMessage:        "1 out of 3 new replicas have been updated",
+			wantTransitionTime: before,
+		},
+		{
+			name:               "old replicas terminating",
+			dep:                newDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			wantStatus:         kanaryv1alpha1.InProgressRolloutStatus,
+			wantMessage:        "1 old replicas are pending termination",
+			wantTransitionTime: now,
+		},
+		{
+			name:               "updated replicas not available",
+			dep:                newDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}),
+			wantStatus:         kanaryv1alpha1.InProgressRolloutStatus,
+			wantMessage:        "2 of 3 updated replicas are available",
+			wantTransitionTime: now,
+		},
+		{
+			name:               "progress deadline exceeded",
+			dep:                newDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3, Conditions: deadlineExceeded}),
+			previous:           &kanaryv1alpha1.KanaryDeploymentStatusRollout{Status: kanaryv1alpha1.InProgressRolloutStatus, LastTransitionTime: before},
+			wantStatus:         kanaryv1alpha1.FailedRolloutStatus,
+			wantMessage:        `deployment exceeded its progress deadline: ReplicaSet "foo-5b7b9c7d4" has timed out progressing.`,
+			wantTransitionTime: now,
+		},
+		{
+			name:               "rollout complete",
+			dep:                newDeployment(2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}),
+			wantStatus:         kanaryv1alpha1.CompleteRolloutStatus,
+			wantMessage:        "deployment successfully rolled out",
+			wantTransitionTime: now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newRolloutStatus(tt.dep, tt.previous, now)
+			if got.Status != tt.wantStatus {
+				t.Errorf("newRolloutStatus() status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("newRolloutStatus() message = %q, want %q", got.Message, tt.wantMessage)
+			}
+			if !got.LastTransitionTime.Equal(&tt.wantTransitionTime) {
+				t.Errorf("newRolloutStatus() lastTransitionTime = %v, want %v", got.LastTransitionTime, tt.wantTransitionTime)
+			}
+		})
+	}
+}
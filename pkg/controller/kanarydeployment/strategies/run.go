@@ -0,0 +1,92 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// defaultValidationTimeout maximum duration of an evaluation of the validation items, when not defined in the spec
+const defaultValidationTimeout = time.Minute
+
+// validationOutcome result of a validation item evaluation
+type validationOutcome struct {
+	result *validation.Result
+	err    error
+}
+
+// runValidations evaluates the validation items concurrently, each one bounded by its own timeout and all by the validation list timeout.
+// The results and the errors are indexed like the validation items, a timed-out item gets a TimedOut result and an error, so its
+// error policy applies as for a provider error.
+func (s *strategy) runValidations(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment) ([]*validation.Result, []error) {
+	listTimeout := getTimeout(kd.Spec.Validations.Timeout, defaultValidationTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+
+	results := make([]*validation.Result, len(s.validations))
+	errs := make([]error, len(s.validations))
+	var wg sync.WaitGroup
+	for i := range s.validations {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			item := &s.validationsItems[i]
+			timeout := getTimeout(item.Timeout, listTimeout)
+			if timeout > listTimeout {
+				timeout = listTimeout
+			}
+			results[i], errs[i] = runValidation(ctx, timeout, s.validations[i], item, kclient, reqLogger, kd, dep, canarydep)
+		}(i)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+// runValidation evaluates a validation item, giving up when the timeout is reached.
+// The evaluation goroutine is not waited for: it is expected to stop on the cancellation of its context.
+func runValidation(ctx context.Context, timeout time.Duration, impl validation.Interface, item *kanaryv1alpha1.KanaryDeploymentSpecValidation, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canarydep *appsv1.Deployment) (*validation.Result, error) {
+	itemCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	outcome := make(chan validationOutcome, 1)
+	go func() {
+		result, err := impl.Validation(itemCtx, kclient, reqLogger, kd, dep, canarydep)
+		outcome <- validationOutcome{result: result, err: err}
+	}()
+
+	select {
+	case o := <-outcome:
+		if o.err != nil && itemCtx.Err() == context.DeadlineExceeded {
+			// the evaluation failed because of the timeout
+			return newTimedOutResult(item, timeout)
+		}
+		return o.result, o.err
+	case <-itemCtx.Done():
+		reqLogger.Info("Validation timed out", "validation", utils.GetValidationItemName(item), "timeout", timeout)
+		return newTimedOutResult(item, timeout)
+	}
+}
+
+func newTimedOutResult(item *kanaryv1alpha1.KanaryDeploymentSpecValidation, timeout time.Duration) (*validation.Result, error) {
+	err := fmt.Errorf("%s validation timed out after %s", utils.GetValidationItemName(item), timeout)
+	return &validation.Result{TimedOut: true, Comment: err.Error()}, err
+}
+
+func getTimeout(timeout *metav1.Duration, defaultTimeout time.Duration) time.Duration {
+	if timeout == nil {
+		return defaultTimeout
+	}
+	return timeout.Duration
+}
@@ -0,0 +1,132 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+// sleepValidation validation taking some time, optionally ignoring the context cancellation
+type sleepValidation struct {
+	duration      time.Duration
+	ignoreContext bool
+	result        *validation.Result
+	err           error
+}
+
+func (v *sleepValidation) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*validation.Result, error) {
+	if v.ignoreContext {
+		time.Sleep(v.duration)
+		return v.result, v.err
+	}
+	select {
+	case <-time.After(v.duration):
+		return v.result, v.err
+	case <-ctx.Done():
+		return &validation.Result{}, ctx.Err()
+	}
+}
+
+func Test_strategy_runValidations(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_strategy_runValidations")
+
+	promQL := kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}}
+	shortTimeout := kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}, Timeout: &metav1.Duration{Duration: 50 * time.Millisecond}}
+	failed := &validation.Result{IsFailed: true, Comment: "failed"}
+	timedOut := func(timeout time.Duration) *validation.Result {
+		return &validation.Result{TimedOut: true, Comment: fmt.Sprintf("promQL validation timed out after %s", timeout)}
+	}
+
+	tests := []struct {
+		name        string
+		listTimeout time.Duration
+		items       []kanaryv1alpha1.KanaryDeploymentSpecValidation
+		validations []validation.Interface
+		want        []*validation.Result
+		wantErrs    []bool
+		maxDuration time.Duration
+	}{
+		{
+			name:        "concurrent validations",
+			listTimeout: time.Second,
+			items:       []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL, promQL, promQL},
+			validations: []validation.Interface{
+				&sleepValidation{duration: 100 * time.Millisecond, result: &validation.Result{}},
+				&sleepValidation{duration: 100 * time.Millisecond, result: failed},
+				&sleepValidation{duration: 100 * time.Millisecond, result: &validation.Result{}, err: fmt.Errorf("prometheus unavailable")},
+			},
+			want:        []*validation.Result{{}, failed, {}},
+			wantErrs:    []bool{false, false, true},
+			maxDuration: 250 * time.Millisecond,
+		},
+		{
+			name:        "item timeout",
+			listTimeout: time.Second,
+			items:       []kanaryv1alpha1.KanaryDeploymentSpecValidation{shortTimeout, promQL},
+			validations: []validation.Interface{
+				&sleepValidation{duration: time.Second, result: failed},
+				&sleepValidation{duration: 10 * time.Millisecond, result: failed},
+			},
+			want:        []*validation.Result{timedOut(50 * time.Millisecond), failed},
+			wantErrs:    []bool{true, false},
+			maxDuration: 500 * time.Millisecond,
+		},
+		{
+			name:        "item ignoring the context cancellation",
+			listTimeout: time.Second,
+			items:       []kanaryv1alpha1.KanaryDeploymentSpecValidation{shortTimeout},
+			validations: []validation.Interface{
+				&sleepValidation{duration: time.Second, ignoreContext: true, result: failed},
+			},
+			want:        []*validation.Result{timedOut(50 * time.Millisecond)},
+			wantErrs:    []bool{true},
+			maxDuration: 500 * time.Millisecond,
+		},
+		{
+			name:        "list timeout",
+			listTimeout: 50 * time.Millisecond,
+			items:       []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+			validations: []validation.Interface{
+				&sleepValidation{duration: time.Second, result: failed},
+			},
+			want:        []*validation.Result{timedOut(50 * time.Millisecond)},
+			wantErrs:    []bool{true},
+			maxDuration: 500 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &strategy{validations: tt.validations, validationsItems: tt.items}
+			kd := &kanaryv1alpha1.KanaryDeployment{}
+			kd.Spec.Validations.Timeout = &metav1.Duration{Duration: tt.listTimeout}
+
+			start := time.Now()
+			got, errs := s.runValidations(nil, reqLogger, kd, nil, nil)
+			if elapsed := time.Since(start); elapsed > tt.maxDuration {
+				t.Errorf("strategy.runValidations() took %s, want less than %s", elapsed, tt.maxDuration)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("strategy.runValidations() = %v, want %v", got, tt.want)
+			}
+			for i, err := range errs {
+				if (err != nil) != tt.wantErrs[i] {
+					t.Errorf("strategy.runValidations() error[%d] = %v, wantErr %v", i, err, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
@@ -5,7 +5,7 @@ import (
 
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/autoscaling/v2beta1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,7 +27,7 @@ func NewHPA(s *kanaryv1alpha1.HorizontalPodAutoscalerSpec) Interface {
 type hpaImpl struct {
 }
 
-func (h *hpaImpl) Scale(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1beta1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+func (h *hpaImpl) Scale(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
 	status := &kd.Status
 	// don't update the canary deployment replicas if the KanaryDeployment has failed
 	if utils.IsKanaryDeploymentFailed(status) {
@@ -53,7 +53,7 @@ func (h *hpaImpl) Scale(kclient client.Client, reqLogger logr.Logger, kd *kanary
 				MaxReplicas: kd.Spec.Scale.HPA.MaxReplicas,
 				Metrics:     kd.Spec.Scale.HPA.Metrics,
 				ScaleTargetRef: v2beta1.CrossVersionObjectReference{
-					APIVersion: appsv1beta1.SchemeGroupVersion.String(),
+					APIVersion: appsv1.SchemeGroupVersion.String(),
 					Kind:       "Deployment",
 					Name:       utils.GetCanaryDeploymentName(kd),
 				},
@@ -72,7 +72,7 @@ func (h *hpaImpl) Scale(kclient client.Client, reqLogger logr.Logger, kd *kanary
 	return status, reconcile.Result{Requeue: requeue}, nil
 }
 
-func (h *hpaImpl) Clear(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1beta1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+func (h *hpaImpl) Clear(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
 	status := &kd.Status
 
 	// check if the HPA is defined.
@@ -5,7 +5,7 @@ import (
 
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -30,10 +30,22 @@ type staticImpl struct {
 	replicas *int32
 }
 
-func (s *staticImpl) Scale(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1beta1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+func (s *staticImpl) Scale(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
 	status := &kd.Status
-	// don't update the canary deployment replicas if the KanaryDeployment has failed
-	if utils.IsKanaryDeploymentFailed(status) {
+	// don't update the canary deployment replicas if the KanaryDeployment has failed, or during the progressive promotion
+	if utils.IsKanaryDeploymentFailed(status) || utils.IsKanaryDeploymentPromoting(status) {
+		return status, reconcile.Result{}, nil
+	}
+	// in blue/green mode, the canary deployment is scaled down once the service targets the updated Deployment pods again
+	if status.BlueGreen != nil && utils.IsKanaryDeploymentDeploymentUpdated(status) && !utils.IsKanaryDeploymentServiceSwitched(status) {
+		if canaryDep.Spec.Replicas != nil && *canaryDep.Spec.Replicas == 0 {
+			return status, reconcile.Result{}, nil
+		}
+		result, err := updateDeploymentReplicas(kclient, reqLogger, canaryDep, 0)
+		return status, result, err
+	}
+	// after the progressive promotion, the canary deployment keeps serving the promoted replicas until the Deployment is rolled out
+	if status.Promotion != nil && utils.IsKanaryDeploymentDeploymentUpdated(status) && (status.Rollout == nil || status.Rollout.Status != kanaryv1alpha1.CompleteRolloutStatus) {
 		return status, reconcile.Result{}, nil
 	}
 
@@ -57,12 +69,12 @@ func (s *staticImpl) Scale(kclient client.Client, reqLogger logr.Logger, kd *kan
 	return status, reconcile.Result{}, nil
 }
 
-func (s *staticImpl) Clear(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1beta1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+func (s *staticImpl) Clear(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
 	status := &kd.Status
 	return status, reconcile.Result{}, nil
 }
 
-func updateDeploymentReplicas(kclient client.Client, reqLogger logr.Logger, dep *appsv1beta1.Deployment, replicas int32) (reconcile.Result, error) {
+func updateDeploymentReplicas(kclient client.Client, reqLogger logr.Logger, dep *appsv1.Deployment, replicas int32) (reconcile.Result, error) {
 	updateDep := dep.DeepCopy()
 	updateDep.Spec.Replicas = &replicas
 	err := kclient.Update(context.TODO(), updateDep)
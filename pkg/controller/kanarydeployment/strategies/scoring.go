@@ -0,0 +1,92 @@
+package strategies
+
+import (
+	"fmt"
+	"strings"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+const defaultValidationWeight = int32(1)
+
+// computeScore aggregates the validation results in a score from 0 to 100, weighted by the validation items weight
+func computeScore(scoring *kanaryv1alpha1.KanaryDeploymentSpecValidationScoring, items []kanaryv1alpha1.KanaryDeploymentSpecValidation, results []*validation.Result) *kanaryv1alpha1.KanaryDeploymentStatusScore {
+	score := &kanaryv1alpha1.KanaryDeploymentStatusScore{}
+
+	var totalWeight, succeededWeight int32
+	for i := range results {
+		totalWeight += getValidationWeight(&items[i])
+	}
+
+	criticalFailed := false
+	for i, result := range results {
+		item := kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+			Validation: utils.GetValidationItemName(&items[i]),
+			Weight:     getValidationWeight(&items[i]),
+			Critical:   items[i].Critical,
+			Failed:     result.IsFailed,
+		}
+		if result.IsFailed {
+			item.Comment = result.Comment
+			if item.Comment == "" {
+				item.Comment = unknownFailureReason
+			}
+			criticalFailed = criticalFailed || item.Critical
+		} else {
+			succeededWeight += item.Weight
+			if totalWeight > 0 {
+				item.Contribution = item.Weight * 100 / totalWeight
+			}
+		}
+		score.Items = append(score.Items, item)
+	}
+
+	score.Score = 100
+	if totalWeight > 0 {
+		score.Score = succeededWeight * 100 / totalWeight
+	}
+
+	switch {
+	case criticalFailed || score.Score < *scoring.MarginalThreshold:
+		score.Outcome = kanaryv1alpha1.FailKanaryDeploymentScoreOutcome
+	case score.Score < *scoring.PassThreshold:
+		score.Outcome = kanaryv1alpha1.MarginalKanaryDeploymentScoreOutcome
+	default:
+		score.Outcome = kanaryv1alpha1.PassKanaryDeploymentScoreOutcome
+	}
+	return score
+}
+
+// scoreFailMessages returns the failure messages of a score, or an empty string if the kanary should not fail.
+// A marginal score fails the kanary only at the end of the validation period.
+func scoreFailMessages(scoring *kanaryv1alpha1.KanaryDeploymentSpecValidationScoring, score *kanaryv1alpha1.KanaryDeploymentStatusScore, validationDeadlineDone bool) string {
+	var criticals, comments []string
+	for _, item := range score.Items {
+		if !item.Failed {
+			continue
+		}
+		comments = append(comments, item.Comment)
+		if item.Critical {
+			criticals = append(criticals, item.Comment)
+		}
+	}
+
+	switch {
+	case len(criticals) > 0:
+		return fmt.Sprintf("critical validation failed: %s", strings.Join(criticals, ","))
+	case score.Outcome == kanaryv1alpha1.FailKanaryDeploymentScoreOutcome:
+		return fmt.Sprintf("score %d below the marginal threshold %d: %s", score.Score, *scoring.MarginalThreshold, strings.Join(comments, ","))
+	case score.Outcome == kanaryv1alpha1.MarginalKanaryDeploymentScoreOutcome && validationDeadlineDone:
+		return fmt.Sprintf("score %d below the pass threshold %d: %s", score.Score, *scoring.PassThreshold, strings.Join(comments, ","))
+	}
+	return ""
+}
+
+func getValidationWeight(v *kanaryv1alpha1.KanaryDeploymentSpecValidation) int32 {
+	if v.Weight == nil {
+		return defaultValidationWeight
+	}
+	return *v.Weight
+}
@@ -0,0 +1,169 @@
+package strategies
+
+import (
+	"reflect"
+	"testing"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+func Test_computeScore(t *testing.T) {
+	scoring := &kanaryv1alpha1.KanaryDeploymentSpecValidationScoring{
+		PassThreshold:     kanaryv1alpha1.NewInt32(95),
+		MarginalThreshold: kanaryv1alpha1.NewInt32(50),
+	}
+	promQL := kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}, Weight: kanaryv1alpha1.NewInt32(3)}
+	podHealth := kanaryv1alpha1.KanaryDeploymentSpecValidation{PodHealth: &kanaryv1alpha1.KanaryDeploymentSpecValidationPodHealth{}}
+	criticalLogs := kanaryv1alpha1.KanaryDeploymentSpecValidation{Logs: &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{}, Critical: true}
+
+	type args struct {
+		items   []kanaryv1alpha1.KanaryDeploymentSpecValidation
+		results []*validation.Result
+	}
+	tests := []struct {
+		name string
+		args args
+		want *kanaryv1alpha1.KanaryDeploymentStatusScore
+	}{
+		{
+			name: "all validations succeeded",
+			args: args{
+				items:   []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL, podHealth},
+				results: []*validation.Result{{}, {}},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatusScore{
+				Score:   100,
+				Outcome: kanaryv1alpha1.PassKanaryDeploymentScoreOutcome,
+				Items: []kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+					{Validation: "promQL", Weight: 3, Contribution: 75},
+					{Validation: "podHealth", Weight: 1, Contribution: 25},
+				},
+			},
+		},
+		{
+			name: "light validation failed: marginal",
+			args: args{
+				items:   []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL, podHealth},
+				results: []*validation.Result{{}, {IsFailed: true, Comment: "restarts"}},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatusScore{
+				Score:   75,
+				Outcome: kanaryv1alpha1.MarginalKanaryDeploymentScoreOutcome,
+				Items: []kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+					{Validation: "promQL", Weight: 3, Contribution: 75},
+					{Validation: "podHealth", Weight: 1, Failed: true, Comment: "restarts"},
+				},
+			},
+		},
+		{
+			name: "heavy validation failed: fail",
+			args: args{
+				items:   []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL, podHealth},
+				results: []*validation.Result{{IsFailed: true}, {}},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatusScore{
+				Score:   25,
+				Outcome: kanaryv1alpha1.FailKanaryDeploymentScoreOutcome,
+				Items: []kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+					{Validation: "promQL", Weight: 3, Failed: true, Comment: unknownFailureReason},
+					{Validation: "podHealth", Weight: 1, Contribution: 25},
+				},
+			},
+		},
+		{
+			name: "critical validation failed: fail",
+			args: args{
+				items:   []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL, podHealth, criticalLogs},
+				results: []*validation.Result{{}, {}, {IsFailed: true, Comment: "errors"}},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatusScore{
+				Score:   80,
+				Outcome: kanaryv1alpha1.FailKanaryDeploymentScoreOutcome,
+				Items: []kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+					{Validation: "promQL", Weight: 3, Contribution: 60},
+					{Validation: "podHealth", Weight: 1, Contribution: 20},
+					{Validation: "logs", Weight: 1, Critical: true, Failed: true, Comment: "errors"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeScore(scoring, tt.args.items, tt.args.results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("computeScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scoreFailMessages(t *testing.T) {
+	scoring := &kanaryv1alpha1.KanaryDeploymentSpecValidationScoring{
+		PassThreshold:     kanaryv1alpha1.NewInt32(95),
+		MarginalThreshold: kanaryv1alpha1.NewInt32(50),
+	}
+	marginal := &kanaryv1alpha1.KanaryDeploymentStatusScore{
+		Score:   75,
+		Outcome: kanaryv1alpha1.MarginalKanaryDeploymentScoreOutcome,
+		Items: []kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+			{Validation: "promQL", Weight: 3, Contribution: 75},
+			{Validation: "podHealth", Weight: 1, Failed: true, Comment: "restarts"},
+		},
+	}
+
+	tests := []struct {
+		name                   string
+		score                  *kanaryv1alpha1.KanaryDeploymentStatusScore
+		validationDeadlineDone bool
+		want                   string
+	}{
+		{
+			name: "pass",
+			score: &kanaryv1alpha1.KanaryDeploymentStatusScore{
+				Score:   100,
+				Outcome: kanaryv1alpha1.PassKanaryDeploymentScoreOutcome,
+			},
+			want: "",
+		},
+		{
+			name:  "marginal during the validation period",
+			score: marginal,
+			want:  "",
+		},
+		{
+			name:                   "marginal at the end of the validation period",
+			score:                  marginal,
+			validationDeadlineDone: true,
+			want:                   "score 75 below the pass threshold 95: restarts",
+		},
+		{
+			name: "below the marginal threshold",
+			score: &kanaryv1alpha1.KanaryDeploymentStatusScore{
+				Score:   25,
+				Outcome: kanaryv1alpha1.FailKanaryDeploymentScoreOutcome,
+				Items: []kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+					{Validation: "promQL", Weight: 3, Failed: true, Comment: "latency"},
+				},
+			},
+			want: "score 25 below the marginal threshold 50: latency",
+		},
+		{
+			name: "critical validation failed",
+			score: &kanaryv1alpha1.KanaryDeploymentStatusScore{
+				Score:   80,
+				Outcome: kanaryv1alpha1.FailKanaryDeploymentScoreOutcome,
+				Items: []kanaryv1alpha1.KanaryDeploymentStatusScoreItem{
+					{Validation: "logs", Weight: 1, Critical: true, Failed: true, Comment: "errors"},
+				},
+			},
+			want: "critical validation failed: errors",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreFailMessages(scoring, tt.score, tt.validationDeadlineDone); got != tt.want {
+				t.Errorf("scoreFailMessages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// controllerRevisionHashLabelKey label set by the StatefulSet controller on its pods, with the revision of their pod template
+const controllerRevisionHashLabelKey = "controller-revision-hash"
+
+// getStatefulSetPartition returns the rolling update partition updating only the canary replicas with the highest ordinals
+func getStatefulSetPartition(sts *appsv1.StatefulSet, canaryReplicas int32) int32 {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	partition := replicas - canaryReplicas
+	if partition < 0 {
+		partition = 0
+	}
+	return partition
+}
+
+// StartStatefulSetCanary starts the canary of a StatefulSet: its pod template is updated with the KanaryDeployment template one, with a
+// rolling update partition so only the canary replicas with the highest ordinals are updated. The previous pod template and update strategy
+// are saved in the status, to be restored on failure.
+func StartStatefulSetCanary(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, sts *appsv1.StatefulSet, currentHash string) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	partition := getStatefulSetPartition(sts, *kd.Spec.StatefulSet.CanaryReplicas)
+	updateSts := sts.DeepCopy()
+	updateSts.Spec.Template = *kd.Spec.Template.Spec.Template.DeepCopy()
+	updateSts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+	}
+	if err := kclient.Update(context.TODO(), updateSts); err != nil {
+		reqLogger.Error(err, "failed to update the StatefulSet", "Namespace", sts.Namespace, "StatefulSet", sts.Name)
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	status := kd.Status.DeepCopy()
+	status.StatefulSet = &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{
+		Partition:              partition,
+		Generation:             updateSts.Generation,
+		PreviousTemplate:       sts.Spec.Template.DeepCopy(),
+		PreviousUpdateStrategy: sts.Spec.UpdateStrategy.DeepCopy(),
+	}
+	status.CurrentHash = currentHash
+	status.ObservedRestart = kd.Spec.Restart
+	if status.Revision == 0 {
+		status.Revision = 1
+	}
+	utils.UpdateKanaryDeploymentStatusCondition(status, metav1.Now(), kanaryv1alpha1.ActivatedKanaryDeploymentConditionType, corev1.ConditionTrue, "", false)
+	reqLogger.Info("StatefulSet canary started", "StatefulSet", sts.Name, "partition", partition)
+	return status, reconcile.Result{Requeue: true}, nil
+}
+
+// IsStatefulSetCanaryObserved returns true once the StatefulSet controller has observed the canary pod template: before, the update
+// revision of the StatefulSet status is still the revision of the stable pods. With a partition, the canary pods run another revision
+// than the current one.
+func IsStatefulSetCanaryObserved(kd *kanaryv1alpha1.KanaryDeployment, sts *appsv1.StatefulSet) bool {
+	progress := kd.Status.StatefulSet
+	if progress == nil || sts.Status.ObservedGeneration < progress.Generation || sts.Status.ObservedGeneration < sts.Generation {
+		return false
+	}
+	if sts.Status.UpdateRevision == "" {
+		return false
+	}
+	return progress.Partition == 0 || sts.Status.UpdateRevision != sts.Status.CurrentRevision
+}
+
+// NewStatefulSetCanaryTarget returns the Deployment validated in place of the StatefulSet canary pods: it is named after the StatefulSet,
+// and its selector only matches the StatefulSet pods running the updated pod template.
+func NewStatefulSetCanaryTarget(kd *kanaryv1alpha1.KanaryDeployment, sts *appsv1.StatefulSet) *appsv1.Deployment {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{}}
+	if sts.Spec.Selector != nil {
+		selector = sts.Spec.Selector.DeepCopy()
+		if selector.MatchLabels == nil {
+			selector.MatchLabels = map[string]string{}
+		}
+	}
+	selector.MatchLabels[controllerRevisionHashLabelKey] = sts.Status.UpdateRevision
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sts.Name,
+			Namespace: sts.Namespace,
+			Labels:    sts.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: kd.Spec.StatefulSet.CanaryReplicas,
+			Selector: selector,
+			Template: sts.Spec.Template,
+		},
+	}
+}
+
+// completeStatefulSetCanary completes the StatefulSet canary once the validation is completed: on success the partition is lowered to zero
+// so all the pods are updated, on failure or in dry-run mode the previous pod template and update strategy are restored.
+func completeStatefulSetCanary(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	if kd.Status.StatefulSet == nil || kd.Status.StatefulSet.EndTime != nil {
+		return &kd.Status, reconcile.Result{}, nil
+	}
+	sts, err := getStatefulSet(kclient, kd)
+	if err != nil {
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	if !utils.IsKanaryDeploymentSucceeded(&kd.Status) || kd.Spec.Validations.NoUpdate {
+		if err = restoreStatefulSet(kclient, reqLogger, kd, sts); err != nil {
+			return &kd.Status, reconcile.Result{}, err
+		}
+		status := kd.Status.DeepCopy()
+		now := metav1.Now()
+		status.StatefulSet.EndTime = &now
+		reqLogger.Info("StatefulSet restored", "StatefulSet", sts.Name)
+		return status, reconcile.Result{}, nil
+	}
+
+	partition := int32(0)
+	updateSts := sts.DeepCopy()
+	updateSts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+	}
+	if err = kclient.Update(context.TODO(), updateSts); err != nil {
+		reqLogger.Error(err, "failed to update the StatefulSet partition", "Namespace", sts.Namespace, "StatefulSet", sts.Name)
+		return &kd.Status, reconcile.Result{}, err
+	}
+	status := kd.Status.DeepCopy()
+	now := metav1.Now()
+	status.StatefulSet.EndTime = &now
+	utils.UpdateKanaryDeploymentStatusCondition(status, now, kanaryv1alpha1.DeploymentUpdatedKanaryDeploymentConditionType, corev1.ConditionTrue, "StatefulSet partition lowered to zero", false)
+	reqLogger.Info("StatefulSet partition lowered to zero", "StatefulSet", sts.Name)
+	return status, reconcile.Result{}, nil
+}
+
+// RestoreStatefulSet restores the previous pod template and update strategy of the StatefulSet, if its canary is in progress
+func RestoreStatefulSet(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) error {
+	if kd.Status.StatefulSet == nil || kd.Status.StatefulSet.EndTime != nil {
+		return nil
+	}
+	sts, err := getStatefulSet(kclient, kd)
+	if apierrors.IsNotFound(err) {
+		// nothing to restore if the StatefulSet was deleted in the meantime
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return restoreStatefulSet(kclient, reqLogger, kd, sts)
+}
+
+func restoreStatefulSet(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, sts *appsv1.StatefulSet) error {
+	previous := kd.Status.StatefulSet
+	updateSts := sts.DeepCopy()
+	if previous.PreviousTemplate != nil {
+		updateSts.Spec.Template = *previous.PreviousTemplate.DeepCopy()
+	}
+	if previous.PreviousUpdateStrategy != nil {
+		updateSts.Spec.UpdateStrategy = *previous.PreviousUpdateStrategy.DeepCopy()
+	}
+	if err := kclient.Update(context.TODO(), updateSts); err != nil {
+		reqLogger.Error(err, "failed to restore the StatefulSet", "Namespace", sts.Namespace, "StatefulSet", sts.Name)
+		return err
+	}
+	return deleteStatefulSetCanaryPods(kclient, reqLogger, sts)
+}
+
+// deleteStatefulSetCanaryPods deletes the StatefulSet pods running the canary revision, so they are recreated with the restored pod
+// template: the StatefulSet controller doesn't roll back pods with the OnDelete strategy, and is blocked by pods never becoming ready
+// with the default OrderedReady pod management policy.
+func deleteStatefulSetCanaryPods(kclient client.Client, reqLogger logr.Logger, sts *appsv1.StatefulSet) error {
+	revision := sts.Status.UpdateRevision
+	if revision == "" || revision == sts.Status.CurrentRevision {
+		return nil
+	}
+	selector := labels.Set{}
+	if sts.Spec.Selector != nil {
+		selector = labels.Set(sts.Spec.Selector.MatchLabels)
+	}
+	selector = labels.Merge(selector, labels.Set{controllerRevisionHashLabelKey: revision})
+	pods := &corev1.PodList{}
+	listOptions := &client.ListOptions{
+		LabelSelector: selector.AsSelector(),
+		Namespace:     sts.Namespace,
+	}
+	if err := kclient.List(context.TODO(), listOptions, pods); err != nil {
+		reqLogger.Error(err, "failed to list the StatefulSet pods", "Namespace", sts.Namespace, "StatefulSet", sts.Name)
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Labels[controllerRevisionHashLabelKey] != revision {
+			continue
+		}
+		if err := kclient.Delete(context.TODO(), pod); err != nil && !apierrors.IsNotFound(err) {
+			reqLogger.Error(err, "failed to delete the StatefulSet canary pod", "Namespace", pod.Namespace, "Pod", pod.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+func getStatefulSet(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment) (*appsv1.StatefulSet, error) {
+	sts := &appsv1.StatefulSet{}
+	err := kclient.Get(context.TODO(), client.ObjectKey{Name: kd.Spec.StatefulSet.Name, Namespace: kd.Namespace}, sts)
+	return sts, err
+}
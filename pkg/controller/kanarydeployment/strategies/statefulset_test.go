@@ -0,0 +1,277 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+func newStatefulSetPodTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "foo", Image: image}}},
+	}
+}
+
+func newStatefulSetPod(name, revision string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"app": "foo", controllerRevisionHashLabelKey: revision},
+		},
+	}
+}
+
+func newStatefulSetKanaryDeployment(status kanaryv1alpha1.KanaryDeploymentStatus, noUpdate bool) *kanaryv1alpha1.KanaryDeployment {
+	return &kanaryv1alpha1.KanaryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+			Template: kanaryv1alpha1.DeploymentTemplate{
+				Spec: appsv1.DeploymentSpec{Template: newStatefulSetPodTemplate("foo:2")},
+			},
+			StatefulSet: &kanaryv1alpha1.KanaryDeploymentSpecStatefulSet{Name: "foo", CanaryReplicas: kanaryv1alpha1.NewInt32(2)},
+			Validations: kanaryv1alpha1.KanaryDeploymentSpecValidationList{NoUpdate: noUpdate},
+		},
+		Status: status,
+	}
+}
+
+func Test_StartStatefulSetCanary(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_StartStatefulSetCanary")
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       kanaryv1alpha1.NewInt32(5),
+			Template:       newStatefulSetPodTemplate("foo:1"),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+	}
+	kclient := fake.NewFakeClient(sts)
+	kd := newStatefulSetKanaryDeployment(kanaryv1alpha1.KanaryDeploymentStatus{}, false)
+
+	status, _, err := StartStatefulSetCanary(kclient, reqLogger, kd, sts, "hash")
+	if err != nil {
+		t.Fatalf("StartStatefulSetCanary() unexpected error: %v", err)
+	}
+	if status.StatefulSet == nil || status.StatefulSet.Partition != 3 {
+		t.Fatalf("StartStatefulSetCanary() statefulSet = %v, want partition 3", status.StatefulSet)
+	}
+	if status.StatefulSet.PreviousTemplate.Spec.Containers[0].Image != "foo:1" || status.StatefulSet.PreviousUpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType {
+		t.Errorf("StartStatefulSetCanary() previous template and update strategy not saved: %v", status.StatefulSet)
+	}
+	if status.CurrentHash != "hash" || status.Revision != 1 {
+		t.Errorf("StartStatefulSetCanary() run not started, hash %q revision %d", status.CurrentHash, status.Revision)
+	}
+
+	current := &appsv1.StatefulSet{}
+	if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+		t.Fatalf("unable to get the statefulset: %v", err)
+	}
+	if current.Spec.Template.Spec.Containers[0].Image != "foo:2" {
+		t.Errorf("statefulset image = %s, want foo:2", current.Spec.Template.Spec.Containers[0].Image)
+	}
+	if current.Spec.UpdateStrategy.RollingUpdate == nil || *current.Spec.UpdateStrategy.RollingUpdate.Partition != 3 {
+		t.Errorf("statefulset update strategy = %v, want a rolling update with the partition 3", current.Spec.UpdateStrategy)
+	}
+}
+
+func Test_completeStatefulSetCanary(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_completeStatefulSetCanary")
+
+	partition := int32(3)
+	canaryStrategy := appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+	}
+	previousTemplate := newStatefulSetPodTemplate("foo:1")
+	previousStrategy := appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}
+
+	tests := []struct {
+		name          string
+		condition     kanaryv1alpha1.KanaryDeploymentConditionType
+		noUpdate      bool
+		wantImage     string
+		wantPartition *int32
+		wantUpdated   bool
+		wantPods      []string
+	}{
+		{
+			name:          "succeeded",
+			condition:     kanaryv1alpha1.SucceededKanaryDeploymentConditionType,
+			wantImage:     "foo:2",
+			wantPartition: kanaryv1alpha1.NewInt32(0),
+			wantUpdated:   true,
+			wantPods:      []string{"foo-0", "foo-3", "foo-4"},
+		},
+		{
+			name:      "failed",
+			condition: kanaryv1alpha1.FailedKanaryDeploymentConditionType,
+			wantImage: "foo:1",
+			wantPods:  []string{"foo-0"},
+		},
+		{
+			name:      "succeeded in dry-run mode",
+			condition: kanaryv1alpha1.SucceededKanaryDeploymentConditionType,
+			noUpdate:  true,
+			wantImage: "foo:1",
+			wantPods:  []string{"foo-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas:       kanaryv1alpha1.NewInt32(5),
+					Template:       newStatefulSetPodTemplate("foo:2"),
+					UpdateStrategy: canaryStrategy,
+				},
+				Status: appsv1.StatefulSetStatus{CurrentRevision: "foo-1", UpdateRevision: "foo-2"},
+			}
+			kclient := fake.NewFakeClient(sts,
+				newStatefulSetPod("foo-0", "foo-1"),
+				newStatefulSetPod("foo-3", "foo-2"),
+				newStatefulSetPod("foo-4", "foo-2"),
+			)
+			kd := newStatefulSetKanaryDeployment(kanaryv1alpha1.KanaryDeploymentStatus{
+				Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{{Type: tt.condition, Status: corev1.ConditionTrue}},
+				StatefulSet: &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{
+					Partition:              partition,
+					PreviousTemplate:       &previousTemplate,
+					PreviousUpdateStrategy: &previousStrategy,
+				},
+			}, tt.noUpdate)
+
+			status, _, err := completeStatefulSetCanary(kclient, reqLogger, kd)
+			if err != nil {
+				t.Fatalf("completeStatefulSetCanary() unexpected error: %v", err)
+			}
+			if status.StatefulSet.EndTime == nil {
+				t.Errorf("completeStatefulSetCanary() end time not set")
+			}
+			if gotUpdated := utils.IsKanaryDeploymentDeploymentUpdated(status); gotUpdated != tt.wantUpdated {
+				t.Errorf("completeStatefulSetCanary() updated = %v, want %v", gotUpdated, tt.wantUpdated)
+			}
+
+			current := &appsv1.StatefulSet{}
+			if err = kclient.Get(context.TODO(), types.NamespacedName{Name: "foo", Namespace: "default"}, current); err != nil {
+				t.Fatalf("unable to get the statefulset: %v", err)
+			}
+			if current.Spec.Template.Spec.Containers[0].Image != tt.wantImage {
+				t.Errorf("statefulset image = %s, want %s", current.Spec.Template.Spec.Containers[0].Image, tt.wantImage)
+			}
+			var gotPartition *int32
+			if current.Spec.UpdateStrategy.RollingUpdate != nil {
+				gotPartition = current.Spec.UpdateStrategy.RollingUpdate.Partition
+			}
+			if (gotPartition == nil) != (tt.wantPartition == nil) || (gotPartition != nil && *gotPartition != *tt.wantPartition) {
+				t.Errorf("statefulset partition = %v, want %v", gotPartition, tt.wantPartition)
+			}
+			pods := &corev1.PodList{}
+			if err = kclient.List(context.TODO(), &client.ListOptions{Namespace: "default"}, pods); err != nil {
+				t.Fatalf("unable to list the pods: %v", err)
+			}
+			var gotPods []string
+			for _, pod := range pods.Items {
+				gotPods = append(gotPods, pod.Name)
+			}
+			sort.Strings(gotPods)
+			if !reflect.DeepEqual(gotPods, tt.wantPods) {
+				t.Errorf("statefulset pods = %v, want %v", gotPods, tt.wantPods)
+			}
+		})
+	}
+}
+
+func Test_NewStatefulSetCanaryTarget(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+		},
+		Status: appsv1.StatefulSetStatus{UpdateRevision: "foo-7d8f9c6b5"},
+	}
+	kd := newStatefulSetKanaryDeployment(kanaryv1alpha1.KanaryDeploymentStatus{}, false)
+
+	got := NewStatefulSetCanaryTarget(kd, sts)
+	if got.Name != "foo" || got.Spec.Selector.MatchLabels["app"] != "foo" || got.Spec.Selector.MatchLabels[controllerRevisionHashLabelKey] != "foo-7d8f9c6b5" {
+		t.Errorf("NewStatefulSetCanaryTarget() = %s %v, want the StatefulSet selector restricted to the update revision", got.Name, got.Spec.Selector.MatchLabels)
+	}
+	if _, ok := sts.Spec.Selector.MatchLabels[controllerRevisionHashLabelKey]; ok {
+		t.Errorf("NewStatefulSetCanaryTarget() modified the StatefulSet selector")
+	}
+}
+
+func Test_IsStatefulSetCanaryObserved(t *testing.T) {
+	tests := []struct {
+		name       string
+		progress   *kanaryv1alpha1.KanaryDeploymentStatusStatefulSet
+		generation int64
+		status     appsv1.StatefulSetStatus
+		want       bool
+	}{
+		{
+			name:       "canary not started",
+			generation: 2,
+			status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, CurrentRevision: "foo-1", UpdateRevision: "foo-2"},
+		},
+		{
+			name:       "canary update not observed",
+			progress:   &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{Partition: 3, Generation: 2},
+			generation: 2,
+			status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, CurrentRevision: "foo-1", UpdateRevision: "foo-1"},
+		},
+		{
+			name:       "stale StatefulSet",
+			progress:   &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{Partition: 3, Generation: 2},
+			generation: 1,
+			status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, CurrentRevision: "foo-1", UpdateRevision: "foo-1"},
+		},
+		{
+			name:       "update revision not computed",
+			progress:   &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{Partition: 3, Generation: 2},
+			generation: 2,
+			status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, CurrentRevision: "foo-1", UpdateRevision: "foo-1"},
+		},
+		{
+			name:       "canary observed",
+			progress:   &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{Partition: 3, Generation: 2},
+			generation: 2,
+			status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, CurrentRevision: "foo-1", UpdateRevision: "foo-2"},
+			want:       true,
+		},
+		{
+			name:       "all the pods updated without partition",
+			progress:   &kanaryv1alpha1.KanaryDeploymentStatusStatefulSet{Partition: 0, Generation: 2},
+			generation: 2,
+			status:     appsv1.StatefulSetStatus{ObservedGeneration: 2, CurrentRevision: "foo-2", UpdateRevision: "foo-2"},
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Generation: tt.generation}, Status: tt.status}
+			kd := newStatefulSetKanaryDeployment(kanaryv1alpha1.KanaryDeploymentStatus{StatefulSet: tt.progress}, false)
+			if got := IsStatefulSetCanaryObserved(kd, sts); got != tt.want {
+				t.Errorf("IsStatefulSetCanaryObserved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
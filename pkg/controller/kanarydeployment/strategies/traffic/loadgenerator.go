@@ -0,0 +1,187 @@
+package traffic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/config"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+const (
+	loadGeneratorContainerName = "loadgenerator"
+	loadGeneratorMetricsPort   = 8080
+)
+
+// NewLoadGenerator returns new traffic.LoadGenerator instance
+func NewLoadGenerator(s *kanaryv1alpha1.KanaryDeploymentSpecTraffic) Interface {
+	return &loadGeneratorImpl{
+		conf:   s.LoadGenerator,
+		scheme: utils.PrepareSchemeForOwnerRef(),
+	}
+}
+
+type loadGeneratorImpl struct {
+	conf   *kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator
+	scheme *runtime.Scheme
+}
+
+func (l *loadGeneratorImpl) Traffic(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	// the load generator is only needed during the validation
+	if utils.IsKanaryDeploymentValidationCompleted(&kd.Status) {
+		return l.Cleanup(kclient, reqLogger, kd, canaryDep)
+	}
+
+	service := &corev1.Service{}
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: utils.GetCanaryServiceName(kd), Namespace: kd.Namespace}, service)
+	if err != nil && errors.IsNotFound(err) {
+		// wait for the kanary service creation
+		return &kd.Status, reconcile.Result{RequeueAfter: time.Second}, nil
+	} else if err != nil {
+		return &kd.Status, reconcile.Result{}, fmt.Errorf("unable to get the kanary service: %v", err)
+	}
+
+	newDep, err := l.newLoadGeneratorDeployment(kd, service)
+	if err != nil {
+		return &kd.Status, reconcile.Result{}, err
+	}
+
+	currentDep := &appsv1.Deployment{}
+	err = kclient.Get(context.TODO(), types.NamespacedName{Name: newDep.Name, Namespace: newDep.Namespace}, currentDep)
+	if err != nil && errors.IsNotFound(err) {
+		reqLogger.Info("Creating the load generator Deployment", "Deployment", newDep.Name)
+		if err = kclient.Create(context.TODO(), newDep); err != nil {
+			return &kd.Status, reconcile.Result{}, fmt.Errorf("unable to create the load generator deployment: %v", err)
+		}
+		return &kd.Status, reconcile.Result{Requeue: true}, nil
+	} else if err != nil {
+		return &kd.Status, reconcile.Result{}, fmt.Errorf("unable to get the load generator deployment: %v", err)
+	}
+
+	if !isSameLoadGeneratorContainer(currentDep, newDep) {
+		updatedDep := currentDep.DeepCopy()
+		updatedDep.Spec.Template.Spec.Containers = newDep.Spec.Template.Spec.Containers
+		reqLogger.Info("Updating the load generator Deployment", "Deployment", newDep.Name)
+		if err = kclient.Update(context.TODO(), updatedDep); err != nil {
+			return &kd.Status, reconcile.Result{}, fmt.Errorf("unable to update the load generator deployment: %v", err)
+		}
+		return &kd.Status, reconcile.Result{Requeue: true}, nil
+	}
+
+	return &kd.Status, reconcile.Result{}, nil
+}
+
+func (l *loadGeneratorImpl) Cleanup(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+	dep := &appsv1.Deployment{}
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: GetLoadGeneratorDeploymentName(kd), Namespace: kd.Namespace}, dep)
+	if err != nil && errors.IsNotFound(err) {
+		return &kd.Status, reconcile.Result{}, nil
+	} else if err != nil {
+		return &kd.Status, reconcile.Result{}, fmt.Errorf("unable to get the load generator deployment: %v", err)
+	}
+
+	reqLogger.Info("Deleting the load generator Deployment", "Deployment", dep.Name)
+	if err = kclient.Delete(context.TODO(), dep); err != nil && !errors.IsNotFound(err) {
+		return &kd.Status, reconcile.Result{}, fmt.Errorf("unable to delete the load generator deployment: %v", err)
+	}
+	return &kd.Status, reconcile.Result{Requeue: true}, nil
+}
+
+// GetLoadGeneratorDeploymentName returns the load generator Deployment name
+func GetLoadGeneratorDeploymentName(kd *kanaryv1alpha1.KanaryDeployment) string {
+	return fmt.Sprintf("%s-kanary-loadgenerator", kd.Name)
+}
+
+// newLoadGeneratorDeployment returns the load generator Deployment targeting the kanary service
+func (l *loadGeneratorImpl) newLoadGeneratorDeployment(kd *kanaryv1alpha1.KanaryDeployment, service *corev1.Service) (*appsv1.Deployment, error) {
+	spec, err := json.Marshal(l.conf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the load generator configuration: %v", err)
+	}
+	target := fmt.Sprintf("http://%s.%s.svc", service.Name, service.Namespace)
+	if len(service.Spec.Ports) > 0 {
+		target = fmt.Sprintf("%s:%d", target, service.Spec.Ports[0].Port)
+	}
+
+	labels := map[string]string{
+		kanaryv1alpha1.KanaryDeploymentLoadGeneratorLabelKey: kd.Name,
+	}
+	replicas := int32(1)
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetLoadGeneratorDeploymentName(kd),
+			Namespace: kd.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   fmt.Sprintf("%d", loadGeneratorMetricsPort),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  loadGeneratorContainerName,
+							Image: l.conf.Image,
+							Args:  []string{fmt.Sprintf("--metrics-addr=:%d", loadGeneratorMetricsPort)},
+							Env: []corev1.EnvVar{
+								{Name: config.KanaryLoadGeneratorTargetEnvVar, Value: target},
+								{Name: config.KanaryLoadGeneratorConfigEnvVar, Value: string(spec)},
+								{Name: config.KanaryLoadGeneratorNameEnvVar, Value: kd.Name},
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: "metrics", ContainerPort: loadGeneratorMetricsPort},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Set KanaryDeployment instance as the owner and controller
+	if err = controllerutil.SetControllerReference(kd, dep, l.scheme); err != nil {
+		return nil, err
+	}
+	return dep, nil
+}
+
+func isSameLoadGeneratorContainer(current, new *appsv1.Deployment) bool {
+	if len(current.Spec.Template.Spec.Containers) != 1 {
+		return false
+	}
+	currentContainer := &current.Spec.Template.Spec.Containers[0]
+	newContainer := &new.Spec.Template.Spec.Containers[0]
+	if currentContainer.Image != newContainer.Image || len(currentContainer.Env) != len(newContainer.Env) {
+		return false
+	}
+	for i := range newContainer.Env {
+		if currentContainer.Env[i] != newContainer.Env[i] {
+			return false
+		}
+	}
+	return true
+}
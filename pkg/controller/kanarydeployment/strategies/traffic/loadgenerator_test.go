@@ -0,0 +1,160 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	"github.com/amadeusitgroup/kanary/pkg/config"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func Test_loadGeneratorImpl_Traffic(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("Test_loadGeneratorImpl_Traffic")
+
+	var (
+		name            = "foo"
+		serviceName     = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		loadGenName     = name + "-kanary-loadgenerator"
+
+		loadGeneratorTraffic = &kanaryv1alpha1.KanaryDeploymentSpecTraffic{
+			Source: kanaryv1alpha1.KanaryServiceKanaryDeploymentSpecTrafficSource,
+			LoadGenerator: &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{
+				RPS:      kanaryv1alpha1.NewInt32(10),
+				Image:    "kanaryoperator/loadgenerator:latest",
+				Requests: []kanaryv1alpha1.LoadGeneratorRequest{{Method: "GET", Path: "/"}},
+			},
+		}
+		kanaryService = utilstest.NewService(serviceName+"-kanary-"+name, namespace, nil, &utilstest.NewServiceOptions{Ports: []corev1.ServicePort{{Port: 8080}}})
+
+		statusSucceeded = &kanaryv1alpha1.KanaryDeploymentStatus{
+			Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{
+				{
+					Type:   kanaryv1alpha1.SucceededKanaryDeploymentConditionType,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		}
+	)
+
+	getLoadGenerator := func(kclient client.Client) (*appsv1.Deployment, error) {
+		dep := &appsv1.Deployment{}
+		err := kclient.Get(context.TODO(), types.NamespacedName{Name: loadGenName, Namespace: namespace}, dep)
+		return dep, err
+	}
+	newLoadGenerator := func() *appsv1.Deployment {
+		kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Traffic: loadGeneratorTraffic})
+		l := &loadGeneratorImpl{conf: loadGeneratorTraffic.LoadGenerator, scheme: utils.PrepareSchemeForOwnerRef()}
+		dep, _ := l.newLoadGeneratorDeployment(kd, kanaryService)
+		return dep
+	}
+
+	type args struct {
+		kclient   client.Client
+		kd        *kanaryv1alpha1.KanaryDeployment
+		canaryDep *appsv1.Deployment
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult reconcile.Result
+		wantErr    bool
+		wantFunc   func(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment) error
+	}{
+		{
+			name: "kanary service doesn't exist yet, requeue",
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Traffic: loadGeneratorTraffic}),
+			},
+			wantResult: reconcile.Result{RequeueAfter: time.Second},
+			wantErr:    false,
+		},
+		{
+			name: "create the load generator",
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Traffic: loadGeneratorTraffic}),
+			},
+			wantResult: reconcile.Result{Requeue: true},
+			wantErr:    false,
+			wantFunc: func(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment) error {
+				dep, err := getLoadGenerator(kclient)
+				if err != nil {
+					return err
+				}
+				env := dep.Spec.Template.Spec.Containers[0].Env
+				if env[0].Name != config.KanaryLoadGeneratorTargetEnvVar || env[0].Value != "http://foo-kanary-foo.kanary.svc:8080" {
+					return fmt.Errorf("bad target env var: %v", env[0])
+				}
+				if len(dep.OwnerReferences) != 1 || dep.OwnerReferences[0].Name != name {
+					return fmt.Errorf("bad ownerReferences: %v", dep.OwnerReferences)
+				}
+				return nil
+			},
+		},
+		{
+			name: "load generator already exists, nothing change",
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService, newLoadGenerator()}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Traffic: loadGeneratorTraffic}),
+			},
+			wantResult: reconcile.Result{},
+			wantErr:    false,
+		},
+		{
+			name: "validation completed, delete the load generator",
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService, newLoadGenerator()}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Traffic: loadGeneratorTraffic, Status: statusSucceeded}),
+			},
+			wantResult: reconcile.Result{Requeue: true},
+			wantErr:    false,
+			wantFunc: func(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment) error {
+				if _, err := getLoadGenerator(kclient); !errors.IsNotFound(err) {
+					return fmt.Errorf("load generator should be deleted, err: %v", err)
+				}
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			l := &loadGeneratorImpl{
+				conf:   tt.args.kd.Spec.Traffic.LoadGenerator,
+				scheme: utils.PrepareSchemeForOwnerRef(),
+			}
+			_, gotResult, err := l.Traffic(tt.args.kclient, reqLogger, tt.args.kd, tt.args.canaryDep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("loadGeneratorImpl.Traffic() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("loadGeneratorImpl.Traffic() gotResult = %v, want %v", gotResult, tt.wantResult)
+			}
+			if tt.wantFunc != nil {
+				if err = tt.wantFunc(tt.args.kclient, tt.args.kd); err != nil {
+					t.Errorf("wantFunc returns an error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -38,7 +38,7 @@ type kanaryServiceImpl struct {
 	scheme *runtime.Scheme
 }
 
-func (k *kanaryServiceImpl) Traffic(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1beta1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
+func (k *kanaryServiceImpl) Traffic(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (*kanaryv1alpha1.KanaryDeploymentStatus, reconcile.Result, error) {
 	// Retrieve and create service if defined
 	newStatus, needsRequeue, result, err := k.manageServices(kclient, reqLogger, kd)
 	utils.UpdateKanaryDeploymentStatusConditionsFailure(newStatus, metav1.Now(), err)
@@ -48,7 +48,7 @@ func (k *kanaryServiceImpl) Traffic(kclient client.Client, reqLogger logr.Logger
 	return newStatus, result, err
 }
 
-func (k *kanaryServiceImpl) Cleanup(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1beta1.Deployment) (status *kanaryv1alpha1.KanaryDeploymentStatus, result reconcile.Result, err error) {
+func (k *kanaryServiceImpl) Cleanup(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (status *kanaryv1alpha1.KanaryDeploymentStatus, result reconcile.Result, err error) {
 	var needsReturn bool
 	if k.conf.Source == kanaryv1alpha1.MirrorKanaryDeploymentSpecTrafficSource || k.conf.Source == kanaryv1alpha1.NoneKanaryDeploymentSpecTrafficSource {
 		needsReturn, result, err = k.clearServices(kclient, reqLogger, kd)
@@ -150,6 +150,11 @@ func (k *kanaryServiceImpl) manageServices(kclient client.Client, reqLogger logr
 					return status, true, reconcile.Result{Requeue: true}, nil
 				}
 			}
+
+			// each variant is behind its own kanary service
+			if needsReturn, result, err2 := k.manageVariantServices(kclient, reqLogger, kd, service); needsReturn {
+				return status, true, result, err2
+			}
 		}
 
 		if k.conf.Source == kanaryv1alpha1.BothKanaryDeploymentSpecTrafficSource || k.conf.Source == kanaryv1alpha1.ServiceKanaryDeploymentSpecTrafficSource {
@@ -181,6 +186,45 @@ func (k *kanaryServiceImpl) manageServices(kclient client.Client, reqLogger logr
 	return status, false, reconcile.Result{}, err
 }
 
+// manageVariantServices creates the kanary service of each variant, or updates it if needed
+func (k *kanaryServiceImpl) manageVariantServices(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, service *corev1.Service) (needsReturn bool, result reconcile.Result, err error) {
+	for _, variant := range kd.Spec.Variants {
+		variantService, err := utils.NewVariantServiceForKanaryDeployment(kd, variant.Name, service, k.scheme)
+		if err != nil {
+			reqLogger.Error(err, "failed to prepare the variant Service", "variant", variant.Name)
+			return true, reconcile.Result{}, err
+		}
+		currentService := &corev1.Service{}
+		err = kclient.Get(context.TODO(), types.NamespacedName{Name: variantService.Name, Namespace: variantService.Namespace}, currentService)
+		if err != nil && errors.IsNotFound(err) {
+			if err = kclient.Create(context.TODO(), variantService); err != nil {
+				reqLogger.Error(err, "failed to create the variant Service", "Namespace", variantService.Namespace, "Service.Name", variantService.Name)
+				return true, reconcile.Result{}, err
+			}
+			return true, reconcile.Result{Requeue: true}, nil
+		} else if err != nil {
+			reqLogger.Error(err, "failed to get the variant Service")
+			return true, reconcile.Result{}, err
+		}
+
+		compareCurrentServiceSpec := currentService.Spec.DeepCopy()
+		compareCurrentServiceSpec.ClusterIP = ""
+		compareCurrentServiceSpec.LoadBalancerIP = ""
+		if !apiequality.Semantic.DeepEqual(&variantService.Spec, compareCurrentServiceSpec) {
+			updatedService := currentService.DeepCopy()
+			updatedService.Spec = variantService.Spec
+			updatedService.Spec.ClusterIP = currentService.Spec.ClusterIP
+			updatedService.Spec.LoadBalancerIP = currentService.Spec.LoadBalancerIP
+			if err = kclient.Update(context.TODO(), updatedService); err != nil {
+				reqLogger.Error(err, "unable to update the variant Service", "Namespace", updatedService.Namespace, "Service.Name", updatedService.Name)
+				return true, reconcile.Result{}, err
+			}
+			return true, reconcile.Result{Requeue: true}, nil
+		}
+	}
+	return false, reconcile.Result{}, nil
+}
+
 func (k *kanaryServiceImpl) clearServices(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment) (needsReturn bool, result reconcile.Result, err error) {
 	services := &corev1.ServiceList{}
 
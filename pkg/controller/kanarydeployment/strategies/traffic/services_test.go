@@ -9,7 +9,7 @@ import (
 	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
 	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
 	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,7 +36,7 @@ func Test_kanaryServiceImpl_Traffic(t *testing.T) {
 	type args struct {
 		kclient   client.Client
 		kd        *kanaryv1alpha1.KanaryDeployment
-		canaryDep *appsv1beta1.Deployment
+		canaryDep *appsv1.Deployment
 	}
 	tests := []struct {
 		name       string
@@ -148,7 +148,7 @@ func Test_kanaryServiceImpl_Cleanup(t *testing.T) {
 	type args struct {
 		kclient   client.Client
 		kd        *kanaryv1alpha1.KanaryDeployment
-		canaryDep *appsv1beta1.Deployment
+		canaryDep *appsv1.Deployment
 	}
 	tests := []struct {
 		name       string
@@ -0,0 +1,191 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+const (
+	alertsAPIPath       = "/api/v2/alerts"
+	alertsClientTimeout = 5 * time.Second
+	alertNameLabel      = "alertname"
+	activeAlertState    = "active"
+	suppressedState     = "suppressed"
+)
+
+// NewAlerts returns new validation.Alerts instance
+func NewAlerts(list *kanaryv1alpha1.KanaryDeploymentSpecValidationList, s *kanaryv1alpha1.KanaryDeploymentSpecValidation) Interface {
+	return &alertsImpl{
+		dryRun: list.NoUpdate,
+		config: s.Alerts,
+		client: &http.Client{Timeout: alertsClientTimeout},
+	}
+}
+
+type alertsImpl struct {
+	dryRun bool
+	config *kanaryv1alpha1.KanaryDeploymentSpecValidationAlerts
+	client *http.Client
+}
+
+// alertmanagerAlert subset of the Alertmanager v2 API gettableAlert
+type alertmanagerAlert struct {
+	Labels map[string]string `json:"labels"`
+	Status struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// alertMatcher alert label matcher with its templated value
+type alertMatcher struct {
+	name    string
+	value   string
+	isRegex bool
+	regex   *regexp.Regexp
+}
+
+func (a *alertsImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
+	result := &Result{}
+
+	data, err := newCanaryTemplateData(ctx, kclient, reqLogger, kd, canaryDep)
+	if err != nil {
+		return result, err
+	}
+	// without canary pod, a `{{.Pods}}` matcher would match every alert without pod label
+	if data.Pods == "" {
+		result.InsufficientData = "no kanary pod found to match the alerts"
+		return result, nil
+	}
+
+	matchers, err := newAlertMatchers(a.config.Matchers, data)
+	if err != nil {
+		return result, err
+	}
+
+	alerts, err := a.getAlerts(ctx, matchers)
+	if err != nil {
+		return result, err
+	}
+
+	firing := map[string]bool{}
+	for _, alert := range alerts {
+		if !a.isFiring(&alert) || !matchAlert(matchers, &alert) {
+			continue
+		}
+		firing[alert.Labels[alertNameLabel]] = true
+	}
+
+	if len(firing) > 0 {
+		var names []string
+		for name := range firing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result.IsFailed = true
+		result.Comment = fmt.Sprintf("alerts firing: %s", strings.Join(names, ","))
+		reqLogger.Info("Alerts", "firing", names)
+	}
+
+	return result, nil
+}
+
+// getAlerts queries the Alertmanager v2 API for the alerts matching the matchers
+func (a *alertsImpl) getAlerts(ctx context.Context, matchers []alertMatcher) ([]alertmanagerAlert, error) {
+	query := url.Values{}
+	for _, m := range matchers {
+		operator := "="
+		if m.isRegex {
+			operator = "=~"
+		}
+		query.Add("filter", fmt.Sprintf("%s%s%q", m.name, operator, m.value))
+	}
+	query.Set("active", "true")
+	query.Set("silenced", fmt.Sprintf("%t", a.config.IncludeSilenced))
+	query.Set("inhibited", fmt.Sprintf("%t", a.config.IncludeSilenced))
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s?%s", a.config.AlertmanagerService, alertsAPIPath, query.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the alertmanager request: %v", err)
+	}
+	response, err := a.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("unable to query alertmanager: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("alertmanager did not respond Ok (200) but %d", response.StatusCode)
+	}
+
+	var alerts []alertmanagerAlert
+	if err = json.NewDecoder(response.Body).Decode(&alerts); err != nil {
+		return nil, fmt.Errorf("unable to decode alertmanager response: %v", err)
+	}
+	return alerts, nil
+}
+
+// isFiring returns true if the alert is active, or suppressed when the silenced alerts are included
+func (a *alertsImpl) isFiring(alert *alertmanagerAlert) bool {
+	switch alert.Status.State {
+	case activeAlertState:
+		return true
+	case suppressedState:
+		return a.config.IncludeSilenced
+	}
+	return false
+}
+
+// newAlertMatchers executes the matchers value templates
+func newAlertMatchers(specs []kanaryv1alpha1.AlertMatcher, data canaryTemplateData) ([]alertMatcher, error) {
+	var matchers []alertMatcher
+	for _, spec := range specs {
+		tmpl, err := template.New(spec.Name).Parse(spec.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse alert matcher %s value: %v", spec.Name, err)
+		}
+		buf := &bytes.Buffer{}
+		if err = tmpl.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("unable to execute alert matcher %s value: %v", spec.Name, err)
+		}
+		m := alertMatcher{name: spec.Name, value: buf.String(), isRegex: spec.IsRegex}
+		if m.isRegex {
+			// anchored, as Alertmanager does
+			if m.regex, err = regexp.Compile("^(?:" + m.value + ")$"); err != nil {
+				return nil, fmt.Errorf("unable to compile alert matcher %s regex: %v", spec.Name, err)
+			}
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// matchAlert returns true if the alert labels match all the matchers
+func matchAlert(matchers []alertMatcher, alert *alertmanagerAlert) bool {
+	for _, m := range matchers {
+		value := alert.Labels[m.name]
+		if m.isRegex {
+			if !m.regex.MatchString(value) {
+				return false
+			}
+		} else if value != m.value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,195 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+// newAlertmanagerStandIn returns a local stand-in of the Alertmanager v2 alerts API, recording the received filters
+func newAlertmanagerStandIn(t *testing.T, alerts string, status int, filters *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != alertsAPIPath {
+			t.Errorf("unexpected alertmanager path %s", r.URL.Path)
+		}
+		*filters = r.URL.Query()["filter"]
+		w.WriteHeader(status)
+		w.Write([]byte(alerts))
+	}))
+}
+
+func newAlertmanagerAlerts(alerts ...alertmanagerAlert) string {
+	b, _ := json.Marshal(alerts)
+	return string(b)
+}
+
+func newAlert(name, state string, labels map[string]string) alertmanagerAlert {
+	alert := alertmanagerAlert{Labels: map[string]string{alertNameLabel: name}}
+	for k, v := range labels {
+		alert.Labels[k] = v
+	}
+	alert.Status.State = state
+	return alert
+}
+
+func Test_alertsImpl_Validation(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("Test_alertsImpl_Validation")
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	var (
+		name            = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		canaryPod       = utilstest.NewPod(name+"-kanary", namespace, "hash", &utilstest.NewPodOptions{Labels: map[string]string{kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: name}})
+		canaryDep       = utilstest.NewDeployment(name+"-kanary-"+name, namespace, 1, nil)
+		matchers        = []kanaryv1alpha1.AlertMatcher{
+			{Name: "namespace", Value: "{{.Namespace}}"},
+			{Name: "pod", Value: "{{.Pods}}", IsRegex: true},
+		}
+	)
+
+	type fields struct {
+		noCanaryPod     bool
+		includeSilenced bool
+		alerts          string
+		status          int
+	}
+	type args struct {
+		kclient   client.Client
+		kd        *kanaryv1alpha1.KanaryDeployment
+		canaryDep *appsv1.Deployment
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		want        *Result
+		wantErr     bool
+		wantFilters []string
+	}{
+		{
+			name:        "no alert",
+			fields:      fields{alerts: newAlertmanagerAlerts(), status: http.StatusOK},
+			want:        &Result{},
+			wantFilters: []string{`namespace="kanary"`, `pod=~"foo-kanary"`},
+		},
+		{
+			name: "matching alert firing",
+			fields: fields{
+				alerts: newAlertmanagerAlerts(
+					newAlert("HighLatency", activeAlertState, map[string]string{"namespace": namespace, "pod": "foo-kanary"}),
+					newAlert("PodCrashLooping", activeAlertState, map[string]string{"namespace": namespace, "pod": "foo-kanary"}),
+				),
+				status: http.StatusOK,
+			},
+			want: &Result{
+				IsFailed: true,
+				Comment:  "alerts firing: HighLatency,PodCrashLooping",
+			},
+			wantFilters: []string{`namespace="kanary"`, `pod=~"foo-kanary"`},
+		},
+		{
+			name: "alert on another pod",
+			fields: fields{
+				alerts: newAlertmanagerAlerts(
+					newAlert("HighLatency", activeAlertState, map[string]string{"namespace": namespace, "pod": "foo-kanary-stable"}),
+				),
+				status: http.StatusOK,
+			},
+			want: &Result{},
+		},
+		{
+			name: "silenced alert ignored",
+			fields: fields{
+				alerts: newAlertmanagerAlerts(
+					newAlert("HighLatency", suppressedState, map[string]string{"namespace": namespace, "pod": "foo-kanary"}),
+				),
+				status: http.StatusOK,
+			},
+			want: &Result{},
+		},
+		{
+			name: "silenced alert included",
+			fields: fields{
+				includeSilenced: true,
+				alerts: newAlertmanagerAlerts(
+					newAlert("HighLatency", suppressedState, map[string]string{"namespace": namespace, "pod": "foo-kanary"}),
+				),
+				status: http.StatusOK,
+			},
+			want: &Result{
+				IsFailed: true,
+				Comment:  "alerts firing: HighLatency",
+			},
+		},
+		{
+			name: "no canary pod",
+			fields: fields{
+				noCanaryPod: true,
+				alerts: newAlertmanagerAlerts(
+					newAlert("HighLatency", activeAlertState, map[string]string{"namespace": namespace}),
+				),
+				status: http.StatusOK,
+			},
+			want: &Result{InsufficientData: "no kanary pod found to match the alerts"},
+		},
+		{
+			name:    "alertmanager error",
+			fields:  fields{alerts: "internal error", status: http.StatusInternalServerError},
+			want:    &Result{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			var filters []string
+			server := newAlertmanagerStandIn(t, tt.fields.alerts, tt.fields.status, &filters)
+			defer server.Close()
+
+			a := NewAlerts(&kanaryv1alpha1.KanaryDeploymentSpecValidationList{}, &kanaryv1alpha1.KanaryDeploymentSpecValidation{
+				Alerts: &kanaryv1alpha1.KanaryDeploymentSpecValidationAlerts{
+					AlertmanagerService: strings.TrimPrefix(server.URL, "http://"),
+					Matchers:            matchers,
+					IncludeSilenced:     tt.fields.includeSilenced,
+				},
+			})
+			objects := []runtime.Object{canaryPod}
+			if tt.fields.noCanaryPod {
+				objects = nil
+			}
+			kclient := fake.NewFakeClient(objects...)
+			kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil)
+
+			got, err := a.Validation(context.Background(), kclient, reqLogger, kd, nil, canaryDep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("alertsImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("alertsImpl.Validation() = %#v, want %#v", got, tt.want)
+			}
+			if tt.wantFilters != nil && !reflect.DeepEqual(filters, tt.wantFilters) {
+				t.Errorf("alertsImpl.Validation() filters = %v, want %v", filters, tt.wantFilters)
+			}
+		})
+	}
+}
@@ -1,18 +1,32 @@
 package validation
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 	"github.com/go-logr/logr"
+	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
 )
 
+// promQueryTimeout maximum duration of a Prometheus query of the SLO and early success templates
+const promQueryTimeout = 10 * time.Second
+
 //GetNextValidationCheckDuration return the shortest duration between deadline-now and MaxIntervalPeriod
 func GetNextValidationCheckDuration(kd *v1alpha1.KanaryDeployment) time.Duration {
 	deadline := GetValidationDeadLine(kd)
@@ -29,7 +43,23 @@ func GetNextValidationCheckDuration(kd *v1alpha1.KanaryDeployment) time.Duration
 
 //GetValidationDeadLine return the timestamp for the end validation period
 func GetValidationDeadLine(kd *v1alpha1.KanaryDeployment) time.Time {
-	return kd.CreationTimestamp.Time.Add(kd.Spec.Validations.InitialDelay.Duration).Add(kd.Spec.Validations.ValidationPeriod.Duration)
+	return GetRunStartTime(kd).Add(kd.Spec.Validations.InitialDelay.Duration).Add(kd.Spec.Validations.ValidationPeriod.Duration)
+}
+
+// GetRunStartTime returns the start of the validation timeline: the canary run start time, or the KanaryDeployment creation
+// for a run started before it was recorded, shifted by the paused durations.
+func GetRunStartTime(kd *v1alpha1.KanaryDeployment) time.Time {
+	start := kd.CreationTimestamp.Time
+	if kd.Status.StartTime != nil {
+		start = kd.Status.StartTime.Time
+	}
+	if kd.Status.PausedDuration != nil {
+		start = start.Add(kd.Status.PausedDuration.Duration)
+	}
+	if kd.Status.PauseTime != nil {
+		start = start.Add(time.Since(kd.Status.PauseTime.Time))
+	}
+	return start
 }
 
 // IsDeadlinePeriodDone returns true if the InitialDelay validation periode is over.
@@ -40,7 +70,7 @@ func IsDeadlinePeriodDone(kd *v1alpha1.KanaryDeployment) bool {
 // IsInitialDelayDone returns true if the InitialDelay validation periode is over.
 func IsInitialDelayDone(kd *v1alpha1.KanaryDeployment) (time.Duration, bool) {
 	now := time.Now()
-	deadline := kd.CreationTimestamp.Time.Add(kd.Spec.Validations.InitialDelay.Duration)
+	deadline := GetRunStartTime(kd).Add(kd.Spec.Validations.InitialDelay.Duration)
 
 	if now.After(deadline) {
 		return deadline.Sub(now), true
@@ -49,7 +79,7 @@ func IsInitialDelayDone(kd *v1alpha1.KanaryDeployment) (time.Duration, bool) {
 	return deadline.Sub(now), false
 }
 
-func getPods(kclient client.Client, reqLogger logr.Logger, KanaryDeploymentName, KanaryDeploymentNamespace string) ([]corev1.Pod, error) {
+func getPods(ctx context.Context, kclient client.Client, reqLogger logr.Logger, KanaryDeploymentName, KanaryDeploymentNamespace string) ([]corev1.Pod, error) {
 	pods := &corev1.PodList{}
 	selector := labels.Set{
 		kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: KanaryDeploymentName,
@@ -58,10 +88,102 @@ func getPods(kclient client.Client, reqLogger logr.Logger, KanaryDeploymentName,
 		LabelSelector: selector.AsSelector(),
 		Namespace:     KanaryDeploymentNamespace,
 	}
-	err := kclient.List(context.TODO(), listOptions, pods)
+	err := kclient.List(ctx, listOptions, pods)
 	if err != nil {
 		reqLogger.Error(err, "failed to list Pod from canary deployment")
 		return nil, fmt.Errorf("failed to list pod from canary deployment, err:%v", err)
 	}
+	// the pods of the variants canary deployments are validated separately
+	canaryPods := []corev1.Pod{}
+	for _, pod := range pods.Items {
+		if _, isVariant := pod.Labels[kanaryv1alpha1.KanaryDeploymentVariantLabelKey]; !isVariant {
+			canaryPods = append(canaryPods, pod)
+		}
+	}
+	return canaryPods, nil
+}
+
+// getTargetPods returns the pods of the validated Deployment: the canary pods, the pods of a variant canary Deployment,
+// or the main Deployment pods when the main Deployment is validated after its update (post-promotion verification)
+func getTargetPods(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, target *appsv1.Deployment) ([]corev1.Pod, error) {
+	if target == nil || target.Spec.Selector == nil || target.Name == utils.GetCanaryDeploymentName(kd) {
+		return getPods(ctx, kclient, reqLogger, kd.Name, kd.Namespace)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(target.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the label selector of the deployment %s: %v", target.Name, err)
+	}
+	pods := &corev1.PodList{}
+	listOptions := &client.ListOptions{
+		LabelSelector: selector,
+		Namespace:     kd.Namespace,
+	}
+	if err = kclient.List(ctx, listOptions, pods); err != nil {
+		reqLogger.Error(err, "failed to list Pod from deployment", "deployment", target.Name)
+		return nil, fmt.Errorf("failed to list pod from deployment %s, err:%v", target.Name, err)
+	}
 	return pods.Items, nil
 }
+
+// canaryTemplateData data available in the validation templates (alert matchers, SLO queries)
+type canaryTemplateData struct {
+	Namespace        string
+	Deployment       string
+	KanaryDeployment string
+	// Pods canary pod names, as a regular expression alternation
+	Pods string
+	// Window query window, only set for the SLO queries
+	Window string
+}
+
+func newCanaryTemplateData(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (canaryTemplateData, error) {
+	data := canaryTemplateData{
+		Namespace:        kd.Namespace,
+		KanaryDeployment: kd.Name,
+	}
+	if canaryDep != nil {
+		data.Deployment = canaryDep.Name
+	}
+	pods, err := getTargetPods(ctx, kclient, reqLogger, kd, canaryDep)
+	if err != nil {
+		return data, fmt.Errorf("unable to list pods: %v", err)
+	}
+	var podNames []string
+	for _, pod := range pods {
+		podNames = append(podNames, regexp.QuoteMeta(pod.Name))
+	}
+	data.Pods = strings.Join(podNames, "|")
+	return data, nil
+}
+
+// queryVectorSum executes the promQL query template and returns the sum of the returned samples
+func queryVectorSum(ctx context.Context, queryAPI promApi.API, name, query string, data canaryTemplateData) (float64, error) {
+	tmpl, err := template.New(name).Parse(query)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %v", name, err)
+	}
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, data); err != nil {
+		return 0, fmt.Errorf("unable to execute %s: %v", name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, promQueryTimeout)
+	defer cancel()
+	value, err := queryAPI.Query(ctx, buf.String(), time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error processing %s: %v", name, err)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return 0, fmt.Errorf("the %s did not return a result in the form of expected type 'model.Vector'", name)
+	}
+
+	var sum float64
+	for _, sample := range vector {
+		// rate() and increase() return NaN for the series without samples over the window
+		if !math.IsNaN(float64(sample.Value)) {
+			sum += float64(sample.Value)
+		}
+	}
+	return sum, nil
+}
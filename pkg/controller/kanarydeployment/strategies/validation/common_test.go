@@ -0,0 +1,52 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+func TestGetRunStartTime(t *testing.T) {
+	created := time.Now().Add(-2 * time.Hour).Round(time.Second)
+	started := metav1.NewTime(created.Add(time.Hour))
+	pausedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+
+	tests := []struct {
+		name   string
+		status kanaryv1alpha1.KanaryDeploymentStatus
+		want   time.Time
+	}{
+		{
+			name: "run started before being recorded",
+			want: created,
+		},
+		{
+			name:   "run started",
+			status: kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started},
+			want:   started.Time,
+		},
+		{
+			name:   "resumed",
+			status: kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, PausedDuration: &metav1.Duration{Duration: 5 * time.Minute}},
+			want:   started.Add(5 * time.Minute),
+		},
+		{
+			name:   "paused",
+			status: kanaryv1alpha1.KanaryDeploymentStatus{StartTime: &started, PausedDuration: &metav1.Duration{Duration: 5 * time.Minute}, PauseTime: &pausedAt},
+			want:   started.Add(15 * time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kd := &kanaryv1alpha1.KanaryDeployment{}
+			kd.CreationTimestamp = metav1.NewTime(created)
+			kd.Status = tt.status
+			if got := GetRunStartTime(kd); got.Sub(tt.want) < 0 || got.Sub(tt.want) > time.Second {
+				t.Errorf("GetRunStartTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
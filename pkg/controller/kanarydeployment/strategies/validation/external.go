@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+// NewExternal returns new validation.External instance
+func NewExternal(list *kanaryv1alpha1.KanaryDeploymentSpecValidationList, s *kanaryv1alpha1.KanaryDeploymentSpecValidation) Interface {
+	return &externalImpl{
+		deadlineStatus: s.External.StatusAfterDeadline,
+		dryRun:         list.NoUpdate,
+	}
+}
+
+type externalImpl struct {
+	deadlineStatus kanaryv1alpha1.KanaryDeploymentSpecValidationManualDeadineStatus
+	dryRun         bool
+}
+
+func (e *externalImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
+	result := &Result{}
+
+	verdict := kd.Status.ExternalVerdict
+	if verdict != nil && verdict.Verdict == kanaryv1alpha1.PassExternalVerdict {
+		result.ForceSuccessNow = true
+		return result, nil
+	}
+	if verdict != nil && verdict.Verdict == kanaryv1alpha1.FailExternalVerdict {
+		result.IsFailed = true
+		result.Comment = fmt.Sprintf("external verdict fail: %s", verdict.Reason)
+		return result, nil
+	}
+
+	// no verdict yet, or inconclusive
+	if IsDeadlinePeriodDone(kd) {
+		switch e.deadlineStatus {
+		case kanaryv1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus:
+			result.IsFailed = true
+			result.Comment = "deadline activated without external verdict, with 'invalid' status"
+			if verdict != nil {
+				result.Comment = fmt.Sprintf("deadline activated with an inconclusive external verdict (%s), with 'invalid' status", verdict.Reason)
+			}
+		case kanaryv1alpha1.ValidKanaryDeploymentSpecValidationManualDeadineStatus:
+			result.Comment = "deadline activated without external verdict, with 'valid' status"
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func Test_externalImpl_Validation(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("Test_externalImpl_Validation")
+
+	var (
+		name            = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		running         = &metav1.Time{Time: time.Now()}
+		deadlineDone    = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	)
+	newKD := func(startTime *metav1.Time, verdict kanaryv1alpha1.ExternalVerdict) *kanaryv1alpha1.KanaryDeployment {
+		status := &kanaryv1alpha1.KanaryDeploymentStatus{}
+		if verdict != "" {
+			status.ExternalVerdict = &kanaryv1alpha1.KanaryDeploymentStatusExternalVerdict{Verdict: verdict, Reason: "e2e tests"}
+		}
+		return kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{StartTime: startTime, Status: status})
+	}
+
+	tests := []struct {
+		name           string
+		deadlineStatus kanaryv1alpha1.KanaryDeploymentSpecValidationManualDeadineStatus
+		kd             *kanaryv1alpha1.KanaryDeployment
+		want           *Result
+	}{
+		{
+			name:           "no verdict yet",
+			deadlineStatus: kanaryv1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus,
+			kd:             newKD(running, ""),
+			want:           &Result{},
+		},
+		{
+			name:           "pass verdict",
+			deadlineStatus: kanaryv1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus,
+			kd:             newKD(running, kanaryv1alpha1.PassExternalVerdict),
+			want:           &Result{ForceSuccessNow: true},
+		},
+		{
+			name:           "fail verdict",
+			deadlineStatus: kanaryv1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus,
+			kd:             newKD(running, kanaryv1alpha1.FailExternalVerdict),
+			want:           &Result{IsFailed: true, Comment: "external verdict fail: e2e tests"},
+		},
+		{
+			name:           "inconclusive verdict",
+			deadlineStatus: kanaryv1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus,
+			kd:             newKD(running, kanaryv1alpha1.InconclusiveExternalVerdict),
+			want:           &Result{},
+		},
+		{
+			name:           "deadline without verdict, invalid",
+			deadlineStatus: kanaryv1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus,
+			kd:             newKD(deadlineDone, ""),
+			want:           &Result{IsFailed: true, Comment: "deadline activated without external verdict, with 'invalid' status"},
+		},
+		{
+			name:           "deadline with inconclusive verdict, invalid",
+			deadlineStatus: kanaryv1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus,
+			kd:             newKD(deadlineDone, kanaryv1alpha1.InconclusiveExternalVerdict),
+			want:           &Result{IsFailed: true, Comment: "deadline activated with an inconclusive external verdict (e2e tests), with 'invalid' status"},
+		},
+		{
+			name:           "deadline without verdict, valid",
+			deadlineStatus: kanaryv1alpha1.ValidKanaryDeploymentSpecValidationManualDeadineStatus,
+			kd:             newKD(deadlineDone, ""),
+			want:           &Result{Comment: "deadline activated without external verdict, with 'valid' status"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			e := &externalImpl{deadlineStatus: tt.deadlineStatus}
+			got, err := e.Validation(context.Background(), fake.NewFakeClient(), reqLogger, tt.kd, nil, nil)
+			if err != nil {
+				t.Errorf("externalImpl.Validation() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("externalImpl.Validation() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package validation
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -12,5 +14,5 @@ import (
 
 // Interface validation strategy interface
 type Interface interface {
-	Validation(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1beta1.Deployment) (*Result, error)
+	Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error)
 }
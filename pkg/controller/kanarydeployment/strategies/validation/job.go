@@ -0,0 +1,147 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+const (
+	// KanaryServiceNameEnvVar env var injected in the Job containers with the kanary service name
+	KanaryServiceNameEnvVar = "KANARY_SERVICE_NAME"
+	// KanaryServiceHostEnvVar env var injected in the Job containers with the kanary service host
+	KanaryServiceHostEnvVar = "KANARY_SERVICE_HOST"
+	// KanaryServicePortEnvVar env var injected in the Job containers with the kanary service port
+	KanaryServicePortEnvVar = "KANARY_SERVICE_PORT"
+	// KanaryServiceURLEnvVar env var injected in the Job containers with the kanary service URL
+	KanaryServiceURLEnvVar = "KANARY_SERVICE_URL"
+)
+
+// NewJob returns new validation.Job instance
+func NewJob(list *kanaryv1alpha1.KanaryDeploymentSpecValidationList, s *kanaryv1alpha1.KanaryDeploymentSpecValidation) Interface {
+	return &jobImpl{
+		dryRun: list.NoUpdate,
+		config: s.Job,
+	}
+}
+
+type jobImpl struct {
+	dryRun bool
+	config *kanaryv1alpha1.KanaryDeploymentSpecValidationJob
+}
+
+func (j *jobImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
+	result := &Result{}
+
+	job := &batchv1.Job{}
+	err := kclient.Get(ctx, types.NamespacedName{Name: GetJobName(kd, j.config), Namespace: kd.Namespace}, job)
+	if err != nil && apierrors.IsNotFound(err) {
+		job, err = j.newJob(ctx, kclient, kd)
+		if err != nil {
+			return result, err
+		}
+		reqLogger.Info("Creating a new Job", "Job", job.Name)
+		if err = kclient.Create(ctx, job); err != nil {
+			return result, fmt.Errorf("unable to create the validation job %s: %v", job.Name, err)
+		}
+		result.Inconclusive = fmt.Sprintf("job %s is not completed", job.Name)
+		return result, nil
+	} else if err != nil {
+		return result, fmt.Errorf("unable to get the validation job: %v", err)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			result.IsFailed = true
+			result.Comment = fmt.Sprintf("job %s failed, reason: %s, message: %s", job.Name, condition.Reason, condition.Message)
+			reqLogger.Info("Job", "failed", job.Name, "reason", condition.Reason)
+			return result, nil
+		case batchv1.JobComplete:
+			return result, nil
+		}
+	}
+
+	// the kanary can't succeed before the end of the Job
+	if IsDeadlinePeriodDone(kd) {
+		result.IsFailed = true
+		result.Comment = fmt.Sprintf("job %s is still running at the end of the validation period", job.Name)
+		reqLogger.Info("Job", "still running", job.Name)
+		return result, nil
+	}
+	result.Inconclusive = fmt.Sprintf("job %s is not completed", job.Name)
+	return result, nil
+}
+
+// newJob returns the validation Job with the kanary service information injected in its containers
+func (j *jobImpl) newJob(ctx context.Context, kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment) (*batchv1.Job, error) {
+	serviceName := utils.GetCanaryServiceName(kd)
+	service := &corev1.Service{}
+	if err := kclient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: kd.Namespace}, service); err != nil {
+		return nil, fmt.Errorf("unable to get the kanary service %s: %v", serviceName, err)
+	}
+
+	host := fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	env := []corev1.EnvVar{
+		{Name: KanaryServiceNameEnvVar, Value: service.Name},
+		{Name: KanaryServiceHostEnvVar, Value: host},
+	}
+	if len(service.Spec.Ports) > 0 {
+		port := strconv.Itoa(int(service.Spec.Ports[0].Port))
+		env = append(env,
+			corev1.EnvVar{Name: KanaryServicePortEnvVar, Value: port},
+			corev1.EnvVar{Name: KanaryServiceURLEnvVar, Value: fmt.Sprintf("http://%s:%s", host, port)},
+		)
+	}
+
+	template := j.config.Template.DeepCopy()
+	job := &batchv1.Job{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+	job.Name = GetJobName(kd, j.config)
+	job.Namespace = kd.Namespace
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+	job.Labels[kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey] = kd.Name
+	if job.Spec.ActiveDeadlineSeconds == nil && kd.Spec.Validations.ValidationPeriod != nil {
+		deadline := int64(kd.Spec.Validations.ValidationPeriod.Duration.Seconds())
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env, env...)
+	}
+
+	// Set KanaryDeployment instance as the owner and controller
+	if err := controllerutil.SetControllerReference(kd, job, utils.PrepareSchemeForOwnerRef()); err != nil {
+		return nil, fmt.Errorf("unable to set the job owner reference: %v", err)
+	}
+	return job, nil
+}
+
+// GetJobName returns the validation Job name
+func GetJobName(kd *kanaryv1alpha1.KanaryDeployment, config *kanaryv1alpha1.KanaryDeploymentSpecValidationJob) string {
+	if config.Template.Name != "" {
+		return config.Template.Name
+	}
+	return fmt.Sprintf("%s-kanary-job", kd.Name)
+}
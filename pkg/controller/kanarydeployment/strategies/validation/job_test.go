@@ -0,0 +1,207 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func Test_jobImpl_Validation(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("Test_jobImpl_Validation")
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	var (
+		name            = "foo"
+		serviceName     = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		jobName         = name + "-kanary-job"
+
+		validations = &kanaryv1alpha1.KanaryDeploymentSpecValidationList{
+			ValidationPeriod: &metav1.Duration{Duration: 15 * time.Minute},
+		}
+		config = &kanaryv1alpha1.KanaryDeploymentSpecValidationJob{
+			Template: kanaryv1alpha1.JobTemplate{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers:    []corev1.Container{{Name: "smoke-test", Image: "smoke-test:latest"}},
+							RestartPolicy: corev1.RestartPolicyNever,
+						},
+					},
+				},
+			},
+		}
+		kanaryService = utilstest.NewService("foo-kanary-foo", namespace, nil, &utilstest.NewServiceOptions{Ports: []corev1.ServicePort{{Port: 8080}}})
+	)
+
+	newJob := func(conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: jobName, Namespace: namespace},
+			Status:     batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+
+	// newRunningKanaryDeployment returns a KanaryDeployment whose validation period is not over
+	newRunningKanaryDeployment := func() *kanaryv1alpha1.KanaryDeployment {
+		kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Validations: validations})
+		kd.CreationTimestamp = metav1.Now()
+		return kd
+	}
+
+	type fields struct {
+		config *kanaryv1alpha1.KanaryDeploymentSpecValidationJob
+	}
+	type args struct {
+		kclient   client.Client
+		kd        *kanaryv1alpha1.KanaryDeployment
+		dep       *appsv1.Deployment
+		canaryDep *appsv1.Deployment
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Result
+		wantEnv []corev1.EnvVar
+		wantErr bool
+	}{
+		{
+			name:   "job created",
+			fields: fields{config: config},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService}...),
+				kd:      newRunningKanaryDeployment(),
+			},
+			want: &Result{
+				Inconclusive: "job foo-kanary-job is not completed",
+			},
+			wantEnv: []corev1.EnvVar{
+				{Name: KanaryServiceNameEnvVar, Value: "foo-kanary-foo"},
+				{Name: KanaryServiceHostEnvVar, Value: "foo-kanary-foo.kanary.svc"},
+				{Name: KanaryServicePortEnvVar, Value: "8080"},
+				{Name: KanaryServiceURLEnvVar, Value: "http://foo-kanary-foo.kanary.svc:8080"},
+			},
+		},
+		{
+			name:   "kanary service not found",
+			fields: fields{config: config},
+			args: args{
+				kclient: fake.NewFakeClient(),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Validations: validations}),
+			},
+			want:    &Result{},
+			wantErr: true,
+		},
+		{
+			name:   "job running",
+			fields: fields{config: config},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService, newJob()}...),
+				kd:      newRunningKanaryDeployment(),
+			},
+			want: &Result{
+				Inconclusive: "job foo-kanary-job is not completed",
+			},
+		},
+		{
+			name:   "job still running at the validation deadline",
+			fields: fields{config: config},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService, newJob()}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Validations: validations}),
+			},
+			want: &Result{
+				IsFailed: true,
+				Comment:  "job foo-kanary-job is still running at the end of the validation period",
+			},
+		},
+		{
+			name:   "job succeeded",
+			fields: fields{config: config},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService, newJob(batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Validations: validations}),
+			},
+			want: &Result{
+				IsFailed: false,
+			},
+		},
+		{
+			name:   "job failed",
+			fields: fields{config: config},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService, newJob(batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit"})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Validations: validations}),
+			},
+			want: &Result{
+				IsFailed: true,
+				Comment:  "job foo-kanary-job failed, reason: BackoffLimitExceeded, message: Job has reached the specified backoff limit",
+			},
+		},
+		{
+			name:   "job deadline exceeded",
+			fields: fields{config: config},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{kanaryService, newJob(batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded", Message: "Job was active longer than specified deadline"})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, serviceName, defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Validations: validations}),
+			},
+			want: &Result{
+				IsFailed: true,
+				Comment:  "job foo-kanary-job failed, reason: DeadlineExceeded, message: Job was active longer than specified deadline",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			j := &jobImpl{
+				config: tt.fields.config,
+			}
+			got, err := j.Validation(context.Background(), tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("jobImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("jobImpl.Validation() = %v, want %v", got, tt.want)
+			}
+			if tt.wantEnv == nil {
+				return
+			}
+			job := &batchv1.Job{}
+			if err = tt.args.kclient.Get(context.TODO(), types.NamespacedName{Name: jobName, Namespace: namespace}, job); err != nil {
+				t.Fatalf("unable to get the job: %v", err)
+			}
+			if !reflect.DeepEqual(job.Spec.Template.Spec.Containers[0].Env, tt.wantEnv) {
+				t.Errorf("job env = %v, want %v", job.Spec.Template.Spec.Containers[0].Env, tt.wantEnv)
+			}
+			if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != int64(15*60) {
+				t.Errorf("job activeDeadlineSeconds = %v, want 900", job.Spec.ActiveDeadlineSeconds)
+			}
+			if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Name != name {
+				t.Errorf("job ownerReferences = %v, want the KanaryDeployment %s", job.OwnerReferences, name)
+			}
+		})
+	}
+}
@@ -1,11 +1,12 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,7 +30,7 @@ type labelWatchImpl struct {
 	config *kanaryv1alpha1.KanaryDeploymentSpecValidationLabelWatch
 }
 
-func (l *labelWatchImpl) Validation(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1beta1.Deployment) (*Result, error) {
+func (l *labelWatchImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
 	var err error
 	result := &Result{}
 	// By default a Deployement is valid until a Label is discovered on pod or deployment.
@@ -55,7 +56,7 @@ func (l *labelWatchImpl) Validation(kclient client.Client, reqLogger logr.Logger
 			return result, fmt.Errorf("unable to create the label selector from PodInvalidationLabels: %v", err)
 		}
 		var pods []corev1.Pod
-		pods, err = getPods(kclient, reqLogger, kd.Name, kd.Namespace)
+		pods, err = getPods(ctx, kclient, reqLogger, kd.Name, kd.Namespace)
 		if err != nil {
 			return result, fmt.Errorf("unable to list pods: %v", err)
 		}
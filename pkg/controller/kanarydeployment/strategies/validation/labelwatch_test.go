@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
@@ -9,7 +10,7 @@ import (
 	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
 	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -65,8 +66,8 @@ func Test_labelWatchImpl_Validation(t *testing.T) {
 	type args struct {
 		kclient   client.Client
 		kd        *kanaryv1alpha1.KanaryDeployment
-		dep       *appsv1beta1.Deployment
-		canaryDep *appsv1beta1.Deployment
+		dep       *appsv1.Deployment
+		canaryDep *appsv1.Deployment
 	}
 	tests := []struct {
 		name    string
@@ -218,7 +219,7 @@ func Test_labelWatchImpl_Validation(t *testing.T) {
 				dryRun: tt.fields.dryRun,
 				config: tt.fields.config,
 			}
-			got, err := l.Validation(tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
+			got, err := l.Validation(context.Background(), tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("labelWatchImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
 				return
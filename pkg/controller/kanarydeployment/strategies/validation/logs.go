@@ -0,0 +1,268 @@
+package validation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+const (
+	// logsLimitBytes limits the size of the logs read per pod and per validation
+	logsLimitBytes = int64(5 * 1024 * 1024)
+	// logsMaxQuotedLines number of offending lines quoted in the failure comment
+	logsMaxQuotedLines = 3
+)
+
+// podLogsGetter returns the logs stream of a Pod container
+type podLogsGetter interface {
+	GetLogs(ctx context.Context, namespace, name string, opts *corev1.PodLogOptions) (io.ReadCloser, error)
+}
+
+// NewLogs returns new validation.Logs instance
+func NewLogs(list *kanaryv1alpha1.KanaryDeploymentSpecValidationList, s *kanaryv1alpha1.KanaryDeploymentSpecValidation) Interface {
+	return &logsImpl{
+		dryRun: list.NoUpdate,
+		config: s.Logs,
+	}
+}
+
+type logsImpl struct {
+	dryRun bool
+	config *kanaryv1alpha1.KanaryDeploymentSpecValidationLogs
+
+	logsGetter podLogsGetter //for test purposes
+}
+
+// logsCount matching lines counters for a Pod
+type logsCount struct {
+	lines    int
+	matching int
+	quotes   []string
+}
+
+// rate returns the % of matching lines
+func (c *logsCount) rate() float64 {
+	if c.lines == 0 {
+		return 0
+	}
+	return float64(c.matching) * 100 / float64(c.lines)
+}
+
+func (l *logsImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
+	result := &Result{}
+
+	patterns, err := compilePatterns(l.config.Patterns)
+	if err != nil {
+		return result, err
+	}
+
+	if l.logsGetter == nil {
+		if l.logsGetter, err = newClientsetLogsGetter(); err != nil {
+			return result, err
+		}
+	}
+
+	canaryPods, err := getPods(ctx, kclient, reqLogger, kd.Name, kd.Namespace)
+	if err != nil {
+		return result, fmt.Errorf("unable to list pods: %v", err)
+	}
+	canaryPods = keepRunningPods(canaryPods, true)
+
+	var failures []string
+	var quotes []string
+	canaryMatching := 0
+	for i := range canaryPods {
+		var counts logsCount
+		counts, err = l.countMatchingLines(ctx, &canaryPods[i], patterns)
+		if err != nil {
+			return result, fmt.Errorf("unable to read logs of pod %s: %v", canaryPods[i].Name, err)
+		}
+		canaryMatching += counts.matching
+		quotes = append(quotes, counts.quotes...)
+		measurement := kanaryv1alpha1.KanaryDeploymentMeasuredValue{
+			Pod:   canaryPods[i].Name,
+			Value: fmt.Sprintf("%d/%d matching lines", counts.matching, counts.lines),
+		}
+
+		if l.config.MaxCount != nil && counts.matching > int(*l.config.MaxCount) {
+			failures = append(failures, fmt.Sprintf("%s logged %d matching lines", canaryPods[i].Name, counts.matching))
+			measurement.Failed = true
+		} else if l.config.MaxRatePercent != nil && counts.rate() > *l.config.MaxRatePercent {
+			failures = append(failures, fmt.Sprintf("%s logged %.2f%% matching lines", canaryPods[i].Name, counts.rate()))
+			measurement.Failed = true
+		}
+		result.Measurements = append(result.Measurements, measurement)
+	}
+
+	if l.config.StableComparison != nil && len(canaryPods) > 0 {
+		if dep == nil {
+			return result, fmt.Errorf("unable to compare the logs with the stable pods: deployment not found")
+		}
+		var stableMatching int
+		stableMatching, err = l.countStableMatchingLines(ctx, kclient, dep, len(canaryPods), patterns)
+		if err != nil {
+			return result, err
+		}
+		minCount := 0
+		if l.config.MaxCount != nil {
+			minCount = int(*l.config.MaxCount)
+		}
+		maxAllowed := float64(stableMatching) * (1 + *l.config.StableComparison.MaxIncreasePercent/100)
+		if canaryMatching > minCount && float64(canaryMatching) > maxAllowed {
+			failures = append(failures, fmt.Sprintf("kanary pods logged %d matching lines, stable pods %d", canaryMatching, stableMatching))
+		}
+	}
+
+	if len(failures) > 0 {
+		result.IsFailed = true
+		result.Comment = fmt.Sprintf("logs validation has detected matching lines: %s", strings.Join(failures, "; "))
+		if len(quotes) > logsMaxQuotedLines {
+			quotes = quotes[:logsMaxQuotedLines]
+		}
+		if len(quotes) > 0 {
+			result.Comment = fmt.Sprintf("%s, lines: %q", result.Comment, quotes)
+		}
+		reqLogger.Info("Logs", "failures", failures)
+	}
+
+	return result, nil
+}
+
+// countStableMatchingLines counts the matching lines on the same number of stable pods than canary pods
+func (l *logsImpl) countStableMatchingLines(ctx context.Context, kclient client.Client, dep *appsv1.Deployment, nbPods int, patterns []*regexp.Regexp) (int, error) {
+	if dep.Spec.Selector == nil {
+		return 0, fmt.Errorf("unable to list stable pods: deployment %s has no selector", dep.Name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(dep.Spec.Selector)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create the label selector of the deployment %s: %v", dep.Name, err)
+	}
+	pods := &corev1.PodList{}
+	listOptions := &client.ListOptions{
+		LabelSelector: selector,
+		Namespace:     dep.Namespace,
+	}
+	if err = kclient.List(ctx, listOptions, pods); err != nil {
+		return 0, fmt.Errorf("unable to list stable pods: %v", err)
+	}
+
+	var stablePods []corev1.Pod
+	stablePods = keepRunningPods(pods.Items, false)
+	if len(stablePods) == 0 {
+		return 0, fmt.Errorf("unable to compare the logs with the stable pods: no running pod found for deployment %s", dep.Name)
+	}
+	sort.Slice(stablePods, func(i, j int) bool { return stablePods[i].Name < stablePods[j].Name })
+	if len(stablePods) > nbPods {
+		stablePods = stablePods[:nbPods]
+	}
+
+	matching := 0
+	for i := range stablePods {
+		counts, err := l.countMatchingLines(ctx, &stablePods[i], patterns)
+		if err != nil {
+			return 0, fmt.Errorf("unable to read logs of pod %s: %v", stablePods[i].Name, err)
+		}
+		matching += counts.matching
+	}
+	return matching, nil
+}
+
+func (l *logsImpl) countMatchingLines(ctx context.Context, pod *corev1.Pod, patterns []*regexp.Regexp) (logsCount, error) {
+	counts := logsCount{}
+	sinceSeconds := int64(l.config.Window.Duration.Seconds())
+	limitBytes := logsLimitBytes
+	opts := &corev1.PodLogOptions{
+		Container:    l.config.Container,
+		SinceSeconds: &sinceSeconds,
+		LimitBytes:   &limitBytes,
+	}
+	if opts.Container == "" && len(pod.Spec.Containers) > 0 {
+		opts.Container = pod.Spec.Containers[0].Name
+	}
+
+	stream, err := l.logsGetter.GetLogs(ctx, pod.Namespace, pod.Name, opts)
+	if err != nil {
+		return counts, err
+	}
+	defer stream.Close()
+
+	// a line is at most as long as the logs read, so long lines (stack traces, JSON payloads) don't stop the scan
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), int(limitBytes)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		counts.lines++
+		for _, pattern := range patterns {
+			if pattern.MatchString(line) {
+				counts.matching++
+				if len(counts.quotes) < logsMaxQuotedLines {
+					counts.quotes = append(counts.quotes, line)
+				}
+				break
+			}
+		}
+	}
+	return counts, scanner.Err()
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var regexps []*regexp.Regexp
+	for _, pattern := range patterns {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compile logs pattern %q: %v", pattern, err)
+		}
+		regexps = append(regexps, r)
+	}
+	return regexps, nil
+}
+
+// keepRunningPods keeps only the running canary (or stable) pods
+func keepRunningPods(pods []corev1.Pod, canary bool) []corev1.Pod {
+	var running []corev1.Pod
+	for _, pod := range pods {
+		_, isCanary := pod.Labels[kanaryv1alpha1.KanaryDeploymentActivateLabelKey]
+		if pod.Status.Phase == corev1.PodRunning && isCanary == canary {
+			running = append(running, pod)
+		}
+	}
+	return running
+}
+
+// clientsetLogsGetter implements podLogsGetter with a kubernetes clientset
+type clientsetLogsGetter struct {
+	clientset kubernetes.Interface
+}
+
+func newClientsetLogsGetter() (podLogsGetter, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the kubernetes client config: %v", err)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the kubernetes clientset: %v", err)
+	}
+	return &clientsetLogsGetter{clientset: clientset}, nil
+}
+
+// GetLogs implements podLogsGetter
+func (c *clientsetLogsGetter) GetLogs(ctx context.Context, namespace, name string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+	return c.clientset.CoreV1().Pods(namespace).GetLogs(name, opts).Context(ctx).Stream()
+}
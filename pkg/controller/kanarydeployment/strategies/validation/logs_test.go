@@ -0,0 +1,284 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+type fakeLogsGetter map[string]string
+
+func (f fakeLogsGetter) GetLogs(ctx context.Context, namespace, name string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+	logs, ok := f[name]
+	if !ok {
+		return nil, fmt.Errorf("pod %s/%s not found", namespace, name)
+	}
+	return ioutil.NopCloser(strings.NewReader(logs)), nil
+}
+
+func Test_logsImpl_Validation(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("Test_logsImpl_Validation")
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	var (
+		name            = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		canaryPodName   = name + "-kanary"
+		stablePodName   = name + "-stable"
+		window          = &metav1.Duration{Duration: time.Minute}
+		patterns        = []string{"ERROR", "FATAL"}
+	)
+
+	newRunningPod := func(podName string, labels map[string]string) *corev1.Pod {
+		pod := utilstest.NewPod(podName, namespace, "hash", &utilstest.NewPodOptions{Labels: labels})
+		pod.Status.Phase = corev1.PodRunning
+		return pod
+	}
+	canaryPod := newRunningPod(canaryPodName, map[string]string{
+		kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: name,
+		kanaryv1alpha1.KanaryDeploymentActivateLabelKey:   kanaryv1alpha1.KanaryDeploymentLabelValueTrue,
+	})
+	stablePod := newRunningPod(stablePodName, map[string]string{"app": name})
+	dep := utilstest.NewDeployment(name, namespace, defaultReplicas, &utilstest.NewDeploymentOptions{Selector: map[string]string{"app": name}})
+
+	type fields struct {
+		config     *kanaryv1alpha1.KanaryDeploymentSpecValidationLogs
+		logsGetter podLogsGetter
+	}
+	type args struct {
+		kclient   client.Client
+		kd        *kanaryv1alpha1.KanaryDeployment
+		dep       *appsv1.Deployment
+		canaryDep *appsv1.Deployment
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Result
+		wantErr bool
+	}{
+		{
+			name: "no matching line",
+			fields: fields{
+				config:     &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxCount: kanaryv1alpha1.NewInt32(0)},
+				logsGetter: fakeLogsGetter{canaryPodName: "INFO started\nINFO request done\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     false,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0/2 matching lines"}},
+			},
+		},
+		{
+			name: "long line",
+			fields: fields{
+				config:     &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxCount: kanaryv1alpha1.NewInt32(0)},
+				logsGetter: fakeLogsGetter{canaryPodName: "INFO " + strings.Repeat("x", 128*1024) + "\nINFO request done\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     false,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0/2 matching lines"}},
+			},
+		},
+		{
+			name: "count exceeded",
+			fields: fields{
+				config:     &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxCount: kanaryv1alpha1.NewInt32(1)},
+				logsGetter: fakeLogsGetter{canaryPodName: "INFO started\nERROR db timeout\nFATAL out of memory\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      `logs validation has detected matching lines: foo-kanary logged 2 matching lines, lines: ["ERROR db timeout" "FATAL out of memory"]`,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "2/3 matching lines", Failed: true}},
+			},
+		},
+		{
+			name: "rate not exceeded",
+			fields: fields{
+				config:     &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxRatePercent: kanaryv1alpha1.NewFloat64(50)},
+				logsGetter: fakeLogsGetter{canaryPodName: "INFO started\nERROR db timeout\nINFO request done\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     false,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "1/3 matching lines"}},
+			},
+		},
+		{
+			name: "rate exceeded",
+			fields: fields{
+				config:     &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxRatePercent: kanaryv1alpha1.NewFloat64(50)},
+				logsGetter: fakeLogsGetter{canaryPodName: "ERROR db timeout\nINFO request done\nERROR db timeout\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      `logs validation has detected matching lines: foo-kanary logged 66.67% matching lines, lines: ["ERROR db timeout" "ERROR db timeout"]`,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "2/3 matching lines", Failed: true}},
+			},
+		},
+		{
+			name: "stable comparison: same errors than stable",
+			fields: fields{
+				config: &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxRatePercent: kanaryv1alpha1.NewFloat64(100),
+					StableComparison: &kanaryv1alpha1.LogsStableComparison{MaxIncreasePercent: kanaryv1alpha1.NewFloat64(0)}},
+				logsGetter: fakeLogsGetter{canaryPodName: "ERROR db timeout\n", stablePodName: "ERROR db timeout\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod, stablePod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     false,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "1/1 matching lines"}},
+			},
+		},
+		{
+			name: "stable comparison: more errors than stable",
+			fields: fields{
+				config: &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxRatePercent: kanaryv1alpha1.NewFloat64(100),
+					StableComparison: &kanaryv1alpha1.LogsStableComparison{MaxIncreasePercent: kanaryv1alpha1.NewFloat64(50)}},
+				logsGetter: fakeLogsGetter{canaryPodName: "ERROR db timeout\nERROR db timeout\n", stablePodName: "ERROR db timeout\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod, stablePod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      `logs validation has detected matching lines: kanary pods logged 2 matching lines, stable pods 1, lines: ["ERROR db timeout" "ERROR db timeout"]`,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "2/2 matching lines"}},
+			},
+		},
+		{
+			name: "stable comparison: per pod limit exceeded",
+			fields: fields{
+				config: &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxCount: kanaryv1alpha1.NewInt32(0),
+					StableComparison: &kanaryv1alpha1.LogsStableComparison{MaxIncreasePercent: kanaryv1alpha1.NewFloat64(0)}},
+				logsGetter: fakeLogsGetter{canaryPodName: "ERROR db timeout\n", stablePodName: "ERROR db timeout\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod, stablePod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      `logs validation has detected matching lines: foo-kanary logged 1 matching lines, lines: ["ERROR db timeout"]`,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "1/1 matching lines", Failed: true}},
+			},
+		},
+		{
+			name: "stable comparison: no stable pod",
+			fields: fields{
+				config: &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxRatePercent: kanaryv1alpha1.NewFloat64(100),
+					StableComparison: &kanaryv1alpha1.LogsStableComparison{MaxIncreasePercent: kanaryv1alpha1.NewFloat64(0)}},
+				logsGetter: fakeLogsGetter{canaryPodName: "ERROR db timeout\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want: &Result{
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "1/1 matching lines"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "stable comparison: no deployment",
+			fields: fields{
+				config: &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: patterns, Window: window, MaxRatePercent: kanaryv1alpha1.NewFloat64(100),
+					StableComparison: &kanaryv1alpha1.LogsStableComparison{MaxIncreasePercent: kanaryv1alpha1.NewFloat64(0)}},
+				logsGetter: fakeLogsGetter{canaryPodName: "ERROR db timeout\n"},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "1/1 matching lines"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad pattern",
+			fields: fields{
+				config:     &kanaryv1alpha1.KanaryDeploymentSpecValidationLogs{Patterns: []string{"ERROR("}, Window: window, MaxCount: kanaryv1alpha1.NewInt32(0)},
+				logsGetter: fakeLogsGetter{},
+			},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{canaryPod}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+				dep:     dep,
+			},
+			want:    &Result{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			l := &logsImpl{
+				config:     tt.fields.config,
+				logsGetter: tt.fields.logsGetter,
+			}
+			got, err := l.Validation(context.Background(), tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("logsImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("logsImpl.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
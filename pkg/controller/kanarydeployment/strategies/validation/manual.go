@@ -1,9 +1,11 @@
 package validation
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -25,7 +27,7 @@ type manualImpl struct {
 	dryRun                 bool
 }
 
-func (m *manualImpl) Validation(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1beta1.Deployment) (*Result, error) {
+func (m *manualImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
 	var err error
 	result := &Result{}
 
@@ -49,7 +51,7 @@ func (m *manualImpl) Validation(kclient client.Client, reqLogger logr.Logger, kd
 	return result, err
 }
 
-//IsStatusAfterDeadlineNone check if there is a Manual Strategy that prevent automation with a None Status.
+//IsStatusAfterDeadlineNone check if there is a Manual or External Strategy that prevent automation with a None Status.
 func IsStatusAfterDeadlineNone(kd *kanaryv1alpha1.KanaryDeployment) bool {
 	for _, v := range kd.Spec.Validations.Items {
 		if v.Manual != nil {
@@ -57,6 +59,11 @@ func IsStatusAfterDeadlineNone(kd *kanaryv1alpha1.KanaryDeployment) bool {
 				return true
 			}
 		}
+		if v.External != nil && v.External.StatusAfterDeadline == kanaryv1alpha1.NoneKanaryDeploymentSpecValidationManualDeadineStatus {
+			if kd.Status.ExternalVerdict == nil || kd.Status.ExternalVerdict.Verdict == kanaryv1alpha1.InconclusiveExternalVerdict {
+				return true
+			}
+		}
 	}
 	return false
 }
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
@@ -9,7 +10,7 @@ import (
 	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
 	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -65,8 +66,8 @@ func Test_manualImpl_Validation(t *testing.T) {
 	type args struct {
 		kclient   client.Client
 		kd        *kanaryv1alpha1.KanaryDeployment
-		dep       *appsv1beta1.Deployment
-		canaryDep *appsv1beta1.Deployment
+		dep       *appsv1.Deployment
+		canaryDep *appsv1.Deployment
 	}
 	tests := []struct {
 		name    string
@@ -181,7 +182,7 @@ func Test_manualImpl_Validation(t *testing.T) {
 				validationManualStatus: tt.fields.validationManualStatus,
 				dryRun:                 tt.fields.dryRun,
 			}
-			got, err := m.Validation(tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
+			got, err := m.Validation(context.Background(), tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("manualImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
 				return
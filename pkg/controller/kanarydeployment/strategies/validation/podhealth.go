@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+const (
+	crashLoopBackOffReason = "CrashLoopBackOff"
+	oomKilledReason        = "OOMKilled"
+)
+
+// NewPodHealth returns new validation.PodHealth instance
+func NewPodHealth(list *kanaryv1alpha1.KanaryDeploymentSpecValidationList, s *kanaryv1alpha1.KanaryDeploymentSpecValidation) Interface {
+	return &podHealthImpl{
+		dryRun: list.NoUpdate,
+		config: s.PodHealth,
+	}
+}
+
+type podHealthImpl struct {
+	dryRun bool
+	config *kanaryv1alpha1.KanaryDeploymentSpecValidationPodHealth
+}
+
+func (p *podHealthImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
+	result := &Result{}
+
+	pods, err := getTargetPods(ctx, kclient, reqLogger, kd, canaryDep)
+	if err != nil {
+		return result, fmt.Errorf("unable to list pods: %v", err)
+	}
+
+	warningsByPod, err := p.getWarningEventsCount(ctx, kclient, kd.Namespace, pods)
+	if err != nil {
+		return result, fmt.Errorf("unable to list events: %v", err)
+	}
+
+	now := time.Now()
+	var issues []string
+	for i := range pods {
+		podIssues := p.checkPod(&pods[i], warningsByPod[pods[i].Name], now)
+		issues = append(issues, podIssues...)
+		result.Measurements = append(result.Measurements, kanaryv1alpha1.KanaryDeploymentMeasuredValue{
+			Pod:    pods[i].Name,
+			Value:  fmt.Sprintf("%d restarts, %d warning events", getRestartCount(&pods[i]), warningsByPod[pods[i].Name]),
+			Failed: len(podIssues) > 0,
+		})
+	}
+
+	if len(issues) > 0 {
+		result.IsFailed = true
+		result.Comment = fmt.Sprintf("podHealth has detected unhealthy kanary pods: %s", strings.Join(issues, "; "))
+		reqLogger.Info("PodHealth", "issues", issues)
+	}
+
+	return result, nil
+}
+
+// checkPod returns the list of health issues detected on a canary pod
+func (p *podHealthImpl) checkPod(pod *corev1.Pod, warnings int32, now time.Time) []string {
+	var issues []string
+	for _, cs := range pod.Status.ContainerStatuses {
+		if p.config.MaxRestarts != nil && cs.RestartCount > *p.config.MaxRestarts {
+			issues = append(issues, fmt.Sprintf("%s/%s restarted %d times", pod.Name, cs.Name, cs.RestartCount))
+		}
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == crashLoopBackOffReason {
+			issues = append(issues, fmt.Sprintf("%s/%s in %s", pod.Name, cs.Name, crashLoopBackOffReason))
+		}
+		if isOOMKilled(&cs) {
+			issues = append(issues, fmt.Sprintf("%s/%s %s", pod.Name, cs.Name, oomKilledReason))
+		}
+	}
+
+	if p.config.NotReadyGracePeriod != nil {
+		if since, notReady := notReadySince(pod); notReady && now.Sub(since) > p.config.NotReadyGracePeriod.Duration {
+			issues = append(issues, fmt.Sprintf("%s not ready for more than %s", pod.Name, p.config.NotReadyGracePeriod.Duration))
+		}
+	}
+
+	if p.config.MaxWarningEvents != nil && warnings > *p.config.MaxWarningEvents {
+		issues = append(issues, fmt.Sprintf("%s reported %d warning events", pod.Name, warnings))
+	}
+	return issues
+}
+
+// getWarningEventsCount returns the number of Warning events indexed by canary pod name
+func (p *podHealthImpl) getWarningEventsCount(ctx context.Context, kclient client.Client, namespace string, pods []corev1.Pod) (map[string]int32, error) {
+	counts := map[string]int32{}
+	if p.config.MaxWarningEvents == nil || len(pods) == 0 {
+		return counts, nil
+	}
+	podNames := map[string]bool{}
+	for _, pod := range pods {
+		podNames[pod.Name] = true
+	}
+
+	events := &corev1.EventList{}
+	if err := kclient.List(ctx, &client.ListOptions{Namespace: namespace}, events); err != nil {
+		return nil, err
+	}
+	for _, event := range events.Items {
+		if event.Type != corev1.EventTypeWarning || event.InvolvedObject.Kind != "Pod" || !podNames[event.InvolvedObject.Name] {
+			continue
+		}
+		count := event.Count
+		if count < 1 {
+			count = 1
+		}
+		counts[event.InvolvedObject.Name] += count
+	}
+	return counts, nil
+}
+
+// getRestartCount returns the number of restarts of all the containers of a pod
+func getRestartCount(pod *corev1.Pod) int32 {
+	var restarts int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		restarts += cs.RestartCount
+	}
+	return restarts
+}
+
+func isOOMKilled(cs *corev1.ContainerStatus) bool {
+	if cs.State.Terminated != nil && cs.State.Terminated.Reason == oomKilledReason {
+		return true
+	}
+	if cs.LastTerminationState.Terminated != nil && cs.LastTerminationState.Terminated.Reason == oomKilledReason {
+		return true
+	}
+	return false
+}
+
+// notReadySince returns since when the pod is not Ready, and false if the pod is Ready
+func notReadySince(pod *corev1.Pod) (time.Time, bool) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			if c.Status == corev1.ConditionTrue {
+				return time.Time{}, false
+			}
+			if !c.LastTransitionTime.IsZero() {
+				return c.LastTransitionTime.Time, true
+			}
+		}
+	}
+	return pod.CreationTimestamp.Time, true
+}
@@ -0,0 +1,195 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func Test_podHealthImpl_Validation(t *testing.T) {
+	now := time.Now()
+	creationTime := &metav1.Time{Time: now.Add(-10 * time.Minute)}
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("Test_podHealthImpl_Validation")
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	var (
+		name            = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		podName         = name + "-kanary"
+
+		defaultConfig = &kanaryv1alpha1.KanaryDeploymentSpecValidationPodHealth{
+			MaxRestarts:         kanaryv1alpha1.NewInt32(1),
+			NotReadyGracePeriod: &metav1.Duration{Duration: 2 * time.Minute},
+			MaxWarningEvents:    kanaryv1alpha1.NewInt32(2),
+		}
+	)
+
+	newPod := func(ready bool, containerStatus corev1.ContainerStatus) *corev1.Pod {
+		pod := utilstest.NewPod(podName, namespace, "hash", &utilstest.NewPodOptions{CreationTime: creationTime, Labels: map[string]string{kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: name}})
+		readyStatus := corev1.ConditionTrue
+		if !ready {
+			readyStatus = corev1.ConditionFalse
+		}
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: readyStatus, LastTransitionTime: *creationTime},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{containerStatus},
+		}
+		return pod
+	}
+	newWarningEvent := func(count int32) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: podName + ".event", Namespace: namespace},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: podName, Namespace: namespace},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Unhealthy",
+			Count:          count,
+		}
+	}
+
+	type fields struct {
+		config *kanaryv1alpha1.KanaryDeploymentSpecValidationPodHealth
+	}
+	type args struct {
+		kclient   client.Client
+		kd        *kanaryv1alpha1.KanaryDeployment
+		dep       *appsv1.Deployment
+		canaryDep *appsv1.Deployment
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Result
+		wantErr bool
+	}{
+		{
+			name:   "healthy pod",
+			fields: fields{config: defaultConfig},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{newPod(true, corev1.ContainerStatus{Name: "app", RestartCount: 1}), newWarningEvent(1)}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				IsFailed:     false,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "1 restarts, 1 warning events"}},
+			},
+		},
+		{
+			name:   "too many restarts",
+			fields: fields{config: defaultConfig},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{newPod(true, corev1.ContainerStatus{Name: "app", RestartCount: 2})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      "podHealth has detected unhealthy kanary pods: foo-kanary/app restarted 2 times",
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "2 restarts, 0 warning events", Failed: true}},
+			},
+		},
+		{
+			name:   "crashLoopBackOff",
+			fields: fields{config: defaultConfig},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{newPod(true, corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      "podHealth has detected unhealthy kanary pods: foo-kanary/app in CrashLoopBackOff",
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0 restarts, 0 warning events", Failed: true}},
+			},
+		},
+		{
+			name:   "OOMKilled",
+			fields: fields{config: defaultConfig},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{newPod(true, corev1.ContainerStatus{Name: "app", LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}}})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      "podHealth has detected unhealthy kanary pods: foo-kanary/app OOMKilled",
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0 restarts, 0 warning events", Failed: true}},
+			},
+		},
+		{
+			name: "not ready during grace period",
+			fields: fields{config: &kanaryv1alpha1.KanaryDeploymentSpecValidationPodHealth{
+				NotReadyGracePeriod: &metav1.Duration{Duration: time.Hour},
+			}},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{newPod(false, corev1.ContainerStatus{Name: "app"})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				IsFailed:     false,
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0 restarts, 0 warning events"}},
+			},
+		},
+		{
+			name:   "not ready after grace period",
+			fields: fields{config: defaultConfig},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{newPod(false, corev1.ContainerStatus{Name: "app"})}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      "podHealth has detected unhealthy kanary pods: foo-kanary not ready for more than 2m0s",
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0 restarts, 0 warning events", Failed: true}},
+			},
+		},
+		{
+			name:   "too many warning events",
+			fields: fields{config: defaultConfig},
+			args: args{
+				kclient: fake.NewFakeClient([]runtime.Object{newPod(true, corev1.ContainerStatus{Name: "app"}), newWarningEvent(3)}...),
+				kd:      kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil),
+			},
+			want: &Result{
+				IsFailed:     true,
+				Comment:      "podHealth has detected unhealthy kanary pods: foo-kanary reported 3 warning events",
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Pod: "foo-kanary", Value: "0 restarts, 3 warning events", Failed: true}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			p := &podHealthImpl{
+				config: tt.fields.config,
+			}
+			got, err := p.Validation(context.Background(), tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("podHealthImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("podHealthImpl.Validation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
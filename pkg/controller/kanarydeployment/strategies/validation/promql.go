@@ -2,12 +2,19 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	promClient "github.com/prometheus/client_golang/api"
+	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +42,9 @@ type promqlImpl struct {
 
 	anomalydetector        anomalydetector.AnomalyDetector
 	anomalydetectorFactory anomalydetector.Factory //for test purposes
+	queryAPI               promApi.API             // traffic exclusion queries
+	excludedPods           map[string]string       // exclusion reasons indexed by pod name
+	analysedPods           map[string]bool         // pods not excluded by the exclusion rules
 }
 
 type promqlPodLister struct {
@@ -64,7 +74,13 @@ func (pl *promqlPodLister) Get(name string) (*corev1.Pod, error) {
 	return pod, nil
 }
 
-func (p *promqlImpl) initAnomalyDetector(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, labelSelector map[string]string) error {
+func (p *promqlImpl) initAnomalyDetector(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, labelSelector map[string]string) error {
+	p.excludedPods, p.analysedPods = map[string]string{}, map[string]bool{}
+	exclusionFunc, err := p.newExclusionFunc(ctx, p.excludedPods, p.analysedPods)
+	if err != nil {
+		return err
+	}
+
 	//config is kind of cloned but that allow decoupling between the CRD definition and the anomalydetector package
 	anomalyDetectorConfig := anomalydetector.FactoryConfig{
 		Config: anomalydetector.Config{
@@ -73,7 +89,9 @@ func (p *promqlImpl) initAnomalyDetector(kclient client.Client, reqLogger logr.L
 				kclient:   kclient,
 				Namespace: kd.Namespace,
 			},
-			Selector: labels.SelectorFromSet(labelSelector),
+			Selector:      labels.SelectorFromSet(labelSelector),
+			ExclusionFunc: exclusionFunc,
+			MinSamples:    p.getMinSamples(),
 		},
 		PromConfig: &anomalydetector.ConfigPrometheusAnomalyDetector{
 			PrometheusService: p.validationSpec.PrometheusService,
@@ -105,23 +123,22 @@ func (p *promqlImpl) initAnomalyDetector(kclient client.Client, reqLogger logr.L
 		p.anomalydetectorFactory = anomalydetector.New
 	}
 
-	var err error
 	if p.anomalydetector, err = p.anomalydetectorFactory(anomalyDetectorConfig); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (p *promqlImpl) Validation(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1beta1.Deployment) (*Result, error) {
+func (p *promqlImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
 	var err error
 	result := &Result{}
 
 	//re-init the anomaly detector at each validation in case some settings have changed in the kd
-	if err = p.initAnomalyDetector(kclient, reqLogger, kd, canaryDep.Spec.Selector.MatchLabels); err != nil {
+	if err = p.initAnomalyDetector(ctx, kclient, reqLogger, kd, canaryDep.Spec.Selector.MatchLabels); err != nil {
 		return result, err
 	}
 	// By default a Deployement is valid until a Label is discovered on pod or deployment.
-	pods, err := p.anomalydetector.GetPodsOutOfBounds()
+	pods, err := p.anomalydetector.GetPodsOutOfBounds(ctx)
 	if err != nil {
 		reqLogger.Error(err, "GetPodsOutOfBounds")
 		return result, err
@@ -137,5 +154,192 @@ func (p *promqlImpl) Validation(kclient client.Client, reqLogger logr.Logger, kd
 		result.Comment = "promQL query reported an issue with one of the kanary pod"
 	}
 
+	if reporter, ok := p.anomalydetector.(anomalydetector.MeasuredValuesReporter); ok {
+		result.Measurements = newMeasurements(reporter.GetMeasuredValues(), pods)
+	}
+
+	if len(p.excludedPods) > 0 && len(p.analysedPods) == 0 {
+		// no kanary pod was analysed, its success would be without evidence
+		result.InsufficientData = "all the kanary pods are excluded from the promQL analysis"
+		reqLogger.Info("All the kanary pods are excluded", "policy", p.validationSpec.NoDataPolicy)
+		p.applyNoDataPolicy(kd, result)
+	} else if detector, ok := p.anomalydetector.(anomalydetector.InsufficientDataDetector); ok {
+		p.checkInsufficientData(reqLogger, kd, detector.GetPodsWithInsufficientData(), result)
+	}
+
+	result.ExcludedPods = newExcludedPods(p.excludedPods)
+
 	return result, err
 }
+
+// checkInsufficientData applies the NoDataPolicy when some kanary pods did not get enough samples
+func (p *promqlImpl) checkInsufficientData(reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, pods []*corev1.Pod, result *Result) {
+	if len(pods) == 0 {
+		return
+	}
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	result.InsufficientData = fmt.Sprintf("promQL query returned less than %d samples for kanary pods: %s", p.getMinSamples(), strings.Join(names, ","))
+	reqLogger.Info("GetPodsWithInsufficientData", "pods", len(pods), "policy", p.validationSpec.NoDataPolicy)
+	p.applyNoDataPolicy(kd, result)
+}
+
+// applyNoDataPolicy applies the NoDataPolicy to a result with insufficient data
+func (p *promqlImpl) applyNoDataPolicy(kd *kanaryv1alpha1.KanaryDeployment, result *Result) {
+	var failure string
+	switch p.validationSpec.NoDataPolicy {
+	case kanaryv1alpha1.FailNoDataPolicy:
+		failure = result.InsufficientData
+	case kanaryv1alpha1.ExtendNoDataPolicy:
+		if time.Now().Before(GetValidationDeadLine(kd).Add(p.getMaxExtension())) {
+			result.ExtendValidation = true
+			return
+		}
+		failure = fmt.Sprintf("%s, after a validation period extension of %s", result.InsufficientData, p.getMaxExtension())
+	default:
+		return
+	}
+
+	if result.IsFailed {
+		result.Comment = fmt.Sprintf("%s; %s", result.Comment, failure)
+	} else {
+		result.IsFailed = true
+		result.Comment = failure
+	}
+}
+
+// newMeasurements returns the measured values sorted by pod name, flagging the pods out of bounds
+func newMeasurements(values map[string]string, podsOutOfBounds []*corev1.Pod) []kanaryv1alpha1.KanaryDeploymentMeasuredValue {
+	if len(values) == 0 {
+		return nil
+	}
+	failed := map[string]bool{}
+	for _, pod := range podsOutOfBounds {
+		failed[pod.Name] = true
+	}
+	measurements := make([]kanaryv1alpha1.KanaryDeploymentMeasuredValue, 0, len(values))
+	for podName, value := range values {
+		measurements = append(measurements, kanaryv1alpha1.KanaryDeploymentMeasuredValue{Pod: podName, Value: value, Failed: failed[podName]})
+	}
+	sort.Slice(measurements, func(i, j int) bool { return measurements[i].Pod < measurements[j].Pod })
+	return measurements
+}
+
+func (p *promqlImpl) getMinSamples() uint {
+	if p.validationSpec.MinSamples == nil {
+		return 1
+	}
+	return uint(*p.validationSpec.MinSamples)
+}
+
+func (p *promqlImpl) getMaxExtension() time.Duration {
+	if p.validationSpec.MaxExtension == nil {
+		return p.validationPeriod
+	}
+	return p.validationSpec.MaxExtension.Duration
+}
+
+// newExclusionFunc returns the anomaly detector ExclusionFunc applying the exclusion rules, the reason of each exclusion is recorded in excluded
+// and the pods left for the analysis in analysed
+func (p *promqlImpl) newExclusionFunc(ctx context.Context, excluded map[string]string, analysed map[string]bool) (func(*corev1.Pod) (bool, error), error) {
+	exclusion := p.validationSpec.Exclusion
+	if exclusion == nil {
+		return nil, nil
+	}
+
+	var selector labels.Selector
+	if exclusion.LabelSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(exclusion.LabelSelector); err != nil {
+			return nil, fmt.Errorf("unable to convert the exclusion label selector: %v", err)
+		}
+	}
+	var trafficByPodName map[string]float64
+	if exclusion.TrafficQuery != "" {
+		var err error
+		if trafficByPodName, err = p.getTrafficByPodName(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	return func(pod *corev1.Pod) (bool, error) {
+		var reason string
+		switch {
+		case exclusion.MinPodAge != nil && now.Sub(getPodStartTime(pod)) < exclusion.MinPodAge.Duration:
+			reason = fmt.Sprintf("started for less than %s", exclusion.MinPodAge.Duration)
+		case selector != nil && selector.Matches(labels.Set(pod.Labels)):
+			reason = "matching the exclusion label selector"
+		case exclusion.TrafficQuery != "" && trafficByPodName[pod.Name] < p.getMinTraffic():
+			reason = fmt.Sprintf("traffic below %g", p.getMinTraffic())
+		default:
+			analysed[pod.Name] = true
+			return false, nil
+		}
+		excluded[pod.Name] = reason
+		return true, nil
+	}, nil
+}
+
+// getTrafficByPodName executes the exclusion traffic query
+func (p *promqlImpl) getTrafficByPodName(ctx context.Context) (map[string]float64, error) {
+	if p.queryAPI == nil {
+		prometheusClient, err := promClient.NewClient(promClient.Config{Address: "http://" + p.validationSpec.PrometheusService})
+		if err != nil {
+			return nil, err
+		}
+		p.queryAPI = promApi.NewAPI(prometheusClient)
+	}
+
+	value, err := p.queryAPI.Query(ctx, p.validationSpec.Exclusion.TrafficQuery, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error processing the exclusion traffic query: %v", err)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("the exclusion traffic query did not return a result in the form of expected type 'model.Vector'")
+	}
+	trafficByPodName := map[string]float64{}
+	for _, sample := range vector {
+		if podName := string(sample.Metric[model.LabelName(p.validationSpec.PodNameKey)]); podName != "" {
+			trafficByPodName[podName] += float64(sample.Value)
+		}
+	}
+	return trafficByPodName, nil
+}
+
+// getPodStartTime returns the time of the last (re)start of the pod
+func getPodStartTime(pod *corev1.Pod) time.Time {
+	startTime := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		startTime = pod.Status.StartTime.Time
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running != nil && status.State.Running.StartedAt.After(startTime) {
+			startTime = status.State.Running.StartedAt.Time
+		}
+	}
+	return startTime
+}
+
+// newExcludedPods returns the excluded pods sorted by pod name
+func newExcludedPods(excluded map[string]string) []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod {
+	if len(excluded) == 0 {
+		return nil
+	}
+	pods := make([]kanaryv1alpha1.KanaryDeploymentStatusExcludedPod, 0, len(excluded))
+	for podName, reason := range excluded {
+		pods = append(pods, kanaryv1alpha1.KanaryDeploymentStatusExcludedPod{Pod: podName, Reason: reason})
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Pod < pods[j].Pod })
+	return pods
+}
+
+func (p *promqlImpl) getMinTraffic() float64 {
+	if p.validationSpec.Exclusion.MinTraffic == nil {
+		return 1
+	}
+	return *p.validationSpec.Exclusion.MinTraffic
+}
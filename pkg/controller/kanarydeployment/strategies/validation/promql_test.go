@@ -1,16 +1,21 @@
 package validation
 
 import (
+	"context"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/model"
+
 	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
 	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/anomalydetector"
 	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +43,8 @@ func Test_promqlImpl_Validation(t *testing.T) {
 		//	serviceName     = "foo"
 		namespace       = "kanary"
 		defaultReplicas = int32(5)
+
+		kanaryPod = utilstest.NewPod(name+"-kanary", namespace, "hash", &utilstest.NewPodOptions{Labels: map[string]string{"foo": "bar", "foo-k": "bar-k"}})
 	)
 	type fields struct {
 		validationSpec         kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL
@@ -50,8 +57,28 @@ func Test_promqlImpl_Validation(t *testing.T) {
 	type args struct {
 		kclient   client.Client
 		kd        *kanaryv1alpha1.KanaryDeployment
-		dep       *appsv1beta1.Deployment
-		canaryDep *appsv1beta1.Deployment
+		dep       *appsv1.Deployment
+		canaryDep *appsv1.Deployment
+	}
+	insufficientDataFactory := func(outOfBounds []*corev1.Pod) anomalydetector.Factory {
+		return func(cfg anomalydetector.FactoryConfig) (anomalydetector.AnomalyDetector, error) {
+			return &anomalydetector.Fake{Pods: outOfBounds, InsufficientData: []*corev1.Pod{kanaryPod}}, nil
+		}
+	}
+	// excludingFactory returns a detector applying the exclusion rules to the kanary pod
+	excludingFactory := func(cfg anomalydetector.FactoryConfig) (anomalydetector.AnomalyDetector, error) {
+		if _, err := cfg.ExclusionFunc(kanaryPod); err != nil {
+			return nil, err
+		}
+		return &anomalydetector.Fake{}, nil
+	}
+	newArgs := func(startTime *metav1.Time) args {
+		return args{
+			kclient:   fake.NewFakeClient([]runtime.Object{utilstest.NewDeployment(name, namespace, defaultReplicas, nil), kanaryPod}...),
+			kd:        kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{StartTime: startTime}),
+			dep:       utilstest.NewDeployment(name, namespace, defaultReplicas, &utilstest.NewDeploymentOptions{CreationTime: creationTime, Labels: map[string]string{"foo": "bar"}, Selector: map[string]string{"foo": "bar"}}),
+			canaryDep: utilstest.NewDeployment(name+"-kanary-"+name, namespace, 1, &utilstest.NewDeploymentOptions{CreationTime: creationTime, Labels: map[string]string{"foo": "bar", "foo-k": "bar-k"}, Selector: map[string]string{"foo-k": "bar-k"}}),
+		}
 	}
 	tests := []struct {
 		name    string
@@ -107,6 +134,109 @@ func Test_promqlImpl_Validation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "measured values",
+			fields: fields{
+				validationPeriod: 30 * time.Second,
+				validationSpec:   kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{},
+				anomalydetectorFactory: func(cfg anomalydetector.FactoryConfig) (anomalydetector.AnomalyDetector, error) {
+					return &anomalydetector.Fake{Pods: []*corev1.Pod{kanaryPod}, Values: map[string]string{"foo-kanary": "0.8", "foo-kanary-2": "0.1"}}, nil
+				},
+			},
+			args: newArgs(nil),
+			want: &Result{
+				IsFailed: true,
+				Comment:  "promQL query reported an issue with one of the kanary pod",
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{
+					{Pod: "foo-kanary", Value: "0.8", Failed: true},
+					{Pod: "foo-kanary-2", Value: "0.1"},
+				},
+			},
+		},
+		{
+			name: "insufficient data, pass policy",
+			fields: fields{
+				validationPeriod:       30 * time.Second,
+				validationSpec:         kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{NoDataPolicy: kanaryv1alpha1.PassNoDataPolicy},
+				anomalydetectorFactory: insufficientDataFactory(nil),
+			},
+			args: newArgs(nil),
+			want: &Result{
+				InsufficientData: "promQL query returned less than 1 samples for kanary pods: foo-kanary",
+			},
+		},
+		{
+			name: "insufficient data, fail policy",
+			fields: fields{
+				validationPeriod:       30 * time.Second,
+				validationSpec:         kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{MinSamples: kanaryv1alpha1.NewInt32(10), NoDataPolicy: kanaryv1alpha1.FailNoDataPolicy},
+				anomalydetectorFactory: insufficientDataFactory([]*corev1.Pod{kanaryPod}),
+			},
+			args: newArgs(nil),
+			want: &Result{
+				IsFailed:         true,
+				Comment:          "promQL query reported an issue with one of the kanary pod; promQL query returned less than 10 samples for kanary pods: foo-kanary",
+				InsufficientData: "promQL query returned less than 10 samples for kanary pods: foo-kanary",
+			},
+		},
+		{
+			name: "insufficient data, extend policy",
+			fields: fields{
+				validationPeriod:       30 * time.Second,
+				validationSpec:         kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{NoDataPolicy: kanaryv1alpha1.ExtendNoDataPolicy},
+				anomalydetectorFactory: insufficientDataFactory(nil),
+			},
+			args: newArgs(&metav1.Time{Time: now}),
+			want: &Result{
+				InsufficientData: "promQL query returned less than 1 samples for kanary pods: foo-kanary",
+				ExtendValidation: true,
+			},
+		},
+		{
+			name: "insufficient data, extension done",
+			fields: fields{
+				validationPeriod:       30 * time.Second,
+				validationSpec:         kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{NoDataPolicy: kanaryv1alpha1.ExtendNoDataPolicy, MaxExtension: &metav1.Duration{Duration: time.Minute}},
+				anomalydetectorFactory: insufficientDataFactory(nil),
+			},
+			args: newArgs(nil),
+			want: &Result{
+				IsFailed:         true,
+				Comment:          "promQL query returned less than 1 samples for kanary pods: foo-kanary, after a validation period extension of 1m0s",
+				InsufficientData: "promQL query returned less than 1 samples for kanary pods: foo-kanary",
+			},
+		},
+		{
+			name: "all the pods excluded, fail policy",
+			fields: fields{
+				validationPeriod: 30 * time.Second,
+				validationSpec: kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{
+					NoDataPolicy: kanaryv1alpha1.FailNoDataPolicy,
+					Exclusion:    &kanaryv1alpha1.PromQLExclusion{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"foo-k": "bar-k"}}},
+				},
+				anomalydetectorFactory: excludingFactory,
+			},
+			args: newArgs(nil),
+			want: &Result{
+				IsFailed:         true,
+				Comment:          "all the kanary pods are excluded from the promQL analysis",
+				InsufficientData: "all the kanary pods are excluded from the promQL analysis",
+				ExcludedPods:     []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod{{Pod: "foo-kanary", Reason: "matching the exclusion label selector"}},
+			},
+		},
+		{
+			name: "pod not excluded",
+			fields: fields{
+				validationPeriod: 30 * time.Second,
+				validationSpec: kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{
+					NoDataPolicy: kanaryv1alpha1.FailNoDataPolicy,
+					Exclusion:    &kanaryv1alpha1.PromQLExclusion{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"debug": "true"}}},
+				},
+				anomalydetectorFactory: excludingFactory,
+			},
+			args: newArgs(nil),
+			want: &Result{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -119,7 +249,7 @@ func Test_promqlImpl_Validation(t *testing.T) {
 				anomalydetector:        tt.fields.anomalydetector,
 				anomalydetectorFactory: tt.fields.anomalydetectorFactory,
 			}
-			got, err := p.Validation(tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
+			got, err := p.Validation(context.Background(), tt.args.kclient, reqLogger, tt.args.kd, tt.args.dep, tt.args.canaryDep)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("promqlImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -130,3 +260,110 @@ func Test_promqlImpl_Validation(t *testing.T) {
 		})
 	}
 }
+
+func Test_promqlImpl_newExclusionFunc(t *testing.T) {
+	now := time.Now()
+	newPod := func(name string, startTime time.Time, labels map[string]string) *corev1.Pod {
+		pod := utilstest.NewPod(name, "kanary", "hash", &utilstest.NewPodOptions{Labels: labels})
+		pod.Status.StartTime = &metav1.Time{Time: startTime}
+		return pod
+	}
+	restartedPod := newPod("restarted", now.Add(-time.Hour), nil)
+	restartedPod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.Time{Time: now.Add(-10 * time.Second)}}}},
+	}
+	trafficQuery := `sum(rate(requests_total[1m])) by (pod)`
+
+	tests := []struct {
+		name      string
+		exclusion *kanaryv1alpha1.PromQLExclusion
+		traffic   model.Vector
+		pod       *corev1.Pod
+		want      bool
+		wantPods  []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod
+	}{
+		{
+			name: "no exclusion rule",
+			pod:  newPod("foo", now, nil),
+		},
+		{
+			name:      "young pod",
+			exclusion: &kanaryv1alpha1.PromQLExclusion{MinPodAge: &metav1.Duration{Duration: time.Minute}},
+			pod:       newPod("young", now.Add(-10*time.Second), nil),
+			want:      true,
+			wantPods:  []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod{{Pod: "young", Reason: "started for less than 1m0s"}},
+		},
+		{
+			name:      "old pod",
+			exclusion: &kanaryv1alpha1.PromQLExclusion{MinPodAge: &metav1.Duration{Duration: time.Minute}},
+			pod:       newPod("old", now.Add(-time.Hour), nil),
+		},
+		{
+			name:      "just restarted pod",
+			exclusion: &kanaryv1alpha1.PromQLExclusion{MinPodAge: &metav1.Duration{Duration: time.Minute}},
+			pod:       restartedPod,
+			want:      true,
+			wantPods:  []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod{{Pod: "restarted", Reason: "started for less than 1m0s"}},
+		},
+		{
+			name:      "pod matching the label selector",
+			exclusion: &kanaryv1alpha1.PromQLExclusion{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"debug": "true"}}},
+			pod:       newPod("debug", now, map[string]string{"debug": "true"}),
+			want:      true,
+			wantPods:  []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod{{Pod: "debug", Reason: "matching the exclusion label selector"}},
+		},
+		{
+			name:      "low traffic pod",
+			exclusion: &kanaryv1alpha1.PromQLExclusion{TrafficQuery: trafficQuery, MinTraffic: kanaryv1alpha1.NewFloat64(5)},
+			traffic:   newPromVector(model.Metric{"pod": "idle"}, 0.5),
+			pod:       newPod("idle", now, nil),
+			want:      true,
+			wantPods:  []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod{{Pod: "idle", Reason: "traffic below 5"}},
+		},
+		{
+			name:      "pod without traffic value",
+			exclusion: &kanaryv1alpha1.PromQLExclusion{TrafficQuery: trafficQuery, MinTraffic: kanaryv1alpha1.NewFloat64(5)},
+			traffic:   newPromVector(model.Metric{"pod": "busy"}, 50),
+			pod:       newPod("unknown", now, nil),
+			want:      true,
+			wantPods:  []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod{{Pod: "unknown", Reason: "traffic below 5"}},
+		},
+		{
+			name:      "serving pod",
+			exclusion: &kanaryv1alpha1.PromQLExclusion{TrafficQuery: trafficQuery, MinTraffic: kanaryv1alpha1.NewFloat64(5)},
+			traffic:   newPromVector(model.Metric{"pod": "busy"}, 50),
+			pod:       newPod("busy", now, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPrometheusStandIn(t, map[string]model.Vector{trafficQuery: tt.traffic}, http.StatusOK)
+			defer server.Close()
+
+			p := &promqlImpl{
+				validationSpec: kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{
+					PrometheusService: strings.TrimPrefix(server.URL, "http://"),
+					PodNameKey:        "pod",
+					Exclusion:         tt.exclusion,
+				},
+			}
+			excluded := map[string]string{}
+			exclusionFunc, err := p.newExclusionFunc(context.Background(), excluded, map[string]bool{})
+			if err != nil {
+				t.Fatalf("promqlImpl.newExclusionFunc() error = %v", err)
+			}
+			var got bool
+			if exclusionFunc != nil {
+				if got, err = exclusionFunc(tt.pod); err != nil {
+					t.Fatalf("promqlImpl.newExclusionFunc()() error = %v", err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("promqlImpl.newExclusionFunc()() = %v, want %v", got, tt.want)
+			}
+			if gotPods := newExcludedPods(excluded); !reflect.DeepEqual(gotPods, tt.wantPods) {
+				t.Errorf("newExcludedPods() = %v, want %v", gotPods, tt.wantPods)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package validation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	promClient "github.com/prometheus/client_golang/api"
+	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+// GetSamples returns the number of samples served by the kanary, from the early success samples query
+func GetSamples(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, canaryDep *appsv1.Deployment) (float64, error) {
+	config := kd.Spec.Validations.EarlySuccess
+	data, err := newCanaryTemplateData(ctx, kclient, reqLogger, kd, canaryDep)
+	if err != nil {
+		return 0, err
+	}
+	prometheusClient, err := promClient.NewClient(promClient.Config{Address: "http://" + config.PrometheusService})
+	if err != nil {
+		return 0, err
+	}
+	return queryVectorSum(ctx, promApi.NewAPI(prometheusClient), "early success samplesQuery", config.SamplesQuery, data)
+}
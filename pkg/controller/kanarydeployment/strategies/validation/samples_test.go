@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/model"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestGetSamples(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("TestGetSamples")
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	var (
+		name            = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		canaryPod       = utilstest.NewPod(name+"-kanary", namespace, "hash", &utilstest.NewPodOptions{Labels: map[string]string{kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: name}})
+		canaryDep       = utilstest.NewDeployment(name+"-kanary-"+name, namespace, 1, nil)
+		samplesQuery    = `sum(requests_total{pod=~"{{.Pods}}"})`
+	)
+
+	tests := []struct {
+		name    string
+		values  map[string]model.Vector
+		status  int
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:   "samples",
+			values: map[string]model.Vector{`sum(requests_total{pod=~"foo-kanary"})`: newPromVector(nil, 1500)},
+			status: http.StatusOK,
+			want:   1500,
+		},
+		{
+			name:   "no sample",
+			status: http.StatusOK,
+		},
+		{
+			name:    "prometheus error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			server := newPrometheusStandIn(t, tt.values, tt.status)
+			defer server.Close()
+
+			kclient := fake.NewFakeClient([]runtime.Object{canaryPod}...)
+			kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil)
+			kd.Spec.Validations.EarlySuccess = &kanaryv1alpha1.KanaryDeploymentSpecValidationEarlySuccess{
+				PrometheusService: strings.TrimPrefix(server.URL, "http://"),
+				SamplesQuery:      samplesQuery,
+				MinSamples:        kanaryv1alpha1.NewFloat64(1000),
+			}
+
+			got, err := GetSamples(context.Background(), kclient, reqLogger, kd, canaryDep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSamples() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetSamples() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
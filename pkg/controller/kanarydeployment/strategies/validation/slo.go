@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	promClient "github.com/prometheus/client_golang/api"
+	promApi "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+// NewSLO returns new validation.SLO instance
+func NewSLO(list *kanaryv1alpha1.KanaryDeploymentSpecValidationList, s *kanaryv1alpha1.KanaryDeploymentSpecValidation) Interface {
+	return &sloImpl{
+		dryRun: list.NoUpdate,
+		config: s.SLO,
+	}
+}
+
+type sloImpl struct {
+	dryRun bool
+	config *kanaryv1alpha1.KanaryDeploymentSpecValidationSLO
+
+	queryAPI promApi.API
+}
+
+// burnRates error-budget burn rates over a pair of windows
+type burnRates struct {
+	window kanaryv1alpha1.SLOBurnRateWindow
+	long   float64
+	short  float64
+	noData bool
+}
+
+func (b *burnRates) isFailed() bool {
+	return b.long > *b.window.BurnRateFactor && b.short > *b.window.BurnRateFactor
+}
+
+func (b *burnRates) windowName() string {
+	return fmt.Sprintf("%s/%s", model.Duration(b.window.LongWindow.Duration), model.Duration(b.window.ShortWindow.Duration))
+}
+
+func (s *sloImpl) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*Result, error) {
+	result := &Result{}
+
+	if s.config.Objective == nil {
+		return result, fmt.Errorf("SLO objective not defined")
+	}
+	for _, window := range s.config.Windows {
+		if window.BurnRateFactor == nil {
+			return result, fmt.Errorf("SLO burn rate factor not defined for the window %s/%s", model.Duration(window.LongWindow.Duration), model.Duration(window.ShortWindow.Duration))
+		}
+	}
+
+	data, err := newCanaryTemplateData(ctx, kclient, reqLogger, kd, canaryDep)
+	if err != nil {
+		return result, err
+	}
+	if s.queryAPI == nil {
+		prometheusClient, err := promClient.NewClient(promClient.Config{Address: "http://" + s.config.PrometheusService})
+		if err != nil {
+			return result, err
+		}
+		s.queryAPI = promApi.NewAPI(prometheusClient)
+	}
+
+	var failures, noData []string
+	for _, window := range s.config.Windows {
+		rates := &burnRates{window: window}
+		var longNoData, shortNoData bool
+		if rates.long, longNoData, err = s.getBurnRate(ctx, data, window.LongWindow.Duration); err != nil {
+			return result, err
+		}
+		if rates.short, shortNoData, err = s.getBurnRate(ctx, data, window.ShortWindow.Duration); err != nil {
+			return result, err
+		}
+		rates.noData = longNoData || shortNoData
+
+		failed := rates.isFailed()
+		if failed {
+			failures = append(failures, fmt.Sprintf("%.2f/%.2f over %s", rates.long, rates.short, rates.windowName()))
+		}
+		if rates.noData {
+			noData = append(noData, rates.windowName())
+		}
+		result.Measurements = append(result.Measurements, kanaryv1alpha1.KanaryDeploymentMeasuredValue{
+			Window: rates.windowName(),
+			Value:  fmt.Sprintf("%.2f/%.2f", rates.long, rates.short),
+			Failed: failed,
+		})
+	}
+
+	if len(failures) > 0 {
+		result.IsFailed = true
+		result.Comment = fmt.Sprintf("SLO error budget burn rate above the factor: %s", strings.Join(failures, ", "))
+		reqLogger.Info("SLO", "burnRates", failures)
+	}
+	if len(noData) > 0 {
+		result.InsufficientData = fmt.Sprintf("SLO total events query returned no event over: %s", strings.Join(noData, ", "))
+	}
+
+	return result, nil
+}
+
+// getBurnRate returns the error-budget burn rate over the window: the ratio between the error rate and the error budget.
+// noData is true if no event was recorded over the window.
+func (s *sloImpl) getBurnRate(ctx context.Context, data canaryTemplateData, window time.Duration) (burnRate float64, noData bool, err error) {
+	data.Window = model.Duration(window).String()
+	good, err := s.query(ctx, "goodEventsQuery", s.config.GoodEventsQuery, data)
+	if err != nil {
+		return 0, false, err
+	}
+	total, err := s.query(ctx, "totalEventsQuery", s.config.TotalEventsQuery, data)
+	if err != nil {
+		return 0, false, err
+	}
+	if total <= 0 {
+		return 0, true, nil
+	}
+
+	errorRate := 1 - good/total
+	if errorRate < 0 {
+		errorRate = 0
+	}
+	return errorRate / (1 - *s.config.Objective/100), false, nil
+}
+
+// query executes the query template and returns the sum of the returned samples
+func (s *sloImpl) query(ctx context.Context, name, query string, data canaryTemplateData) (float64, error) {
+	return queryVectorSum(ctx, s.queryAPI, "SLO "+name, query, data)
+}
@@ -0,0 +1,179 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+// newPrometheusStandIn returns a local stand-in of the Prometheus instant query API, answering the vectors by query.
+// A query without vector gets an empty vector.
+func newPrometheusStandIn(t *testing.T, vectors map[string]model.Vector, status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse prometheus query: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"status":"error","errorType":"internal","error":"prometheus error"}`))
+			return
+		}
+		vector, ok := vectors[r.Form.Get("query")]
+		if !ok {
+			vector = model.Vector{}
+		}
+		result, err := json.Marshal(vector)
+		if err != nil {
+			t.Errorf("unable to marshal prometheus vector: %v", err)
+		}
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":` + string(result) + `}}`))
+	}))
+}
+
+// newPromVector returns a vector of a single sample
+func newPromVector(metric model.Metric, value float64) model.Vector {
+	return model.Vector{&model.Sample{Metric: metric, Value: model.SampleValue(value), Timestamp: model.Now()}}
+}
+
+func Test_sloImpl_Validation(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	log := logf.Log.WithName("Test_sloImpl_Validation")
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	var (
+		name            = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		canaryPod       = utilstest.NewPod(name+"-kanary", namespace, "hash", &utilstest.NewPodOptions{Labels: map[string]string{kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: name}})
+		canaryDep       = utilstest.NewDeployment(name+"-kanary-"+name, namespace, 1, nil)
+		goodQuery       = `sum(increase(requests_total{pod=~"{{.Pods}}",code!~"5.."}[{{.Window}}]))`
+		totalQuery      = `sum(increase(requests_total{pod=~"{{.Pods}}"}[{{.Window}}]))`
+		windows         = []kanaryv1alpha1.SLOBurnRateWindow{
+			{LongWindow: metav1.Duration{Duration: time.Hour}, ShortWindow: metav1.Duration{Duration: 5 * time.Minute}, BurnRateFactor: kanaryv1alpha1.NewFloat64(14.4)},
+		}
+	)
+	good := func(window string) string {
+		return `sum(increase(requests_total{pod=~"foo-kanary",code!~"5.."}[` + window + `]))`
+	}
+	total := func(window string) string {
+		return `sum(increase(requests_total{pod=~"foo-kanary"}[` + window + `]))`
+	}
+
+	tests := []struct {
+		name      string
+		values    map[string]model.Vector
+		status    int
+		configure func(*kanaryv1alpha1.KanaryDeploymentSpecValidationSLO)
+		want      *Result
+		wantErr   bool
+	}{
+		{
+			name:   "within budget",
+			values: map[string]model.Vector{good("1h"): newPromVector(nil, 9995), total("1h"): newPromVector(nil, 10000), good("5m"): newPromVector(nil, 999), total("5m"): newPromVector(nil, 1000)},
+			status: http.StatusOK,
+			want: &Result{
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Window: "1h/5m", Value: "0.50/1.00"}},
+			},
+		},
+		{
+			name:   "burning the budget over the long window only",
+			values: map[string]model.Vector{good("1h"): newPromVector(nil, 9800), total("1h"): newPromVector(nil, 10000), good("5m"): newPromVector(nil, 999), total("5m"): newPromVector(nil, 1000)},
+			status: http.StatusOK,
+			want: &Result{
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Window: "1h/5m", Value: "20.00/1.00"}},
+			},
+		},
+		{
+			name:   "burning the budget over both windows",
+			values: map[string]model.Vector{good("1h"): newPromVector(nil, 9800), total("1h"): newPromVector(nil, 10000), good("5m"): newPromVector(nil, 970), total("5m"): newPromVector(nil, 1000)},
+			status: http.StatusOK,
+			want: &Result{
+				IsFailed:     true,
+				Comment:      "SLO error budget burn rate above the factor: 20.00/30.00 over 1h/5m",
+				Measurements: []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Window: "1h/5m", Value: "20.00/30.00", Failed: true}},
+			},
+		},
+		{
+			name:   "no event",
+			values: map[string]model.Vector{},
+			status: http.StatusOK,
+			want: &Result{
+				InsufficientData: "SLO total events query returned no event over: 1h/5m",
+				Measurements:     []kanaryv1alpha1.KanaryDeploymentMeasuredValue{{Window: "1h/5m", Value: "0.00/0.00"}},
+			},
+		},
+		{
+			name:    "prometheus error",
+			status:  http.StatusInternalServerError,
+			want:    &Result{},
+			wantErr: true,
+		},
+		{
+			name:      "objective not defined",
+			status:    http.StatusOK,
+			configure: func(slo *kanaryv1alpha1.KanaryDeploymentSpecValidationSLO) { slo.Objective = nil },
+			want:      &Result{},
+			wantErr:   true,
+		},
+		{
+			name:   "burn rate factor not defined",
+			status: http.StatusOK,
+			configure: func(slo *kanaryv1alpha1.KanaryDeploymentSpecValidationSLO) {
+				slo.Windows = []kanaryv1alpha1.SLOBurnRateWindow{{LongWindow: metav1.Duration{Duration: time.Hour}, ShortWindow: metav1.Duration{Duration: 5 * time.Minute}}}
+			},
+			want:    &Result{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqLogger := log.WithValues("test:", tt.name)
+			server := newPrometheusStandIn(t, tt.values, tt.status)
+			defer server.Close()
+
+			slo := &kanaryv1alpha1.KanaryDeploymentSpecValidationSLO{
+				PrometheusService: strings.TrimPrefix(server.URL, "http://"),
+				GoodEventsQuery:   goodQuery,
+				TotalEventsQuery:  totalQuery,
+				Objective:         kanaryv1alpha1.NewFloat64(99.9),
+				Windows:           windows,
+			}
+			if tt.configure != nil {
+				tt.configure(slo)
+			}
+			v := NewSLO(&kanaryv1alpha1.KanaryDeploymentSpecValidationList{}, &kanaryv1alpha1.KanaryDeploymentSpecValidation{SLO: slo})
+			kclient := fake.NewFakeClient([]runtime.Object{canaryPod}...)
+			kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, nil)
+
+			got, err := v.Validation(context.Background(), kclient, reqLogger, kd, nil, canaryDep)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sloImpl.Validation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sloImpl.Validation() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,24 @@
 package validation
 
+import (
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
 //Result returns result of a Validation
 type Result struct {
 	IsFailed        bool
 	ForceSuccessNow bool
 	Comment         string
+	// InsufficientData warns that the kanary did not get enough data to be judged
+	InsufficientData string
+	// ExtendValidation requests to extend the validation period until the kanary gets enough data
+	ExtendValidation bool
+	// Measurements values measured for each kanary pod
+	Measurements []kanaryv1alpha1.KanaryDeploymentMeasuredValue
+	// TimedOut the validation item evaluation did not complete before its timeout
+	TimedOut bool
+	// Inconclusive the validation item could not be evaluated because of provider errors, it neither fails nor validates the kanary
+	Inconclusive string
+	// ExcludedPods kanary pods left out of the analysis by the exclusion rules
+	ExcludedPods []kanaryv1alpha1.KanaryDeploymentStatusExcludedPod
 }
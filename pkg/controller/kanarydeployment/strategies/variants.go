@@ -0,0 +1,156 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+// evaluateVariants returns the verdict of each candidate, the template first: the template verdict comes from the validation results
+// of the canary Deployment, and the validation items are evaluated against the canary Deployment of each variant.
+func (s *strategy) evaluateVariants(kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep *appsv1.Deployment, templateResults []*validation.Result, validationDone bool) []kanaryv1alpha1.KanaryDeploymentStatusVariant {
+	variants := []kanaryv1alpha1.KanaryDeploymentStatusVariant{
+		s.newVariantVerdict(kanaryv1alpha1.TemplateVariantName, getVariantStatus(&kd.Status, kanaryv1alpha1.TemplateVariantName), templateResults, validationDone),
+	}
+	for _, variant := range kd.Spec.Variants {
+		previous := getVariantStatus(&kd.Status, variant.Name)
+		if previous != nil && previous.Verdict == kanaryv1alpha1.FailedVariantVerdict {
+			variants = append(variants, *previous)
+			continue
+		}
+
+		variantDep := &appsv1.Deployment{}
+		err := kclient.Get(context.TODO(), client.ObjectKey{Name: utils.GetVariantDeploymentName(kd, variant.Name), Namespace: kd.Namespace}, variantDep)
+		if err != nil {
+			reqLogger.Error(err, "failed to get the variant Deployment", "variant", variant.Name)
+			message := fmt.Sprintf("variant deployment not available: %v", err)
+			results := []*validation.Result{{Comment: message, Inconclusive: message}}
+			variants = append(variants, s.newVariantVerdict(variant.Name, previous, results, validationDone))
+			continue
+		}
+
+		results, itemErrs := s.runValidations(kclient, reqLogger, kd, dep, variantDep)
+		for i, err := range itemErrs {
+			if err != nil {
+				// a provider error makes the validation item inconclusive for the variant
+				reqLogger.Error(err, "Variant validation error", "variant", variant.Name, "validation", utils.GetValidationItemName(&s.validationsItems[i]))
+				message := fmt.Sprintf("%s validation error: %v", utils.GetValidationItemName(&s.validationsItems[i]), err)
+				results[i] = &validation.Result{Comment: message, Inconclusive: message}
+			}
+		}
+		variants = append(variants, s.newVariantVerdict(variant.Name, previous, results, validationDone))
+	}
+	return variants
+}
+
+// newVariantVerdict returns the verdict of a candidate from its validation results. A failed candidate stays failed,
+// and a candidate is judged only at the end of the validation period.
+func (s *strategy) newVariantVerdict(name string, previous *kanaryv1alpha1.KanaryDeploymentStatusVariant, results []*validation.Result, validationDone bool) kanaryv1alpha1.KanaryDeploymentStatusVariant {
+	if previous != nil && previous.Verdict == kanaryv1alpha1.FailedVariantVerdict {
+		return *previous
+	}
+	variant := kanaryv1alpha1.KanaryDeploymentStatusVariant{Name: name, Verdict: kanaryv1alpha1.RunningVariantVerdict}
+	failMessages, _ := computeStatus(results)
+	if s.scoring != nil {
+		score := computeScore(s.scoring, s.validationsItems, results)
+		variant.Score = &score.Score
+		failMessages = scoreFailMessages(s.scoring, score, validationDone)
+	}
+
+	switch {
+	case failMessages != "":
+		variant.Verdict = kanaryv1alpha1.FailedVariantVerdict
+		variant.Message = failMessages
+	case !validationDone:
+	case computeInconclusive(results) != "":
+		variant.Verdict = kanaryv1alpha1.InconclusiveVariantVerdict
+		variant.Message = computeInconclusive(results)
+	default:
+		variant.Verdict = kanaryv1alpha1.PassedVariantVerdict
+	}
+	return variant
+}
+
+// variantsFailMessages returns the failure messages of the candidates, or an empty string while a candidate can still be promoted
+func variantsFailMessages(variants []kanaryv1alpha1.KanaryDeploymentStatusVariant, validationDone bool) string {
+	comments := []string{}
+	for _, variant := range variants {
+		if isVariantEligible(&variant, validationDone) {
+			return ""
+		}
+		message := variant.Message
+		if message == "" {
+			message = string(variant.Verdict)
+		}
+		comments = append(comments, fmt.Sprintf("%s: %s", variant.Name, message))
+	}
+	return fmt.Sprintf("no candidate passed the validation, %s", strings.Join(comments, ","))
+}
+
+// getBestVariant returns the name of the candidate to promote: the eligible candidate with the best score, the template first
+// in case of equality, or an empty string if no candidate is eligible
+func getBestVariant(variants []kanaryv1alpha1.KanaryDeploymentStatusVariant, validationDone bool) string {
+	var best *kanaryv1alpha1.KanaryDeploymentStatusVariant
+	for i := range variants {
+		variant := &variants[i]
+		if !isVariantEligible(variant, validationDone) {
+			continue
+		}
+		if best == nil || getVariantScore(variant) > getVariantScore(best) {
+			best = variant
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.Name
+}
+
+// isVariantEligible returns true if the candidate can be promoted: not failed, and passed once the validation is done
+func isVariantEligible(variant *kanaryv1alpha1.KanaryDeploymentStatusVariant, validationDone bool) bool {
+	if validationDone {
+		return variant.Verdict == kanaryv1alpha1.PassedVariantVerdict
+	}
+	return variant.Verdict != kanaryv1alpha1.FailedVariantVerdict
+}
+
+func getVariantScore(variant *kanaryv1alpha1.KanaryDeploymentStatusVariant) int32 {
+	if variant.Score == nil {
+		return 0
+	}
+	return *variant.Score
+}
+
+func getVariantStatus(status *kanaryv1alpha1.KanaryDeploymentStatus, name string) *kanaryv1alpha1.KanaryDeploymentStatusVariant {
+	for i := range status.Variants {
+		if status.Variants[i].Name == name {
+			return &status.Variants[i]
+		}
+	}
+	return nil
+}
+
+// getPromotedKanaryDeployment returns the KanaryDeployment with the template of the promoted variant, if a variant was selected
+func getPromotedKanaryDeployment(kd *kanaryv1alpha1.KanaryDeployment) *kanaryv1alpha1.KanaryDeployment {
+	if kd.Status.PromotedVariant == "" || kd.Status.PromotedVariant == kanaryv1alpha1.TemplateVariantName {
+		return kd
+	}
+	for _, variant := range kd.Spec.Variants {
+		if variant.Name == kd.Status.PromotedVariant {
+			promoted := kd.DeepCopy()
+			promoted.Spec.Template = variant.Template
+			return promoted
+		}
+	}
+	return kd
+}
@@ -0,0 +1,135 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/strategies/validation"
+)
+
+// targetValidation validation failing for the listed canary Deployments
+type targetValidation struct {
+	failed map[string]bool
+}
+
+func (v *targetValidation) Validation(ctx context.Context, kclient client.Client, reqLogger logr.Logger, kd *kanaryv1alpha1.KanaryDeployment, dep, canaryDep *appsv1.Deployment) (*validation.Result, error) {
+	if v.failed[canaryDep.Name] {
+		return &validation.Result{IsFailed: true, Comment: "error rate too high"}, nil
+	}
+	return &validation.Result{}, nil
+}
+
+func Test_strategy_evaluateVariants(t *testing.T) {
+	logf.SetLogger(logf.ZapLogger(true))
+	reqLogger := logf.Log.WithName("Test_strategy_evaluateVariants")
+
+	newDeployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	promQL := kanaryv1alpha1.KanaryDeploymentSpecValidation{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}}
+	kd := &kanaryv1alpha1.KanaryDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+			Variants: []kanaryv1alpha1.KanaryDeploymentSpecVariant{{Name: "gc1"}, {Name: "gc2"}, {Name: "gc3"}, {Name: "gc4"}},
+		},
+		Status: kanaryv1alpha1.KanaryDeploymentStatus{
+			Variants: []kanaryv1alpha1.KanaryDeploymentStatusVariant{
+				{Name: "gc4", Verdict: kanaryv1alpha1.FailedVariantVerdict, Message: "latency too high"},
+			},
+		},
+	}
+	kclient := fake.NewFakeClient(newDeployment("foo-kanary-foo-gc1"), newDeployment("foo-kanary-foo-gc2"), newDeployment("foo-kanary-foo-gc4"))
+	s := &strategy{
+		validations:      []validation.Interface{&targetValidation{failed: map[string]bool{"foo-kanary-foo-gc2": true}}},
+		validationsItems: []kanaryv1alpha1.KanaryDeploymentSpecValidation{promQL},
+	}
+
+	got := s.evaluateVariants(kclient, reqLogger, kd, newDeployment("foo"), []*validation.Result{{}}, true)
+	want := []kanaryv1alpha1.KanaryDeploymentStatusVariant{
+		{Name: kanaryv1alpha1.TemplateVariantName, Verdict: kanaryv1alpha1.PassedVariantVerdict},
+		{Name: "gc1", Verdict: kanaryv1alpha1.PassedVariantVerdict},
+		{Name: "gc2", Verdict: kanaryv1alpha1.FailedVariantVerdict, Message: "error rate too high"},
+		{Name: "gc3", Verdict: kanaryv1alpha1.InconclusiveVariantVerdict},
+		{Name: "gc4", Verdict: kanaryv1alpha1.FailedVariantVerdict, Message: "latency too high"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("strategy.evaluateVariants() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || got[i].Verdict != want[i].Verdict {
+			t.Errorf("strategy.evaluateVariants()[%d] = %s/%s, want %s/%s", i, got[i].Name, got[i].Verdict, want[i].Name, want[i].Verdict)
+		}
+		if want[i].Message != "" && got[i].Message != want[i].Message {
+			t.Errorf("strategy.evaluateVariants()[%d] message = %q, want %q", i, got[i].Message, want[i].Message)
+		}
+	}
+}
+
+func Test_getBestVariant(t *testing.T) {
+	score := func(value int32) *int32 { return &value }
+	tests := []struct {
+		name               string
+		variants           []kanaryv1alpha1.KanaryDeploymentStatusVariant
+		validationDone     bool
+		want               string
+		wantFailedMessages bool
+	}{
+		{
+			name: "best score",
+			variants: []kanaryv1alpha1.KanaryDeploymentStatusVariant{
+				{Name: "template", Verdict: kanaryv1alpha1.PassedVariantVerdict, Score: score(80)},
+				{Name: "gc1", Verdict: kanaryv1alpha1.PassedVariantVerdict, Score: score(100)},
+				{Name: "gc2", Verdict: kanaryv1alpha1.FailedVariantVerdict, Score: score(100)},
+			},
+			validationDone: true,
+			want:           "gc1",
+		},
+		{
+			name: "template first on equality",
+			variants: []kanaryv1alpha1.KanaryDeploymentStatusVariant{
+				{Name: "template", Verdict: kanaryv1alpha1.PassedVariantVerdict},
+				{Name: "gc1", Verdict: kanaryv1alpha1.PassedVariantVerdict},
+			},
+			validationDone: true,
+			want:           "template",
+		},
+		{
+			name: "running candidate during the validation",
+			variants: []kanaryv1alpha1.KanaryDeploymentStatusVariant{
+				{Name: "template", Verdict: kanaryv1alpha1.FailedVariantVerdict},
+				{Name: "gc1", Verdict: kanaryv1alpha1.RunningVariantVerdict},
+			},
+			want: "gc1",
+		},
+		{
+			name: "no candidate passed",
+			variants: []kanaryv1alpha1.KanaryDeploymentStatusVariant{
+				{Name: "template", Verdict: kanaryv1alpha1.FailedVariantVerdict, Message: "error rate too high"},
+				{Name: "gc1", Verdict: kanaryv1alpha1.InconclusiveVariantVerdict},
+			},
+			validationDone:     true,
+			want:               "",
+			wantFailedMessages: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getBestVariant(tt.variants, tt.validationDone); got != tt.want {
+				t.Errorf("getBestVariant() = %q, want %q", got, tt.want)
+			}
+			if got := variantsFailMessages(tt.variants, tt.validationDone); (got != "") != tt.wantFailedMessages {
+				t.Errorf("variantsFailMessages() = %q, want failure messages %v", got, tt.wantFailedMessages)
+			}
+		})
+	}
+}
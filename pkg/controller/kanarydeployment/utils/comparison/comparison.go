@@ -8,7 +8,7 @@ import (
 	"fmt"
 	"io"
 
-	apps "k8s.io/api/apps/v1beta1"
+	apps "k8s.io/api/apps/v1"
 
 	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 )
@@ -34,7 +34,31 @@ func CompareDeploymentMD5Hash(hash string, dep *apps.Deployment) bool {
 
 // GenerateMD5DeploymentSpec used to generate the DeploymentSpec MD5 hash
 func GenerateMD5DeploymentSpec(spec *apps.DeploymentSpec) (string, error) {
-	b, err := json.Marshal(spec)
+	return generateMD5(spec)
+}
+
+// GenerateMD5KanaryDeploymentRun used to generate the MD5 hash of the templates of a KanaryDeployment run: the deployment template
+// and the variants templates. Without variants, it is the DeploymentSpec MD5 hash.
+func GenerateMD5KanaryDeploymentRun(spec *kanaryv1alpha1.KanaryDeploymentSpec) (string, error) {
+	if len(spec.Variants) == 0 {
+		return GenerateMD5DeploymentSpec(&spec.Template.Spec)
+	}
+	return generateMD5(struct {
+		Template apps.DeploymentSpec                          `json:"template"`
+		Variants []kanaryv1alpha1.KanaryDeploymentSpecVariant `json:"variants"`
+	}{
+		Template: spec.Template.Spec,
+		Variants: spec.Variants,
+	})
+}
+
+// GenerateMD5KanaryDeploymentPatch used to generate the KanaryDeploymentSpecPatch MD5 hash
+func GenerateMD5KanaryDeploymentPatch(patch *kanaryv1alpha1.KanaryDeploymentSpecPatch) (string, error) {
+	return generateMD5(patch)
+}
+
+func generateMD5(obj interface{}) (string, error) {
+	b, err := json.Marshal(obj)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,49 @@
+package comparison
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+func TestGenerateMD5KanaryDeploymentRun(t *testing.T) {
+	newSpec := func(image string, variantImages ...string) *kanaryv1alpha1.KanaryDeploymentSpec {
+		spec := &kanaryv1alpha1.KanaryDeploymentSpec{}
+		spec.Template.Spec.Template.Spec.Containers = []corev1.Container{{Name: "foo", Image: image}}
+		for _, variantImage := range variantImages {
+			variant := kanaryv1alpha1.KanaryDeploymentSpecVariant{Name: "bar"}
+			variant.Template.Spec.Template.Spec.Containers = []corev1.Container{{Name: "foo", Image: variantImage}}
+			spec.Variants = append(spec.Variants, variant)
+		}
+		return spec
+	}
+	hash := func(spec *kanaryv1alpha1.KanaryDeploymentSpec) string {
+		h, err := GenerateMD5KanaryDeploymentRun(spec)
+		if err != nil {
+			t.Fatalf("GenerateMD5KanaryDeploymentRun() unexpected error: %v", err)
+		}
+		return h
+	}
+
+	spec := newSpec("foo:2")
+	templateHash, err := GenerateMD5DeploymentSpec(&spec.Template.Spec)
+	if err != nil {
+		t.Fatalf("GenerateMD5DeploymentSpec() unexpected error: %v", err)
+	}
+	if got := hash(spec); got != templateHash {
+		t.Errorf("GenerateMD5KanaryDeploymentRun() without variants = %s, want the template hash %s", got, templateHash)
+	}
+
+	withVariant := hash(newSpec("foo:2", "foo:3"))
+	if withVariant == templateHash {
+		t.Errorf("GenerateMD5KanaryDeploymentRun() ignored the variants")
+	}
+	if got := hash(newSpec("foo:2", "foo:4")); got == withVariant {
+		t.Errorf("GenerateMD5KanaryDeploymentRun() ignored the variant template update")
+	}
+	if got := hash(newSpec("foo:2", "foo:3")); got != withVariant {
+		t.Errorf("GenerateMD5KanaryDeploymentRun() = %s, want the same hash %s", got, withVariant)
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+// NewTemplateFromKanaryDeploymentPatch returns the KanaryDeployment template derived from the main Deployment: the spec patch is applied
+// to the Deployment spec, then the container images are set. The template keeps the Deployment labels and annotations.
+func NewTemplateFromKanaryDeploymentPatch(patch *kanaryv1alpha1.KanaryDeploymentSpecPatch, dep *appsv1.Deployment) (*kanaryv1alpha1.DeploymentTemplate, error) {
+	spec := dep.Spec.DeepCopy()
+	if patch.Spec != nil && len(patch.Spec.Raw) > 0 {
+		original, err := json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+		var patched []byte
+		switch patch.Type {
+		case kanaryv1alpha1.JSONKanaryDeploymentSpecPatchType:
+			jsonPatch, err2 := jsonpatch.DecodePatch(patch.Spec.Raw)
+			if err2 != nil {
+				return nil, fmt.Errorf("unable to decode the json patch, %v", err2)
+			}
+			patched, err = jsonPatch.Apply(original)
+		default:
+			patched, err = strategicpatch.StrategicMergePatch(original, patch.Spec.Raw, appsv1.DeploymentSpec{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to apply the %s patch, %v", patch.Type, err)
+		}
+		spec = &appsv1.DeploymentSpec{}
+		if err = json.Unmarshal(patched, spec); err != nil {
+			return nil, fmt.Errorf("unable to decode the patched Deployment spec, %v", err)
+		}
+	}
+
+	for name, image := range patch.Images {
+		container := getContainer(&spec.Template.Spec, name)
+		if container == nil {
+			return nil, fmt.Errorf("container %s not found in the Deployment %s", name, dep.Name)
+		}
+		container.Image = image
+	}
+
+	template := &kanaryv1alpha1.DeploymentTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dep.Name,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *spec,
+	}
+	for k, v := range dep.Labels {
+		template.Labels[k] = v
+	}
+	for k, v := range dep.Annotations {
+		if k == string(kanaryv1alpha1.MD5KanaryDeploymentAnnotationKey) {
+			continue // set on the Deployments created from the template
+		}
+		template.Annotations[k] = v
+	}
+	return template, nil
+}
+
+func getContainer(spec *corev1.PodSpec, name string) *corev1.Container {
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == name {
+			return &spec.Containers[i]
+		}
+	}
+	for i := range spec.InitContainers {
+		if spec.InitContainers[i].Name == name {
+			return &spec.InitContainers[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+func TestNewTemplateFromKanaryDeploymentPatch(t *testing.T) {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Labels:      map[string]string{"app": "foo"},
+			Annotations: map[string]string{"team": "bar", string(kanaryv1alpha1.MD5KanaryDeploymentAnnotationKey): "hash"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: kanaryv1alpha1.NewInt32(3),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "foo", Image: "foo:1"},
+					{Name: "sidecar", Image: "sidecar:1"},
+				}},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		patch      *kanaryv1alpha1.KanaryDeploymentSpecPatch
+		wantImages []string
+		wantErr    bool
+	}{
+		{
+			name: "images",
+			patch: &kanaryv1alpha1.KanaryDeploymentSpecPatch{
+				Images: map[string]string{"foo": "foo:2"},
+			},
+			wantImages: []string{"foo:2", "sidecar:1"},
+		},
+		{
+			name: "strategic merge patch",
+			patch: &kanaryv1alpha1.KanaryDeploymentSpecPatch{
+				Type: kanaryv1alpha1.StrategicMergeKanaryDeploymentSpecPatchType,
+				Spec: &runtime.RawExtension{Raw: []byte(`{"template":{"spec":{"containers":[{"name":"sidecar","image":"sidecar:2"}]}}}`)},
+			},
+			wantImages: []string{"foo:1", "sidecar:2"},
+		},
+		{
+			name: "json patch then images",
+			patch: &kanaryv1alpha1.KanaryDeploymentSpecPatch{
+				Type:   kanaryv1alpha1.JSONKanaryDeploymentSpecPatchType,
+				Spec:   &runtime.RawExtension{Raw: []byte(`[{"op":"replace","path":"/template/spec/containers/1/image","value":"sidecar:2"}]`)},
+				Images: map[string]string{"foo": "foo:2"},
+			},
+			wantImages: []string{"foo:2", "sidecar:2"},
+		},
+		{
+			name: "invalid json patch",
+			patch: &kanaryv1alpha1.KanaryDeploymentSpecPatch{
+				Type: kanaryv1alpha1.JSONKanaryDeploymentSpecPatchType,
+				Spec: &runtime.RawExtension{Raw: []byte(`[{"op":"replace","path":"/template/spec/containers/5/image","value":"foo:2"}]`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown container",
+			patch: &kanaryv1alpha1.KanaryDeploymentSpecPatch{
+				Images: map[string]string{"bar": "bar:2"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTemplateFromKanaryDeploymentPatch(tt.patch, dep)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTemplateFromKanaryDeploymentPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Spec.Template.Spec.Containers) != len(tt.wantImages) {
+				t.Fatalf("NewTemplateFromKanaryDeploymentPatch() containers = %v, want images %v", got.Spec.Template.Spec.Containers, tt.wantImages)
+			}
+			for i, image := range tt.wantImages {
+				if got.Spec.Template.Spec.Containers[i].Image != image {
+					t.Errorf("NewTemplateFromKanaryDeploymentPatch() container %d image = %s, want %s", i, got.Spec.Template.Spec.Containers[i].Image, image)
+				}
+			}
+			if *got.Spec.Replicas != 3 || got.Spec.Selector.MatchLabels["app"] != "foo" {
+				t.Errorf("NewTemplateFromKanaryDeploymentPatch() spec = %v, want the Deployment replicas and selector", got.Spec)
+			}
+			if got.Name != "foo" || got.Labels["app"] != "foo" || got.Annotations["team"] != "bar" {
+				t.Errorf("NewTemplateFromKanaryDeploymentPatch() metadata = %v, want the Deployment name, labels and annotations", got.ObjectMeta)
+			}
+			if _, ok := got.Annotations[string(kanaryv1alpha1.MD5KanaryDeploymentAnnotationKey)]; ok {
+				t.Errorf("NewTemplateFromKanaryDeploymentPatch() kept the md5 annotation")
+			}
+			if dep.Spec.Template.Spec.Containers[0].Image != "foo:1" || dep.Spec.Template.Spec.Containers[1].Image != "sidecar:1" {
+				t.Errorf("NewTemplateFromKanaryDeploymentPatch() modified the Deployment")
+			}
+		})
+	}
+}
@@ -2,13 +2,15 @@ package utils
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -77,14 +79,14 @@ func GetCanaryServiceName(kd *kanaryv1alpha1.KanaryDeployment) string {
 }
 
 // NewDeploymentFromKanaryDeploymentTemplate returns a Deployment object
-func NewDeploymentFromKanaryDeploymentTemplate(kdold *kanaryv1alpha1.KanaryDeployment, scheme *runtime.Scheme, setOwnerRef bool) (*appsv1beta1.Deployment, error) {
+func NewDeploymentFromKanaryDeploymentTemplate(kdold *kanaryv1alpha1.KanaryDeployment, scheme *runtime.Scheme, setOwnerRef bool) (*appsv1.Deployment, error) {
 	kd := kdold.DeepCopy()
 	ls := GetLabelsForKanaryDeploymentd(kd.Name)
 
-	dep := &appsv1beta1.Deployment{
+	dep := &appsv1.Deployment{
 		TypeMeta:   kd.Spec.Template.TypeMeta,
 		ObjectMeta: kd.Spec.Template.ObjectMeta,
-		Spec:       kd.Spec.Template.Spec,
+		Spec:       *kanaryv1alpha1.ConvertV1beta1DeploymentSpec(&kd.Spec.Template.Spec),
 	}
 
 	if dep.Labels == nil {
@@ -114,7 +116,7 @@ func NewDeploymentFromKanaryDeploymentTemplate(kdold *kanaryv1alpha1.KanaryDeplo
 }
 
 // NewCanaryDeploymentFromKanaryDeploymentTemplate returns a Deployment object
-func NewCanaryDeploymentFromKanaryDeploymentTemplate(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment, scheme *runtime.Scheme, setOwnerRef bool) (*appsv1beta1.Deployment, error) {
+func NewCanaryDeploymentFromKanaryDeploymentTemplate(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment, scheme *runtime.Scheme, setOwnerRef bool) (*appsv1.Deployment, error) {
 	dep, err := NewDeploymentFromKanaryDeploymentTemplate(kd, scheme, true)
 	if err != nil {
 		return nil, err
@@ -145,13 +147,89 @@ func NewCanaryDeploymentFromKanaryDeploymentTemplate(kclient client.Client, kd *
 	return dep, nil
 }
 
+// NewCanaryDaemonSetFromKanaryDeploymentTemplate returns the canary DaemonSet object: it runs the pod template of the KanaryDeployment
+// template only on the canary nodes. Its pods are labelled like the canary Deployment pods, without the DaemonSet selector labels.
+func NewCanaryDaemonSetFromKanaryDeploymentTemplate(kd *kanaryv1alpha1.KanaryDeployment, ds *appsv1.DaemonSet, scheme *runtime.Scheme, setOwnerRef bool) (*appsv1.DaemonSet, error) {
+	template := kd.Spec.Template.Spec.Template.DeepCopy()
+	labels := GetLabelsForKanaryPod(kd.Name)
+	for k, v := range template.Labels {
+		if ds.Spec.Selector != nil {
+			if _, ok := ds.Spec.Selector.MatchLabels[k]; ok {
+				continue // don't add this label that would select the pod in the DaemonSet
+			}
+		}
+		labels[k] = v
+	}
+	template.Labels = labels
+	if template.Spec.NodeSelector == nil {
+		template.Spec.NodeSelector = map[string]string{}
+	}
+	template.Spec.NodeSelector[kanaryv1alpha1.KanaryDeploymentCanaryNodeLabelKey] = GetCanaryNodeLabelValue(kd)
+
+	canary := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetCanaryDaemonSetName(kd),
+			Namespace: kd.Namespace,
+			Labels:    GetLabelsForKanaryDeploymentd(kd.Name),
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: GetLabelsForKanaryPod(kd.Name)},
+			Template:       *template,
+			UpdateStrategy: ds.Spec.UpdateStrategy,
+		},
+	}
+	if setOwnerRef {
+		if err := controllerutil.SetControllerReference(kd, canary, scheme); err != nil {
+			return nil, err
+		}
+	}
+	return canary, nil
+}
+
+// NewVariantDeploymentFromKanaryDeploymentTemplate returns the canary Deployment object of a variant. Its pods are only labelled with
+// the KanaryDeployment name and the variant name, to not be selected by the canary Deployment and the kanary service.
+func NewVariantDeploymentFromKanaryDeploymentTemplate(kclient client.Client, kd *kanaryv1alpha1.KanaryDeployment, variant *kanaryv1alpha1.KanaryDeploymentSpecVariant, scheme *runtime.Scheme) (*appsv1.Deployment, error) {
+	kdVariant := kd.DeepCopy()
+	kdVariant.Spec.DeploymentName = GetDeploymentName(kd)
+	kdVariant.Spec.Template = variant.Template
+	dep, err := NewCanaryDeploymentFromKanaryDeploymentTemplate(kclient, kdVariant, scheme, false)
+	if err != nil {
+		return nil, err
+	}
+	dep.Name = GetVariantDeploymentName(kd, variant.Name)
+	dep.Labels[kanaryv1alpha1.KanaryDeploymentVariantLabelKey] = variant.Name
+
+	podLabels := map[string]string{}
+	for key, val := range dep.Spec.Template.Labels {
+		podLabels[key] = val
+	}
+	delete(podLabels, kanaryv1alpha1.KanaryDeploymentActivateLabelKey)
+	for key, val := range GetLabelsForVariantPod(kd.Name, variant.Name) {
+		podLabels[key] = val
+	}
+	dep.Spec.Template.Labels = podLabels
+	dep.Spec.Selector = &metav1.LabelSelector{MatchLabels: GetLabelsForVariantPod(kd.Name, variant.Name)}
+	return dep, nil
+}
+
+// NewVariantServiceForKanaryDeployment returns the kanary service object of a variant
+func NewVariantServiceForKanaryDeployment(kd *kanaryv1alpha1.KanaryDeployment, variantName string, service *corev1.Service, scheme *runtime.Scheme) (*corev1.Service, error) {
+	newService, err := NewCanaryServiceForKanaryDeployment(kd, service, false, scheme, true)
+	if err != nil {
+		return nil, err
+	}
+	newService.Name = GetVariantServiceName(kd, variantName)
+	newService.Spec.Selector = GetLabelsForVariantPod(kd.Name, variantName)
+	return newService, nil
+}
+
 // UpdateDeploymentWithKanaryDeploymentTemplate returns a Deployment object updated
-func UpdateDeploymentWithKanaryDeploymentTemplate(kd *kanaryv1alpha1.KanaryDeployment, oldDep *appsv1beta1.Deployment) (*appsv1beta1.Deployment, error) {
+func UpdateDeploymentWithKanaryDeploymentTemplate(kd *kanaryv1alpha1.KanaryDeployment, oldDep *appsv1.Deployment) (*appsv1.Deployment, error) {
 	newDep := oldDep.DeepCopy()
 	{
 		newDep.Labels = kd.Spec.Template.Labels
 		newDep.Annotations = kd.Spec.Template.Annotations
-		newDep.Spec = kd.Spec.Template.Spec
+		newDep.Spec = *kanaryv1alpha1.ConvertV1beta1DeploymentSpec(&kd.Spec.Template.Spec)
 	}
 
 	if _, err := comparison.SetMD5DeploymentSpecAnnotation(kd, newDep); err != nil {
@@ -177,6 +255,31 @@ func GetCanaryDeploymentName(kd *kanaryv1alpha1.KanaryDeployment) string {
 	return fmt.Sprintf("%s-kanary-%s", GetDeploymentName(kd), kd.Name)
 }
 
+// GetCanaryDaemonSetName returns the canary DaemonSet name from the KanaryDeployment instance
+func GetCanaryDaemonSetName(kd *kanaryv1alpha1.KanaryDeployment) string {
+	return fmt.Sprintf("%s-kanary-%s", kd.Spec.DaemonSet.Name, kd.Name)
+}
+
+// GetCanaryNodeLabelValue returns the value of the canary node label set by the KanaryDeployment: its namespace and name, or their md5
+// hash if they don't fit in a label value
+func GetCanaryNodeLabelValue(kd *kanaryv1alpha1.KanaryDeployment) string {
+	value := fmt.Sprintf("%s.%s", kd.Namespace, kd.Name)
+	if len(validation.IsValidLabelValue(value)) == 0 {
+		return value
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(value)))
+}
+
+// GetVariantDeploymentName returns the canary Deployment name of a variant
+func GetVariantDeploymentName(kd *kanaryv1alpha1.KanaryDeployment, variantName string) string {
+	return fmt.Sprintf("%s-%s", GetCanaryDeploymentName(kd), variantName)
+}
+
+// GetVariantServiceName returns the kanary service name of a variant
+func GetVariantServiceName(kd *kanaryv1alpha1.KanaryDeployment, variantName string) string {
+	return fmt.Sprintf("%s-%s", GetCanaryServiceName(kd), variantName)
+}
+
 // GetLabelsForKanaryDeploymentd return labels belonging to the given KanaryDeployment CR name.
 func GetLabelsForKanaryDeploymentd(name string) map[string]string {
 	return map[string]string{
@@ -193,11 +296,26 @@ func GetLabelsForKanaryPod(kdname string) map[string]string {
 	}
 }
 
+// GetLabelsForVariantPod return labels of a variant canary pod associated to a kanarydeployment.
+func GetLabelsForVariantPod(kdname, variantName string) map[string]string {
+	return map[string]string{
+		kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: kdname,
+		kanaryv1alpha1.KanaryDeploymentVariantLabelKey:    variantName,
+	}
+}
+
 // GetCanaryReplicasValue returns the replicas value of the Canary Deployment
 func GetCanaryReplicasValue(kd *kanaryv1alpha1.KanaryDeployment) *int32 {
 	var value *int32
 	if kd.Spec.Scale.Static != nil {
 		value = kd.Spec.Scale.Static.Replicas
 	}
+	if kd.Spec.BlueGreen != nil {
+		// in blue/green mode, the canary Deployment runs at full size
+		value = kanaryv1alpha1.NewInt32(1)
+		if kd.Spec.Template.Spec.Replicas != nil {
+			value = kd.Spec.Template.Spec.Replicas
+		}
+	}
 	return value
 }
@@ -7,6 +7,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
 	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
 	utilstest "github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils/test"
@@ -55,3 +57,30 @@ func TestNewCanaryServiceForKanaryDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestNewVariantDeploymentFromKanaryDeploymentTemplate(t *testing.T) {
+	namespace := "kanary"
+	name := "foo"
+	kd := kanaryv1alpha1test.NewKanaryDeployment(name, namespace, name, 3, nil)
+	variant := &kanaryv1alpha1.KanaryDeploymentSpecVariant{Name: "gc1", Template: kd.Spec.Template}
+
+	got, err := NewVariantDeploymentFromKanaryDeploymentTemplate(fake.NewFakeClient(), kd, variant, PrepareSchemeForOwnerRef())
+	if err != nil {
+		t.Fatalf("NewVariantDeploymentFromKanaryDeploymentTemplate() unexpected error: %v", err)
+	}
+	if got.Name != name+"-kanary-"+name+"-gc1" {
+		t.Errorf("NewVariantDeploymentFromKanaryDeploymentTemplate() name = %s, want %s", got.Name, name+"-kanary-"+name+"-gc1")
+	}
+	wantSelector := map[string]string{kanaryv1alpha1.KanaryDeploymentKanaryNameLabelKey: name, kanaryv1alpha1.KanaryDeploymentVariantLabelKey: "gc1"}
+	if !equality.Semantic.DeepEqual(got.Spec.Selector.MatchLabels, wantSelector) {
+		t.Errorf("NewVariantDeploymentFromKanaryDeploymentTemplate() selector = %v, want %v", got.Spec.Selector.MatchLabels, wantSelector)
+	}
+	if _, ok := got.Spec.Template.Labels[kanaryv1alpha1.KanaryDeploymentActivateLabelKey]; ok {
+		t.Errorf("NewVariantDeploymentFromKanaryDeploymentTemplate() pod labels = %v, should not be selected by the kanary service", got.Spec.Template.Labels)
+	}
+	for key, val := range wantSelector {
+		if got.Spec.Template.Labels[key] != val {
+			t.Errorf("NewVariantDeploymentFromKanaryDeploymentTemplate() pod labels = %v, should match the selector", got.Spec.Template.Labels)
+		}
+	}
+}
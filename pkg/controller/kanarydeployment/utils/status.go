@@ -36,6 +36,10 @@ func UpdateKanaryDeploymentStatus(kclient client.Client, subResourceDisabled boo
 	if !apiequality.Semantic.DeepEqual(&kd.Status, newStatus) {
 		updatedKd := kd.DeepCopy()
 		updatedKd.Status = *newStatus
+		if updatedKd.Spec.Patch != nil {
+			// the template resolved from the patch is only set in memory
+			updatedKd.Spec.Template = kanaryv1alpha1.DeploymentTemplate{}
+		}
 		err2 := kclientStatus.Update(context.TODO(), updatedKd)
 		if err2 != nil {
 			reqLogger.Error(err2, "failed to update KanaryDeployment status", "KanaryDeployment.Namespace", updatedKd.Namespace, "KanaryDeployment.Name", updatedKd.Name)
@@ -142,6 +146,62 @@ func IsKanaryDeploymentDeploymentUpdated(status *kanaryv1alpha1.KanaryDeployment
 	return false
 }
 
+// IsKanaryDeploymentRolledBack returns true if the Deployment was rolled back after a failed post-promotion verification
+func IsKanaryDeploymentRolledBack(status *kanaryv1alpha1.KanaryDeploymentStatus) bool {
+	if status == nil {
+		return false
+	}
+	id := getIndexForConditionType(status, kanaryv1alpha1.RolledBackKanaryDeploymentConditionType)
+	if id >= 0 && status.Conditions[id].Status == corev1.ConditionTrue {
+		return true
+	}
+	return false
+}
+
+// IsKanaryDeploymentServiceSwitched returns true if the service targets the canary pods in blue/green mode
+func IsKanaryDeploymentServiceSwitched(status *kanaryv1alpha1.KanaryDeploymentStatus) bool {
+	if status == nil {
+		return false
+	}
+	id := getIndexForConditionType(status, kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType)
+	if id >= 0 && status.Conditions[id].Status == corev1.ConditionTrue {
+		return true
+	}
+	return false
+}
+
+// IsKanaryDeploymentPromoting returns true if the progressive promotion of the succeeded KanaryDeployment is in progress
+func IsKanaryDeploymentPromoting(status *kanaryv1alpha1.KanaryDeploymentStatus) bool {
+	if status == nil || status.Promotion == nil {
+		return false
+	}
+	return IsKanaryDeploymentSucceeded(status) && !IsKanaryDeploymentFailed(status) && !IsKanaryDeploymentDeploymentUpdated(status)
+}
+
+// IsKanaryDeploymentPaused returns true if the KanaryDeployment validation is paused
+func IsKanaryDeploymentPaused(status *kanaryv1alpha1.KanaryDeploymentStatus) bool {
+	if status == nil || IsKanaryDeploymentValidationCompleted(status) {
+		return false
+	}
+	id := getIndexForConditionType(status, kanaryv1alpha1.PausedKanaryDeploymentConditionType)
+	if id >= 0 && status.Conditions[id].Status == corev1.ConditionTrue {
+		return true
+	}
+	return false
+}
+
+// IsKanaryDeploymentInconclusive returns true if the KanaryDeployment validation is running but inconclusive
+func IsKanaryDeploymentInconclusive(status *kanaryv1alpha1.KanaryDeploymentStatus) bool {
+	if !IsKanaryDeploymentValidationRunning(status) {
+		return false
+	}
+	id := getIndexForConditionType(status, kanaryv1alpha1.InconclusiveKanaryDeploymentConditionType)
+	if id >= 0 && status.Conditions[id].Status == corev1.ConditionTrue {
+		return true
+	}
+	return false
+}
+
 // IsKanaryDeploymentValidationRunning returns true if the KanaryDeployment is runnning
 func IsKanaryDeploymentValidationRunning(status *kanaryv1alpha1.KanaryDeploymentStatus) bool {
 	if status == nil {
@@ -181,14 +241,30 @@ func getReportStatus(status *kanaryv1alpha1.KanaryDeploymentStatus) string {
 		return string(v1alpha1.FailedKanaryDeploymentConditionType)
 	}
 
+	if IsKanaryDeploymentRolledBack(status) {
+		return string(v1alpha1.RolledBackKanaryDeploymentConditionType)
+	}
+
 	if IsKanaryDeploymentDeploymentUpdated(status) {
 		return string(v1alpha1.DeploymentUpdatedKanaryDeploymentConditionType)
 	}
 
+	if IsKanaryDeploymentServiceSwitched(status) {
+		return string(v1alpha1.ServiceSwitchedKanaryDeploymentConditionType)
+	}
+
 	if IsKanaryDeploymentSucceeded(status) {
 		return string(v1alpha1.SucceededKanaryDeploymentConditionType)
 	}
 
+	if IsKanaryDeploymentPaused(status) {
+		return string(v1alpha1.PausedKanaryDeploymentConditionType)
+	}
+
+	if IsKanaryDeploymentInconclusive(status) {
+		return string(v1alpha1.InconclusiveKanaryDeploymentConditionType)
+	}
+
 	if IsKanaryDeploymentValidationRunning(status) {
 		return string(v1alpha1.RunningKanaryDeploymentConditionType)
 	}
@@ -207,14 +283,8 @@ func getReportStatus(status *kanaryv1alpha1.KanaryDeploymentStatus) string {
 func getValidation(kd *kanaryv1alpha1.KanaryDeployment) string {
 	var list []string
 	for _, v := range kd.Spec.Validations.Items {
-		if v.LabelWatch != nil {
-			list = append(list, "labelWatch")
-		}
-		if v.PromQL != nil {
-			list = append(list, "promQL")
-		}
-		if v.Manual != nil {
-			list = append(list, "manual")
+		if name := GetValidationItemName(&v); name != "" {
+			list = append(list, name)
 		}
 	}
 	if len(list) == 0 {
@@ -223,6 +293,31 @@ func getValidation(kd *kanaryv1alpha1.KanaryDeployment) string {
 	return strings.Join(list, ",")
 }
 
+// GetValidationItemName returns the type name of a validation item
+func GetValidationItemName(v *kanaryv1alpha1.KanaryDeploymentSpecValidation) string {
+	switch {
+	case v.Manual != nil:
+		return "manual"
+	case v.LabelWatch != nil:
+		return "labelWatch"
+	case v.PromQL != nil:
+		return "promQL"
+	case v.PodHealth != nil:
+		return "podHealth"
+	case v.Logs != nil:
+		return "logs"
+	case v.Job != nil:
+		return "job"
+	case v.Alerts != nil:
+		return "alerts"
+	case v.External != nil:
+		return "external"
+	case v.SLO != nil:
+		return "slo"
+	}
+	return ""
+}
+
 func getScale(kd *kanaryv1alpha1.KanaryDeployment) string {
 	if kd.Spec.Scale.HPA == nil {
 		return "static"
@@ -240,5 +335,13 @@ func updateStatusReport(kd *kanaryv1alpha1.KanaryDeployment, status *kanaryv1alp
 		Validation: getValidation(kd),
 		Scale:      getScale(kd),
 		Traffic:    getTraffic(kd),
+		Rollout:    getRollout(status),
+	}
+}
+
+func getRollout(status *kanaryv1alpha1.KanaryDeploymentStatus) string {
+	if status.Rollout == nil {
+		return ""
 	}
+	return string(status.Rollout.Status)
 }
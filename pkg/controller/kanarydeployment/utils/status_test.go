@@ -190,6 +190,125 @@ func Test_updateStatusWithReport(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "inconclusive validation",
+			args: args{
+				kd: &kanaryv1alpha1.KanaryDeployment{
+					Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+						Traffic: kanaryv1alpha1.KanaryDeploymentSpecTraffic{
+							Mirror: &kanaryv1alpha1.KanaryDeploymentSpecTrafficMirror{},
+						},
+						Validations: kanaryv1alpha1.KanaryDeploymentSpecValidationList{
+							Items: []kanaryv1alpha1.KanaryDeploymentSpecValidation{
+								{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}},
+							},
+						},
+					},
+				},
+				status: &kanaryv1alpha1.KanaryDeploymentStatus{
+					Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.ScheduledKanaryDeploymentConditionType,
+						},
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.RunningKanaryDeploymentConditionType,
+						},
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.InconclusiveKanaryDeploymentConditionType,
+						},
+					},
+					Report: kanaryv1alpha1.KanaryDeploymentStatusReport{},
+				},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatus{
+				Report: kanaryv1alpha1.KanaryDeploymentStatusReport{
+					Status:     string(kanaryv1alpha1.InconclusiveKanaryDeploymentConditionType),
+					Scale:      "static",
+					Validation: "promQL",
+				},
+			},
+		},
+		{
+			name: "rolled back deployment",
+			args: args{
+				kd: &kanaryv1alpha1.KanaryDeployment{
+					Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+						Traffic: kanaryv1alpha1.KanaryDeploymentSpecTraffic{
+							Mirror: &kanaryv1alpha1.KanaryDeploymentSpecTrafficMirror{},
+						},
+						Validations: kanaryv1alpha1.KanaryDeploymentSpecValidationList{
+							Items: []kanaryv1alpha1.KanaryDeploymentSpecValidation{
+								{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}},
+							},
+						},
+					},
+				},
+				status: &kanaryv1alpha1.KanaryDeploymentStatus{
+					Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.SucceededKanaryDeploymentConditionType,
+						},
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.DeploymentUpdatedKanaryDeploymentConditionType,
+						},
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.RolledBackKanaryDeploymentConditionType,
+						},
+					},
+					Report: kanaryv1alpha1.KanaryDeploymentStatusReport{},
+				},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatus{
+				Report: kanaryv1alpha1.KanaryDeploymentStatusReport{
+					Status:     string(kanaryv1alpha1.RolledBackKanaryDeploymentConditionType),
+					Scale:      "static",
+					Validation: "promQL",
+				},
+			},
+		},
+		{
+			name: "service switched to the canary pods",
+			args: args{
+				kd: &kanaryv1alpha1.KanaryDeployment{
+					Spec: kanaryv1alpha1.KanaryDeploymentSpec{
+						Traffic: kanaryv1alpha1.KanaryDeploymentSpecTraffic{
+							Mirror: &kanaryv1alpha1.KanaryDeploymentSpecTrafficMirror{},
+						},
+						Validations: kanaryv1alpha1.KanaryDeploymentSpecValidationList{
+							Items: []kanaryv1alpha1.KanaryDeploymentSpecValidation{
+								{PromQL: &kanaryv1alpha1.KanaryDeploymentSpecValidationPromQL{}},
+							},
+						},
+					},
+				},
+				status: &kanaryv1alpha1.KanaryDeploymentStatus{
+					Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.SucceededKanaryDeploymentConditionType,
+						},
+						kanaryv1alpha1.KanaryDeploymentCondition{
+							Status: corev1.ConditionTrue,
+							Type:   kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType,
+						},
+					},
+					Report: kanaryv1alpha1.KanaryDeploymentStatusReport{},
+				},
+			},
+			want: &kanaryv1alpha1.KanaryDeploymentStatus{
+				Report: kanaryv1alpha1.KanaryDeploymentStatusReport{
+					Status:     string(kanaryv1alpha1.ServiceSwitchedKanaryDeploymentConditionType),
+					Scale:      "static",
+					Validation: "promQL",
+				},
+			},
+		},
 		{
 			name: "labelWatch validation",
 			args: args{
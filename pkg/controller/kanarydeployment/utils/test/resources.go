@@ -3,7 +3,7 @@ package utils_test
 import (
 	"fmt"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,18 +20,18 @@ type NewDeploymentOptions struct {
 }
 
 // NewDeployment returns new Deployment instance for testing purpose
-func NewDeployment(name, namespace string, replicas int32, options *NewDeploymentOptions) *appsv1beta1.Deployment {
-	spec := &appsv1beta1.DeploymentSpec{
+func NewDeployment(name, namespace string, replicas int32, options *NewDeploymentOptions) *appsv1.Deployment {
+	spec := &appsv1.DeploymentSpec{
 		Replicas: &replicas,
 	}
 	md5, err := comparison.GenerateMD5DeploymentSpec(spec)
 	if err != nil {
 		md5 = "fakeMd5"
 	}
-	newDep := &appsv1beta1.Deployment{
+	newDep := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Deployment",
-			APIVersion: appsv1beta1.SchemeGroupVersion.String(),
+			APIVersion: appsv1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
@@ -2,6 +2,10 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 )
@@ -13,6 +17,174 @@ func ValidateKanaryDeployment(kd *v1alpha1.KanaryDeployment) []error {
 	errs = append(errs, validateKanaryDeploymentSpecScale(&kd.Spec.Scale)...)
 	errs = append(errs, validateKanaryDeploymentSpecTraffic(&kd.Spec.Traffic)...)
 	errs = append(errs, validateKanaryDeploymentSpecValidationList(&kd.Spec.Validations)...)
+	if kd.Spec.Promotion != nil {
+		errs = append(errs, validateKanaryDeploymentSpecPromotion(kd.Spec.Promotion, &kd.Spec.Scale, &kd.Spec.Traffic)...)
+	}
+	if kd.Spec.BlueGreen != nil {
+		errs = append(errs, validateKanaryDeploymentSpecBlueGreen(&kd.Spec)...)
+	}
+	if len(kd.Spec.Variants) > 0 {
+		errs = append(errs, validateKanaryDeploymentSpecVariants(&kd.Spec)...)
+	}
+	if kd.Spec.StatefulSet != nil {
+		errs = append(errs, validateKanaryDeploymentSpecStatefulSet(&kd.Spec)...)
+	}
+	if kd.Spec.DaemonSet != nil {
+		errs = append(errs, validateKanaryDeploymentSpecDaemonSet(&kd.Spec)...)
+	}
+	if kd.Spec.Patch != nil {
+		errs = append(errs, validateKanaryDeploymentSpecPatch(&kd.Spec)...)
+	}
+	return errs
+}
+
+func validateKanaryDeploymentSpecStatefulSet(spec *v1alpha1.KanaryDeploymentSpec) []error {
+	var errs []error
+	if spec.StatefulSet.Name == "" {
+		errs = append(errs, fmt.Errorf("spec.statefulSet.name not defined"))
+	}
+	if spec.StatefulSet.CanaryReplicas != nil && *spec.StatefulSet.CanaryReplicas < 1 {
+		errs = append(errs, fmt.Errorf("spec.statefulSet.canaryReplicas bad value, should be positive, current value:%d", *spec.StatefulSet.CanaryReplicas))
+	}
+	if spec.Traffic.Source != v1alpha1.NoneKanaryDeploymentSpecTrafficSource {
+		errs = append(errs, fmt.Errorf("spec.statefulSet bad configuration, the StatefulSet canary requires the 'none' traffic source, current value:%s", spec.Traffic.Source))
+	}
+	if spec.Scale.HPA != nil || spec.Promotion != nil || spec.BlueGreen != nil || len(spec.Variants) > 0 || spec.Validations.PostPromotion != nil {
+		errs = append(errs, fmt.Errorf("spec.statefulSet bad configuration, the StatefulSet canary can not be combined with the HPA scale, the progressive promotion, the blue/green mode, the variants or the post-promotion verification"))
+	}
+	for _, v := range spec.Validations.Items {
+		if v.LabelWatch != nil || v.Logs != nil || v.Job != nil {
+			errs = append(errs, fmt.Errorf("spec.statefulSet bad configuration, only promQL, podHealth, alerts, slo, manual and external validations are supported"))
+			break
+		}
+	}
+	return errs
+}
+
+func validateKanaryDeploymentSpecPatch(spec *v1alpha1.KanaryDeploymentSpec) []error {
+	var errs []error
+	switch spec.Patch.Type {
+	case v1alpha1.StrategicMergeKanaryDeploymentSpecPatchType, v1alpha1.JSONKanaryDeploymentSpecPatchType:
+	default:
+		errs = append(errs, fmt.Errorf("spec.patch.type bad value, should be '%s' or '%s', current value:%s", v1alpha1.StrategicMergeKanaryDeploymentSpecPatchType, v1alpha1.JSONKanaryDeploymentSpecPatchType, spec.Patch.Type))
+	}
+	if (spec.Patch.Spec == nil || len(spec.Patch.Spec.Raw) == 0) && len(spec.Patch.Images) == 0 {
+		errs = append(errs, fmt.Errorf("spec.patch bad configuration, the spec or the images should be defined"))
+	}
+	if len(spec.Template.Spec.Template.Spec.Containers) > 0 {
+		errs = append(errs, fmt.Errorf("spec.patch bad configuration, the patch and the template can not be both defined"))
+	}
+	if spec.StatefulSet != nil || spec.DaemonSet != nil {
+		errs = append(errs, fmt.Errorf("spec.patch bad configuration, the patch can not be combined with the StatefulSet or the DaemonSet canary"))
+	}
+	return errs
+}
+
+func validateKanaryDeploymentSpecDaemonSet(spec *v1alpha1.KanaryDeploymentSpec) []error {
+	var errs []error
+	if spec.DaemonSet.Name == "" {
+		errs = append(errs, fmt.Errorf("spec.daemonSet.name not defined"))
+	}
+	if len(spec.DaemonSet.NodeSelector) > 0 && spec.DaemonSet.NodePercent != nil {
+		errs = append(errs, fmt.Errorf("spec.daemonSet bad configuration, only one of nodeSelector or nodePercent can be defined"))
+	}
+	if spec.DaemonSet.NodePercent != nil && (*spec.DaemonSet.NodePercent <= 0 || *spec.DaemonSet.NodePercent >= 100) {
+		errs = append(errs, fmt.Errorf("spec.daemonSet.nodePercent bad value, should be in ]0:100[, current value:%d", *spec.DaemonSet.NodePercent))
+	}
+	if spec.Traffic.Source != v1alpha1.NoneKanaryDeploymentSpecTrafficSource {
+		errs = append(errs, fmt.Errorf("spec.daemonSet bad configuration, the DaemonSet canary requires the 'none' traffic source, current value:%s", spec.Traffic.Source))
+	}
+	if spec.StatefulSet != nil || spec.Scale.HPA != nil || spec.Promotion != nil || spec.BlueGreen != nil || len(spec.Variants) > 0 || spec.Validations.PostPromotion != nil {
+		errs = append(errs, fmt.Errorf("spec.daemonSet bad configuration, the DaemonSet canary can not be combined with the StatefulSet canary, the HPA scale, the progressive promotion, the blue/green mode, the variants or the post-promotion verification"))
+	}
+	for _, v := range spec.Validations.Items {
+		if v.Job != nil {
+			errs = append(errs, fmt.Errorf("spec.daemonSet bad configuration, the job validation is not supported"))
+			break
+		}
+	}
+	return errs
+}
+
+var variantNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func validateKanaryDeploymentSpecVariants(spec *v1alpha1.KanaryDeploymentSpec) []error {
+	var errs []error
+	if spec.Traffic.Source != v1alpha1.KanaryServiceKanaryDeploymentSpecTrafficSource {
+		errs = append(errs, fmt.Errorf("spec.variants bad configuration, the variants require the 'kanary-service' traffic source, current value:%s", spec.Traffic.Source))
+	}
+	if spec.Scale.HPA != nil {
+		errs = append(errs, fmt.Errorf("spec.variants bad configuration, the variants require the static scale"))
+	}
+	if spec.Promotion != nil || spec.BlueGreen != nil {
+		errs = append(errs, fmt.Errorf("spec.variants bad configuration, the variants can not be combined with the progressive promotion or the blue/green mode"))
+	}
+	names := map[string]bool{v1alpha1.TemplateVariantName: true}
+	for _, variant := range spec.Variants {
+		if !variantNameRegexp.MatchString(variant.Name) {
+			errs = append(errs, fmt.Errorf("spec.variants.name bad value, should be a DNS label, current value:%s", variant.Name))
+		}
+		if names[variant.Name] {
+			errs = append(errs, fmt.Errorf("spec.variants.name bad value, should be unique and different from '%s', current value:%s", v1alpha1.TemplateVariantName, variant.Name))
+		}
+		names[variant.Name] = true
+	}
+	for _, v := range spec.Validations.Items {
+		if v.Manual != nil || v.LabelWatch != nil || v.Logs != nil || v.Job != nil || v.External != nil {
+			errs = append(errs, fmt.Errorf("spec.variants bad configuration, only promQL, podHealth, alerts and slo validations can evaluate the variants"))
+			break
+		}
+	}
+	return errs
+}
+
+func validateKanaryDeploymentSpecBlueGreen(spec *v1alpha1.KanaryDeploymentSpec) []error {
+	var errs []error
+	if spec.Traffic.Source != v1alpha1.KanaryServiceKanaryDeploymentSpecTrafficSource {
+		errs = append(errs, fmt.Errorf("spec.blueGreen bad configuration, the blue/green mode requires the 'kanary-service' traffic source, current value:%s", spec.Traffic.Source))
+	}
+	if spec.ServiceName == "" {
+		errs = append(errs, fmt.Errorf("spec.blueGreen bad configuration, the blue/green mode requires the serviceName"))
+	}
+	if spec.Promotion != nil {
+		errs = append(errs, fmt.Errorf("spec.blueGreen bad configuration, the blue/green mode and the progressive promotion can not be combined"))
+	}
+	if spec.Scale.HPA != nil {
+		errs = append(errs, fmt.Errorf("spec.blueGreen bad configuration, the blue/green mode requires the static scale"))
+	}
+	if spec.BlueGreen.RollbackWindow != nil && spec.BlueGreen.RollbackWindow.Duration < 0 {
+		errs = append(errs, fmt.Errorf("spec.blueGreen.rollbackWindow bad value, should not be negative, current value:%s", spec.BlueGreen.RollbackWindow.Duration))
+	}
+	return errs
+}
+
+func validateKanaryDeploymentSpecPromotion(p *v1alpha1.KanaryDeploymentSpecPromotion, s *v1alpha1.KanaryDeploymentSpecScale, t *v1alpha1.KanaryDeploymentSpecTraffic) []error {
+	var errs []error
+	if t.Source != v1alpha1.ServiceKanaryDeploymentSpecTrafficSource && t.Source != v1alpha1.BothKanaryDeploymentSpecTrafficSource {
+		// the Deployment is scaled down while the canary is scaled up, the canary pods must serve the production traffic
+		errs = append(errs, fmt.Errorf("spec.promotion bad configuration, the progressive promotion requires the 'service' or 'both' traffic source, current value:%s", t.Source))
+	}
+	if s.HPA != nil {
+		errs = append(errs, fmt.Errorf("spec.promotion bad configuration, the progressive promotion requires the static scale"))
+	}
+	for i, step := range p.Steps {
+		if step <= 0 || step >= 100 {
+			errs = append(errs, fmt.Errorf("spec.promotion.steps bad value, should be in ]0:100[, current value:%d", step))
+		}
+		if i > 0 && step <= p.Steps[i-1] {
+			errs = append(errs, fmt.Errorf("spec.promotion.steps bad value, should be increasing, current value:%v", p.Steps))
+		}
+	}
+	if p.StepDuration != nil && p.StepDuration.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("spec.promotion.stepDuration bad value, should be positive, current value:%s", p.StepDuration.Duration))
+	}
+	for _, v := range p.Items {
+		if v.Manual != nil || v.LabelWatch != nil || v.Job != nil || v.External != nil {
+			errs = append(errs, fmt.Errorf("spec.promotion.items bad value, only promQL, podHealth, logs, alerts and slo validations are supported"))
+			continue
+		}
+		errs = append(errs, validateKanaryDeploymentSpecValidation(&v)...)
+	}
 	return errs
 }
 
@@ -39,6 +211,15 @@ func validateKanaryDeploymentSpecTraffic(t *v1alpha1.KanaryDeploymentSpecTraffic
 		errs = append(errs, fmt.Errorf("spec.traffic bad configuration, 'mirror' configuration provived, but 'source'=%s", t.Source))
 	}
 
+	if t.LoadGenerator != nil {
+		if t.Source != v1alpha1.KanaryServiceKanaryDeploymentSpecTrafficSource && t.Source != v1alpha1.BothKanaryDeploymentSpecTrafficSource {
+			errs = append(errs, fmt.Errorf("spec.traffic bad configuration, 'loadGenerator' configuration provided, but 'source'=%s", t.Source))
+		}
+		if t.LoadGenerator.RPS != nil && (*t.LoadGenerator.RPS <= 0 || *t.LoadGenerator.RPS > v1alpha1.MaxLoadGeneratorRPS) {
+			errs = append(errs, fmt.Errorf("spec.traffic.loadGenerator.rps bad value, current value:%d", *t.LoadGenerator.RPS))
+		}
+	}
+
 	return errs
 }
 
@@ -47,17 +228,160 @@ func validateKanaryDeploymentSpecValidationList(list *v1alpha1.KanaryDeploymentS
 	if len(list.Items) == 0 {
 		return []error{fmt.Errorf("validation list is not set")}
 	}
+	// the Job name is the template name, or the KanaryDeployment name for an unnamed template
+	jobNames := map[string]bool{}
 	for _, v := range list.Items {
 		errs = append(errs, validateKanaryDeploymentSpecValidation(&v)...)
+		if v.Job != nil {
+			if jobNames[v.Job.Template.Name] {
+				errs = append(errs, fmt.Errorf("spec.validation.items bad configuration, each job item requires a different template.name, duplicated value:%q", v.Job.Template.Name))
+			}
+			jobNames[v.Job.Template.Name] = true
+		}
+	}
+	if list.Timeout != nil && list.Timeout.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("spec.validation.timeout bad value, should be positive, current value:%s", list.Timeout.Duration))
+	}
+	if list.Scoring != nil {
+		pass, marginal := list.Scoring.PassThreshold, list.Scoring.MarginalThreshold
+		if pass != nil && (*pass < 0 || *pass > 100) {
+			errs = append(errs, fmt.Errorf("spec.validation.scoring.passThreshold bad value, should be in [0:100], current value:%d", *pass))
+		}
+		if marginal != nil && (*marginal < 0 || *marginal > 100) {
+			errs = append(errs, fmt.Errorf("spec.validation.scoring.marginalThreshold bad value, should be in [0:100], current value:%d", *marginal))
+		}
+		if pass != nil && marginal != nil && *marginal > *pass {
+			errs = append(errs, fmt.Errorf("spec.validation.scoring bad configuration, marginalThreshold (%d) greater than passThreshold (%d)", *marginal, *pass))
+		}
+	}
+	if list.EarlySuccess != nil {
+		if list.EarlySuccess.ConsecutivePasses != nil && *list.EarlySuccess.ConsecutivePasses < 1 {
+			errs = append(errs, fmt.Errorf("spec.validation.earlySuccess.consecutivePasses bad value, should be at least 1, current value:%d", *list.EarlySuccess.ConsecutivePasses))
+		}
+		if list.EarlySuccess.SamplesQuery != "" {
+			if _, err := template.New("samplesQuery").Parse(list.EarlySuccess.SamplesQuery); err != nil {
+				errs = append(errs, fmt.Errorf("spec.validation.earlySuccess.samplesQuery bad value: %v", err))
+			}
+		}
+	}
+	if list.PostPromotion != nil {
+		if list.PostPromotion.Period != nil && list.PostPromotion.Period.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("spec.validation.postPromotion.period bad value, should be positive, current value:%s", list.PostPromotion.Period.Duration))
+		}
+		if len(list.PostPromotion.Items) == 0 {
+			errs = append(errs, fmt.Errorf("spec.validation.postPromotion.items not defined"))
+		}
+		for _, v := range list.PostPromotion.Items {
+			if v.Manual != nil || v.LabelWatch != nil || v.Logs != nil || v.Job != nil || v.External != nil {
+				errs = append(errs, fmt.Errorf("spec.validation.postPromotion.items bad value, only promQL, podHealth, alerts and slo validations are supported"))
+				continue
+			}
+			errs = append(errs, validateKanaryDeploymentSpecValidation(&v)...)
+		}
 	}
 	return errs
 }
 
 func validateKanaryDeploymentSpecValidation(v *v1alpha1.KanaryDeploymentSpecValidation) []error {
 	var errs []error
-	if v.Manual == nil && v.LabelWatch == nil && v.PromQL == nil {
+	if v.Weight != nil && *v.Weight < 0 {
+		errs = append(errs, fmt.Errorf("spec.validation.weight bad value, current value:%d", *v.Weight))
+	}
+	if v.Timeout != nil && v.Timeout.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("spec.validation.items.timeout bad value, should be positive, current value:%s", v.Timeout.Duration))
+	}
+	if v.ErrorPolicy != nil {
+		if v.ErrorPolicy.MaxConsecutiveErrors != nil && *v.ErrorPolicy.MaxConsecutiveErrors < 1 {
+			errs = append(errs, fmt.Errorf("spec.validation.items.errorPolicy.maxConsecutiveErrors bad value, should be at least 1, current value:%d", *v.ErrorPolicy.MaxConsecutiveErrors))
+		}
+		switch v.ErrorPolicy.Action {
+		case "", v1alpha1.FailErrorPolicyAction, v1alpha1.PassErrorPolicyAction, v1alpha1.PauseErrorPolicyAction:
+		default:
+			errs = append(errs, fmt.Errorf("spec.validation.items.errorPolicy.action bad value, should be Fail, Pass or Pause, current value:%s", v.ErrorPolicy.Action))
+		}
+	}
+	if v.Manual == nil && v.LabelWatch == nil && v.PromQL == nil && v.PodHealth == nil && v.Logs == nil && v.Job == nil && v.Alerts == nil && v.External == nil && v.SLO == nil {
 		errs = append(errs, fmt.Errorf("spec.validation not defined: %v", v))
 	}
+	if v.Logs != nil {
+		if len(v.Logs.Patterns) == 0 {
+			errs = append(errs, fmt.Errorf("spec.validation.logs.patterns not defined"))
+		}
+		for _, pattern := range v.Logs.Patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Errorf("spec.validation.logs.patterns bad value %q: %v", pattern, err))
+			}
+		}
+	}
+	if v.Job != nil && len(v.Job.Template.Spec.Template.Spec.Containers) == 0 {
+		errs = append(errs, fmt.Errorf("spec.validation.job.template.spec.template.spec.containers not defined"))
+	}
+	if v.Alerts != nil {
+		if len(v.Alerts.Matchers) == 0 {
+			errs = append(errs, fmt.Errorf("spec.validation.alerts.matchers not defined"))
+		}
+		for _, matcher := range v.Alerts.Matchers {
+			if matcher.Name == "" {
+				errs = append(errs, fmt.Errorf("spec.validation.alerts.matchers.name not defined"))
+			}
+			if _, err := template.New(matcher.Name).Parse(matcher.Value); err != nil {
+				errs = append(errs, fmt.Errorf("spec.validation.alerts.matchers bad value %q: %v", matcher.Value, err))
+			}
+		}
+	}
+	if v.External != nil {
+		if v.External.TokenSecret == "" {
+			errs = append(errs, fmt.Errorf("spec.validation.external.tokenSecret not defined"))
+		}
+		switch v.External.StatusAfterDeadline {
+		case "", v1alpha1.NoneKanaryDeploymentSpecValidationManualDeadineStatus, v1alpha1.ValidKanaryDeploymentSpecValidationManualDeadineStatus, v1alpha1.InvalidKanaryDeploymentSpecValidationManualDeadineStatus:
+		default:
+			errs = append(errs, fmt.Errorf("spec.validation.external.statusAfterDeadline bad value, current value:%s", v.External.StatusAfterDeadline))
+		}
+	}
+	if v.SLO != nil {
+		errs = append(errs, validateKanaryDeploymentSpecValidationSLO(v.SLO)...)
+	}
+	if v.PromQL != nil {
+		if v.PromQL.MinSamples != nil && *v.PromQL.MinSamples < 0 {
+			errs = append(errs, fmt.Errorf("spec.validation.promQL.minSamples bad value, current value:%d", *v.PromQL.MinSamples))
+		}
+		switch v.PromQL.NoDataPolicy {
+		case "", v1alpha1.PassNoDataPolicy, v1alpha1.FailNoDataPolicy, v1alpha1.ExtendNoDataPolicy:
+		default:
+			errs = append(errs, fmt.Errorf("spec.validation.promQL.noDataPolicy bad value, current value:%s", v.PromQL.NoDataPolicy))
+		}
+		if v.PromQL.Exclusion != nil && v.PromQL.Exclusion.LabelSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(v.PromQL.Exclusion.LabelSelector); err != nil {
+				errs = append(errs, fmt.Errorf("spec.validation.promQL.exclusion.labelSelector bad value: %v", err))
+			}
+		}
+	}
+
+	return errs
+}
 
+func validateKanaryDeploymentSpecValidationSLO(slo *v1alpha1.KanaryDeploymentSpecValidationSLO) []error {
+	var errs []error
+	queries := []struct{ name, query string }{{"goodEventsQuery", slo.GoodEventsQuery}, {"totalEventsQuery", slo.TotalEventsQuery}}
+	for _, q := range queries {
+		name, query := q.name, q.query
+		if query == "" {
+			errs = append(errs, fmt.Errorf("spec.validation.slo.%s not defined", name))
+		} else if _, err := template.New(name).Parse(query); err != nil {
+			errs = append(errs, fmt.Errorf("spec.validation.slo.%s bad value %q: %v", name, query, err))
+		}
+	}
+	if slo.Objective == nil || *slo.Objective <= 0 || *slo.Objective >= 100 {
+		errs = append(errs, fmt.Errorf("spec.validation.slo.objective should be between 0 and 100 excluded"))
+	}
+	for _, w := range slo.Windows {
+		if w.ShortWindow.Duration <= 0 || w.LongWindow.Duration <= w.ShortWindow.Duration {
+			errs = append(errs, fmt.Errorf("spec.validation.slo.windows bad value, the shortWindow (%s) should be shorter than the longWindow (%s)", w.ShortWindow.Duration, w.LongWindow.Duration))
+		}
+		if w.BurnRateFactor == nil || *w.BurnRateFactor <= 0 {
+			errs = append(errs, fmt.Errorf("spec.validation.slo.windows.burnRateFactor should be positive"))
+		}
+	}
 	return errs
 }
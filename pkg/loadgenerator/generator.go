@@ -0,0 +1,139 @@
+package loadgenerator
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// transportErrorCode code label value used when no response was received
+	transportErrorCode = "error"
+)
+
+// Generator sends the configured requests to a target at a fixed rate, and records the latency and errors metrics
+type Generator struct {
+	target string
+	spec   *kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator
+	client *http.Client
+
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// NewGenerator returns new Generator instance, its metrics are registered in the registerer
+func NewGenerator(target, kanaryName string, spec *kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator, registerer prometheus.Registerer) (*Generator, error) {
+	if spec.RPS == nil || *spec.RPS <= 0 {
+		return nil, fmt.Errorf("rps should be greater than 0")
+	}
+	if *spec.RPS > kanaryv1alpha1.MaxLoadGeneratorRPS {
+		return nil, fmt.Errorf("rps should not be greater than %d", kanaryv1alpha1.MaxLoadGeneratorRPS)
+	}
+	if len(spec.Requests) == 0 {
+		return nil, fmt.Errorf("requests list is empty")
+	}
+
+	constLabels := prometheus.Labels{"kanary_name": kanaryName}
+	g := &Generator{
+		target: strings.TrimSuffix(target, "/"),
+		spec:   spec,
+		client: &http.Client{Timeout: requestTimeout},
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "kanary_loadgenerator_requests_total",
+			Help:        "Number of requests sent by the load generator, by response code.",
+			ConstLabels: constLabels,
+		}, []string{"method", "path", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "kanary_loadgenerator_errors_total",
+			Help:        "Number of requests that failed (no response or 5xx response code).",
+			ConstLabels: constLabels,
+		}, []string{"method", "path"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "kanary_loadgenerator_request_duration_seconds",
+			Help:        "Requests latency in seconds.",
+			ConstLabels: constLabels,
+			Buckets:     prometheus.DefBuckets,
+		}, []string{"method", "path"}),
+	}
+
+	for _, c := range []prometheus.Collector{g.requests, g.errors, g.latency} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Run sends the requests until the stop channel is closed or the configured duration is over
+func (g *Generator) Run(stop <-chan struct{}) {
+	var deadline <-chan time.Time
+	if g.spec.Duration != nil {
+		timer := time.NewTimer(g.spec.Duration.Duration)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(*g.spec.RPS))
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for i := 0; ; i++ {
+		select {
+		case <-stop:
+			return
+		case <-deadline:
+			return
+		case <-ticker.C:
+			wg.Add(1)
+			go func(r *kanaryv1alpha1.LoadGeneratorRequest) {
+				defer wg.Done()
+				g.send(r)
+			}(&g.spec.Requests[i%len(g.spec.Requests)])
+		}
+	}
+}
+
+// send sends a request and records its metrics
+func (g *Generator) send(r *kanaryv1alpha1.LoadGeneratorRequest) {
+	var body io.Reader
+	if r.Body != "" {
+		body = strings.NewReader(r.Body)
+	}
+	req, err := http.NewRequest(r.Method, g.target+r.Path, body)
+	if err != nil {
+		g.requests.WithLabelValues(r.Method, r.Path, transportErrorCode).Inc()
+		g.errors.WithLabelValues(r.Method, r.Path).Inc()
+		return
+	}
+	for key, value := range g.spec.Headers {
+		req.Header.Set(key, value)
+	}
+
+	start := time.Now()
+	resp, err := g.client.Do(req)
+	if err != nil {
+		g.requests.WithLabelValues(r.Method, r.Path, transportErrorCode).Inc()
+		g.errors.WithLabelValues(r.Method, r.Path).Inc()
+		return
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	g.latency.WithLabelValues(r.Method, r.Path).Observe(time.Since(start).Seconds())
+
+	g.requests.WithLabelValues(r.Method, r.Path, strconv.Itoa(resp.StatusCode)).Inc()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		g.errors.WithLabelValues(r.Method, r.Path).Inc()
+	}
+}
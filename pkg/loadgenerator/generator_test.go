@@ -0,0 +1,161 @@
+package loadgenerator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+)
+
+func newTestServer(received *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(received, 1)
+		if r.Header.Get("X-Test") != "kanary" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// gatherCounter returns the sum of a counter metric values
+func gatherCounter(t *testing.T, registry *prometheus.Registry, name string) float64 {
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unable to gather metrics: %v", err)
+	}
+	var sum float64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			sum += m.GetCounter().GetValue()
+		}
+	}
+	return sum
+}
+
+func TestGenerator_send(t *testing.T) {
+	var received int32
+	server := newTestServer(&received)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		request      kanaryv1alpha1.LoadGeneratorRequest
+		wantRequests float64
+		wantErrors   float64
+	}{
+		{
+			name:         "request succeeded",
+			request:      kanaryv1alpha1.LoadGeneratorRequest{Method: "GET", Path: "/"},
+			wantRequests: 1,
+			wantErrors:   0,
+		},
+		{
+			name:         "5xx response",
+			request:      kanaryv1alpha1.LoadGeneratorRequest{Method: "POST", Path: "/fail", Body: "{}"},
+			wantRequests: 1,
+			wantErrors:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			spec := &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{
+				RPS:      kanaryv1alpha1.NewInt32(1),
+				Requests: []kanaryv1alpha1.LoadGeneratorRequest{tt.request},
+				Headers:  map[string]string{"X-Test": "kanary"},
+			}
+			g, err := NewGenerator(server.URL, "foo", spec, registry)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			g.send(&spec.Requests[0])
+			if got := gatherCounter(t, registry, "kanary_loadgenerator_requests_total"); got != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", got, tt.wantRequests)
+			}
+			if got := gatherCounter(t, registry, "kanary_loadgenerator_errors_total"); got != tt.wantErrors {
+				t.Errorf("errors = %v, want %v", got, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestGenerator_Run(t *testing.T) {
+	var received int32
+	server := newTestServer(&received)
+	defer server.Close()
+
+	spec := &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{
+		RPS:      kanaryv1alpha1.NewInt32(100),
+		Duration: &metav1.Duration{Duration: 200 * time.Millisecond},
+		Requests: []kanaryv1alpha1.LoadGeneratorRequest{{Method: "GET", Path: "/"}},
+		Headers:  map[string]string{"X-Test": "kanary"},
+	}
+	g, err := NewGenerator(server.URL, "foo", spec, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		g.Run(make(chan struct{}))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Generator.Run() didn't stop after the configured duration")
+	}
+	if atomic.LoadInt32(&received) == 0 {
+		t.Errorf("Generator.Run() didn't send any request")
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator
+		wantErr bool
+	}{
+		{
+			name:    "rps not set",
+			spec:    &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{Requests: []kanaryv1alpha1.LoadGeneratorRequest{{Method: "GET", Path: "/"}}},
+			wantErr: true,
+		},
+		{
+			name:    "rps too high",
+			spec:    &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{RPS: kanaryv1alpha1.NewInt32(2000000000), Requests: []kanaryv1alpha1.LoadGeneratorRequest{{Method: "GET", Path: "/"}}},
+			wantErr: true,
+		},
+		{
+			name:    "no request",
+			spec:    &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{RPS: kanaryv1alpha1.NewInt32(10)},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			spec: &kanaryv1alpha1.KanaryDeploymentSpecTrafficLoadGenerator{RPS: kanaryv1alpha1.NewInt32(10), Requests: []kanaryv1alpha1.LoadGeneratorRequest{{Method: "GET", Path: "/"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator("http://foo", "foo", tt.spec, prometheus.NewRegistry())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -21,7 +21,7 @@ import (
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
-	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 
 	"github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
 	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
@@ -201,7 +201,7 @@ func (o *generateOptions) Run() error {
 		o.userName = o.userDeploymentName
 	}
 
-	dep := &appsv1beta1.Deployment{}
+	dep := &appsv1.Deployment{}
 	err := o.client.Get(context.TODO(), client.ObjectKey{Name: o.userDeploymentName, Namespace: o.userNamespace}, dep)
 	if err != nil && errors.IsNotFound(err) {
 		return fmt.Errorf("deployment %s/%s didn't exist", o.userNamespace, o.userDeploymentName)
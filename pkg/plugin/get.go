@@ -140,7 +140,7 @@ func (o *GetOptions) Run() error {
 
 	table := newTable(o.Out)
 	for _, item := range kanaryList.Items {
-		data := []string{item.Namespace, item.Name, getStatus(&item), item.Spec.DeploymentName, item.Spec.ServiceName, getScale(&item), getTraffic(&item), getValidation(&item), getDuration(&item)}
+		data := []string{item.Namespace, item.Name, getStatus(&item), getRollout(&item), item.Spec.DeploymentName, item.Spec.ServiceName, getScale(&item), getTraffic(&item), getValidation(&item), getDuration(&item)}
 		table.Append(data)
 	}
 
@@ -164,6 +164,13 @@ func getStatus(kd *v1alpha1.KanaryDeployment) string {
 	return kd.Status.Report.Status
 }
 
+func getRollout(kd *v1alpha1.KanaryDeployment) string {
+	if kd.Status.Report.Rollout == "" {
+		return "-"
+	}
+	return kd.Status.Report.Rollout
+}
+
 func getDuration(kd *v1alpha1.KanaryDeployment) string {
 	duration := time.Duration(0)
 	if kd.Spec.Validations.InitialDelay != nil {
@@ -172,13 +179,24 @@ func getDuration(kd *v1alpha1.KanaryDeployment) string {
 	if kd.Spec.Validations.ValidationPeriod != nil {
 		duration += kd.Spec.Validations.ValidationPeriod.Duration
 	}
-	since := time.Since(kd.ObjectMeta.CreationTimestamp.Time)
+	// elapsed time of the validation timeline, since the run start and without the pauses
+	start := kd.ObjectMeta.CreationTimestamp.Time
+	if kd.Status.StartTime != nil {
+		start = kd.Status.StartTime.Time
+	}
+	since := time.Since(start)
+	if kd.Status.PausedDuration != nil {
+		since -= kd.Status.PausedDuration.Duration
+	}
+	if kd.Status.PauseTime != nil {
+		since -= time.Since(kd.Status.PauseTime.Time)
+	}
 	return fmt.Sprintf("%s/%s", since, duration)
 }
 
 func newTable(out io.Writer) *tablewriter.Table {
 	table := tablewriter.NewWriter(out)
-	table.SetHeader([]string{"Namespace", "Name", "Status", "Deployment", "Service", "Scale", "Traffic", "Validation", "Duration"})
+	table.SetHeader([]string{"Namespace", "Name", "Status", "Rollout", "Deployment", "Service", "Scale", "Traffic", "Validation", "Duration"})
 	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 	table.SetRowLine(false)
@@ -0,0 +1,209 @@
+package verdict
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	"github.com/amadeusitgroup/kanary/pkg/config"
+	"github.com/amadeusitgroup/kanary/pkg/controller/kanarydeployment/utils"
+)
+
+const (
+	// PathPrefix prefix of the verdict endpoint path: /verdicts/<namespace>/<kanarydeployment>
+	PathPrefix = "/verdicts/"
+	// TokenSecretKey key of the bearer token in the external validation TokenSecret
+	TokenSecretKey = "token"
+
+	maxBodyBytes    = 64 * 1024
+	shutdownTimeout = 5 * time.Second
+)
+
+var log = logf.Log.WithName("verdict")
+
+// errUnauthorized returned for all the authentication failures
+var errUnauthorized = fmt.Errorf("unauthorized")
+
+// Request body of a verdict request
+type Request struct {
+	Verdict kanaryv1alpha1.ExternalVerdict `json:"verdict"`
+	Reason  string                         `json:"reason,omitempty"`
+}
+
+// Server HTTP server receiving the verdicts of external systems (CI pipelines, QA tools...)
+// for the KanaryDeployments having an external validation
+type Server struct {
+	addr                string
+	certFile            string
+	keyFile             string
+	kclient             client.Client
+	secretReader        client.Reader
+	subResourceDisabled bool
+	logger              logr.Logger
+}
+
+// NewServer returns new verdict Server instance. The endpoint is served over TLS if certFile and keyFile are set.
+// The token Secrets are read with the secretReader, that should not be backed by a cache to only get the needed Secrets.
+func NewServer(addr, certFile, keyFile string, kclient client.Client, secretReader client.Reader) *Server {
+	return &Server{
+		addr:                addr,
+		certFile:            certFile,
+		keyFile:             keyFile,
+		kclient:             kclient,
+		secretReader:        secretReader,
+		subResourceDisabled: os.Getenv(config.KanaryStatusSubresourceDisabledEnvVar) == "1",
+		logger:              log,
+	}
+}
+
+// Start implements manager.Runnable, it serves the verdict endpoint until the stop channel is closed
+func (s *Server) Start(stop <-chan struct{}) error {
+	mux := http.NewServeMux()
+	mux.Handle(PathPrefix, s)
+	server := &http.Server{Addr: s.addr, Handler: mux}
+
+	errChan := make(chan error, 1)
+	go func() {
+		if s.certFile != "" && s.keyFile != "" {
+			s.logger.Info("Starting the verdict server", "addr", s.addr, "tls", true)
+			errChan <- server.ListenAndServeTLS(s.certFile, s.keyFile)
+			return
+		}
+		s.logger.Info("Starting the verdict server without TLS, it should only be reachable from the cluster", "addr", s.addr)
+		errChan <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-stop:
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(ctx)
+	}
+}
+
+// ServeHTTP records the verdict posted for a KanaryDeployment in its status
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, PathPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, fmt.Sprintf("path should be %s<namespace>/<kanarydeployment>", PathPrefix), http.StatusNotFound)
+		return
+	}
+	key := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	reqLogger := s.logger.WithValues("Request.Namespace", key.Namespace, "Request.Name", key.Name)
+
+	// the same reply whatever the reason, to not disclose the KanaryDeployments to unauthenticated callers
+	kd, err := s.authenticate(r, key)
+	if err == errUnauthorized {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		reqLogger.Error(err, "unable to authenticate the verdict request")
+		http.Error(w, "unable to authenticate the verdict request", http.StatusInternalServerError)
+		return
+	}
+
+	verdict := &Request{}
+	if err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(verdict); err != nil {
+		http.Error(w, fmt.Sprintf("unable to decode the verdict: %v", err), http.StatusBadRequest)
+		return
+	}
+	switch verdict.Verdict {
+	case kanaryv1alpha1.PassExternalVerdict, kanaryv1alpha1.FailExternalVerdict, kanaryv1alpha1.InconclusiveExternalVerdict:
+	default:
+		http.Error(w, fmt.Sprintf("bad verdict %q, should be %s, %s or %s", verdict.Verdict, kanaryv1alpha1.PassExternalVerdict, kanaryv1alpha1.FailExternalVerdict, kanaryv1alpha1.InconclusiveExternalVerdict), http.StatusBadRequest)
+		return
+	}
+
+	if utils.IsKanaryDeploymentValidationCompleted(&kd.Status) {
+		http.Error(w, "KanaryDeployment validation already completed", http.StatusConflict)
+		return
+	}
+
+	updatedKd := kd.DeepCopy()
+	updatedKd.Status.ExternalVerdict = &kanaryv1alpha1.KanaryDeploymentStatusExternalVerdict{
+		Verdict: verdict.Verdict,
+		Reason:  verdict.Reason,
+		Time:    metav1.Now(),
+	}
+	var statusWriter client.StatusWriter = s.kclient
+	if !s.subResourceDisabled {
+		statusWriter = s.kclient.Status()
+	}
+	if err = statusWriter.Update(context.TODO(), updatedKd); err != nil {
+		if errors.IsConflict(err) {
+			http.Error(w, "KanaryDeployment modified concurrently, retry", http.StatusConflict)
+			return
+		}
+		reqLogger.Error(err, "unable to update the KanaryDeployment status")
+		http.Error(w, "unable to record the verdict", http.StatusInternalServerError)
+		return
+	}
+
+	reqLogger.Info("Verdict recorded", "verdict", verdict.Verdict, "reason", verdict.Reason)
+	w.WriteHeader(http.StatusOK)
+}
+
+// authenticate checks the request bearer token against the token stored in the Secret of the KanaryDeployment external validation,
+// it returns errUnauthorized if the KanaryDeployment, its external validation or the token Secret are not found.
+func (s *Server) authenticate(r *http.Request, key types.NamespacedName) (*kanaryv1alpha1.KanaryDeployment, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return nil, errUnauthorized
+	}
+
+	kd := &kanaryv1alpha1.KanaryDeployment{}
+	if err := s.kclient.Get(context.TODO(), key, kd); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errUnauthorized
+		}
+		return nil, fmt.Errorf("unable to get the KanaryDeployment: %v", err)
+	}
+	external := getExternalValidation(kd)
+	if external == nil {
+		return nil, errUnauthorized
+	}
+
+	secret := &corev1.Secret{}
+	if err := s.secretReader.Get(context.TODO(), types.NamespacedName{Namespace: kd.Namespace, Name: external.TokenSecret}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errUnauthorized
+		}
+		return nil, fmt.Errorf("unable to get the token secret: %v", err)
+	}
+	expected := secret.Data[TokenSecretKey]
+	if len(expected) == 0 || subtle.ConstantTimeCompare(expected, []byte(token)) != 1 {
+		return nil, errUnauthorized
+	}
+	return kd, nil
+}
+
+func getExternalValidation(kd *kanaryv1alpha1.KanaryDeployment) *kanaryv1alpha1.KanaryDeploymentSpecValidationExternal {
+	for _, v := range kd.Spec.Validations.Items {
+		if v.External != nil {
+			return v.External
+		}
+	}
+	return nil
+}
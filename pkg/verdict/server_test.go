@@ -0,0 +1,178 @@
+package verdict
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kanaryv1alpha1 "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1"
+	kanaryv1alpha1test "github.com/amadeusitgroup/kanary/pkg/apis/kanary/v1alpha1/test"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServer_ServeHTTP(t *testing.T) {
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(kanaryv1alpha1.SchemeGroupVersion, &kanaryv1alpha1.KanaryDeployment{})
+
+	var (
+		name            = "foo"
+		namespace       = "kanary"
+		defaultReplicas = int32(5)
+		path            = PathPrefix + namespace + "/" + name
+
+		externalValidation = &kanaryv1alpha1.KanaryDeploymentSpecValidationList{
+			Items: []kanaryv1alpha1.KanaryDeploymentSpecValidation{
+				{External: &kanaryv1alpha1.KanaryDeploymentSpecValidationExternal{TokenSecret: "foo-verdict"}},
+			},
+		}
+		tokenSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-verdict", Namespace: namespace},
+			Data:       map[string][]byte{TokenSecretKey: []byte("s3cr3t")},
+		}
+		succeeded = &kanaryv1alpha1.KanaryDeploymentStatus{
+			Conditions: []kanaryv1alpha1.KanaryDeploymentCondition{
+				{Type: kanaryv1alpha1.SucceededKanaryDeploymentConditionType, Status: corev1.ConditionTrue},
+			},
+		}
+	)
+	newKD := func(validations *kanaryv1alpha1.KanaryDeploymentSpecValidationList, status *kanaryv1alpha1.KanaryDeploymentStatus) *kanaryv1alpha1.KanaryDeployment {
+		return kanaryv1alpha1test.NewKanaryDeployment(name, namespace, "", defaultReplicas, &kanaryv1alpha1test.NewKanaryDeploymentOptions{Validations: validations, Status: status})
+	}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		method      string
+		path        string
+		token       string
+		body        string
+		wantStatus  int
+		wantVerdict *kanaryv1alpha1.KanaryDeploymentStatusExternalVerdict
+	}{
+		{
+			name:       "method not allowed",
+			objects:    []runtime.Object{newKD(externalValidation, nil), tokenSecret},
+			method:     http.MethodGet,
+			path:       path,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "KanaryDeployment not found",
+			objects:    []runtime.Object{tokenSecret},
+			method:     http.MethodPost,
+			path:       path,
+			token:      "s3cr3t",
+			body:       `{"verdict":"pass"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no external validation",
+			objects:    []runtime.Object{newKD(nil, nil), tokenSecret},
+			method:     http.MethodPost,
+			path:       path,
+			token:      "s3cr3t",
+			body:       `{"verdict":"pass"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token secret not found",
+			objects:    []runtime.Object{newKD(externalValidation, nil)},
+			method:     http.MethodPost,
+			path:       path,
+			token:      "s3cr3t",
+			body:       `{"verdict":"pass"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing token",
+			objects:    []runtime.Object{newKD(externalValidation, nil), tokenSecret},
+			method:     http.MethodPost,
+			path:       path,
+			body:       `{"verdict":"pass"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "bad token",
+			objects:    []runtime.Object{newKD(externalValidation, nil), tokenSecret},
+			method:     http.MethodPost,
+			path:       path,
+			token:      "guess",
+			body:       `{"verdict":"pass"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "bad verdict",
+			objects:    []runtime.Object{newKD(externalValidation, nil), tokenSecret},
+			method:     http.MethodPost,
+			path:       path,
+			token:      "s3cr3t",
+			body:       `{"verdict":"maybe"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "validation already completed",
+			objects:    []runtime.Object{newKD(externalValidation, succeeded), tokenSecret},
+			method:     http.MethodPost,
+			path:       path,
+			token:      "s3cr3t",
+			body:       `{"verdict":"fail"}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:        "verdict recorded",
+			objects:     []runtime.Object{newKD(externalValidation, nil), tokenSecret},
+			method:      http.MethodPost,
+			path:        path,
+			token:       "s3cr3t",
+			body:        `{"verdict":"fail","reason":"e2e tests failed"}`,
+			wantStatus:  http.StatusOK,
+			wantVerdict: &kanaryv1alpha1.KanaryDeploymentStatusExternalVerdict{Verdict: kanaryv1alpha1.FailExternalVerdict, Reason: "e2e tests failed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kclient := fake.NewFakeClient(tt.objects...)
+			server := NewServer(":0", "", "", kclient, kclient)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Server.ServeHTTP() status = %d, want %d, body: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantVerdict != nil {
+				if err := checkVerdict(kclient, namespace, name, tt.wantVerdict); err != nil {
+					t.Errorf("Server.ServeHTTP() %v", err)
+				}
+			}
+		})
+	}
+}
+
+func checkVerdict(kclient client.Client, namespace, name string, want *kanaryv1alpha1.KanaryDeploymentStatusExternalVerdict) error {
+	kd := &kanaryv1alpha1.KanaryDeployment{}
+	if err := kclient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, kd); err != nil {
+		return err
+	}
+	got := kd.Status.ExternalVerdict
+	if got == nil || got.Verdict != want.Verdict || got.Reason != want.Reason || got.Time.IsZero() {
+		return fmt.Errorf("verdict = %v, want %v", got, want)
+	}
+	return nil
+}
@@ -4,7 +4,7 @@ import (
 	goctx "context"
 	"fmt"
 
-	appsv1 "k8s.io/api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"